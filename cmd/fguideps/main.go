@@ -0,0 +1,135 @@
+// Command fguideps 分析目录中 .fui 包之间的资源引用关系。
+//
+// 报告缺失的引用目标、相互引用的包和组件、不被任何导出资源使用的资源，
+// 以及只被未使用资源占用的图集页。存在缺失引用或循环引用时以状态码 1 退出，
+// 加上 -strict 后存在未使用资源也视为失败，便于在 CI 中检查发布结果。
+//
+// 用法：
+//
+//	fguideps [-strict] [dir]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+)
+
+func main() {
+	strict := flag.Bool("strict", false, "treat unused items and atlas pages as failures")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: fguideps [-strict] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	pkgs, err := loadPackages(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fguideps:", err)
+		os.Exit(2)
+	}
+	report := assets.AnalyzeDependencies(pkgs)
+	printReport(os.Stdout, report)
+
+	failed := len(report.Missing) > 0 || len(report.PackageCycles) > 0 || len(report.ComponentCycles) > 0 || len(report.Errors) > 0
+	if *strict && (len(report.Unused) > 0 || len(report.UnusedAtlases) > 0) {
+		failed = true
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func loadPackages(dir string) ([]*assets.Package, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.fui"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .fui files in %s", dir)
+	}
+	sort.Strings(files)
+	pkgs := make([]*assets.Package, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pkg, err := assets.ParsePackage(data, strings.TrimSuffix(file, ".fui"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func printReport(w io.Writer, report *assets.DependencyReport) {
+	refs := 0
+	for _, list := range report.References {
+		refs += len(list)
+	}
+	fmt.Fprintf(w, "%d packages, %d references\n", len(report.Packages), refs)
+
+	for _, err := range report.Errors {
+		fmt.Fprintf(w, "error: %v\n", err)
+	}
+
+	section(w, "missing references", len(report.Missing))
+	for _, ref := range report.Missing {
+		fmt.Fprintf(w, "  %s\n", ref)
+	}
+
+	section(w, "package cycles", len(report.PackageCycles))
+	for _, cycle := range report.PackageCycles {
+		names := make([]string, len(cycle))
+		for i, pkg := range cycle {
+			names[i] = pkg.Name
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(names, " <-> "))
+	}
+
+	section(w, "component cycles", len(report.ComponentCycles))
+	for _, cycle := range report.ComponentCycles {
+		names := make([]string, len(cycle))
+		for i, item := range cycle {
+			names[i] = itemName(item)
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(names, " <-> "))
+	}
+
+	section(w, "unused items", len(report.Unused))
+	for _, item := range report.Unused {
+		fmt.Fprintf(w, "  %s (%s)\n", itemName(item), item.ID)
+	}
+
+	section(w, "unused atlas pages", len(report.UnusedAtlases))
+	for _, item := range report.UnusedAtlases {
+		fmt.Fprintf(w, "  %s/%s\n", item.Owner.Name, item.ID)
+	}
+}
+
+func section(w io.Writer, title string, count int) {
+	fmt.Fprintf(w, "\n%s: %d\n", title, count)
+}
+
+func itemName(item *assets.PackageItem) string {
+	path := item.Path
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return item.Owner.Name + path + item.Name
+}
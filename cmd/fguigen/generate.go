@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+)
+
+// Options 控制代码生成
+type Options struct {
+	// Package 是生成文件的 Go 包名
+	Package string
+	// IgnoreNoname 跳过编辑器默认命名（n1、c1、t1）的子对象、控制器和动效，与编辑器的同名导出选项一致
+	IgnoreNoname bool
+}
+
+// File 是生成的 Go 源文件
+type File struct {
+	Name   string
+	Source []byte
+}
+
+type fieldKind int
+
+const (
+	fieldChild fieldKind = iota
+	fieldNested
+	fieldController
+	fieldTransition
+)
+
+// field 是绑定结构体中的一个字段
+type field struct {
+	name string
+	typ  string
+	// src 是子对象、控制器或动效在组件中的名称
+	src  string
+	kind fieldKind
+}
+
+// binding 是一个导出组件的绑定结构体
+type binding struct {
+	typeName string
+	pkg      *assets.Package
+	item     *assets.PackageItem
+	root     string
+	fields   []field
+}
+
+type generator struct {
+	opts     Options
+	byID     map[string]*assets.Package
+	bindings map[*assets.PackageItem]*binding
+	types    map[string]bool
+}
+
+var defaultNames = map[fieldKind]*regexp.Regexp{
+	fieldChild:      regexp.MustCompile(`^n\d+$`),
+	fieldController: regexp.MustCompile(`^c\d+$`),
+	fieldTransition: regexp.MustCompile(`^t\d+$`),
+}
+
+// Generate 为 pkgs 中的导出组件生成绑定代码，每个包含导出组件的包生成一个文件。
+// 所有文件属于同一个 Go 包，结构体名称冲突时加上包名前缀
+func Generate(pkgs []*assets.Package, opts Options) ([]File, error) {
+	if opts.Package == "" {
+		opts.Package = "ui"
+	}
+	g := &generator{
+		opts:     opts,
+		byID:     make(map[string]*assets.Package),
+		bindings: make(map[*assets.PackageItem]*binding),
+		types:    make(map[string]bool),
+	}
+	var order []*binding
+	for _, pkg := range pkgs {
+		g.byID[pkg.ID] = pkg
+		for _, item := range pkg.Items {
+			if item.Type != assets.PackageItemTypeComponent || item.Component == nil || !item.Exported {
+				continue
+			}
+			b := &binding{typeName: g.typeName(pkg, item), pkg: pkg, item: item, root: widgetType(item.ObjectType)}
+			g.bindings[item] = b
+			order = append(order, b)
+		}
+	}
+	for _, b := range order {
+		g.collectFields(b)
+	}
+
+	var files []File
+	for _, pkg := range pkgs {
+		var list []*binding
+		for _, b := range order {
+			if b.pkg == pkg {
+				list = append(list, b)
+			}
+		}
+		if len(list) == 0 {
+			continue
+		}
+		src, err := g.render(pkg, list)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pkg.Name, err)
+		}
+		files = append(files, File{Name: strings.ToLower(exportedName(pkg.Name)) + "_binding.go", Source: src})
+	}
+	return files, nil
+}
+
+// typeName 返回组件的结构体名称，与已生成的名称冲突时加上包名前缀或序号
+func (g *generator) typeName(pkg *assets.Package, item *assets.PackageItem) string {
+	base := exportedName(item.Name)
+	name := base
+	if g.types[name] {
+		base = exportedName(pkg.Name) + base
+		name = base
+	}
+	for i := 2; g.types[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.types[name] = true
+	g.types[name+"URL"] = true
+	return name
+}
+
+func (g *generator) collectFields(b *binding) {
+	names := map[string]bool{"Bind": true, b.root[strings.LastIndex(b.root, ".")+1:]: true}
+	add := func(f field, suffix string) {
+		if f.src == "" || g.opts.IgnoreNoname && defaultNames[f.kind] != nil && defaultNames[f.kind].MatchString(f.src) {
+			return
+		}
+		base := exportedName(f.src) + suffix
+		f.name = base
+		for i := 2; names[f.name]; i++ {
+			f.name = base + strconv.Itoa(i)
+		}
+		names[f.name] = true
+		b.fields = append(b.fields, f)
+	}
+
+	// GetChild 只能取得同名子对象中的第一个，后面的同名子对象不生成字段
+	seen := make(map[string]bool)
+	for i := range b.item.Component.Children {
+		child := &b.item.Component.Children[i]
+		if seen[child.Name] {
+			continue
+		}
+		seen[child.Name] = true
+		f := field{src: child.Name, kind: fieldChild}
+		target := g.childItem(b.pkg, child)
+		if nested := g.bindings[target]; nested != nil {
+			f.kind, f.typ = fieldNested, nested.typeName
+		} else if target != nil && target.Type == assets.PackageItemTypeComponent {
+			f.typ = widgetType(target.ObjectType)
+		} else {
+			f.typ = widgetType(child.Type)
+		}
+		add(f, "")
+	}
+	for _, ctrl := range b.item.Component.Controllers {
+		add(field{src: ctrl.Name, typ: "*core.Controller", kind: fieldController}, "Ctrl")
+	}
+	for _, name := range b.item.Component.TransitionNames {
+		add(field{src: name, typ: "*core.Transition", kind: fieldTransition}, "Trans")
+	}
+}
+
+// childItem 返回子对象引用的资源，引用的包没有一同加载时返回 nil
+func (g *generator) childItem(pkg *assets.Package, child *assets.ComponentChild) *assets.PackageItem {
+	if child.Src == "" {
+		return nil
+	}
+	if child.PackageID != "" && child.PackageID != pkg.ID {
+		pkg = g.byID[child.PackageID]
+		if pkg == nil {
+			return nil
+		}
+	}
+	if item := pkg.ItemByID(child.Src); item != nil {
+		return item
+	}
+	return pkg.ItemByName(child.Src)
+}
+
+func (g *generator) render(pkg *assets.Package, list []*binding) ([]byte, error) {
+	usesWidgets := false
+	for _, b := range list {
+		usesWidgets = usesWidgets || strings.HasPrefix(b.root, "*widgets.")
+		for _, f := range b.fields {
+			usesWidgets = usesWidgets || strings.HasPrefix(f.typ, "*widgets.")
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by fguigen from %s.fui. DO NOT EDIT.\n\n", pkg.Name)
+	fmt.Fprintf(&buf, "package %s\n\n", g.opts.Package)
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"github.com/chslink/fairygui/pkg/fgui/binding\"\n")
+	buf.WriteString("\t\"github.com/chslink/fairygui/pkg/fgui/core\"\n")
+	if usesWidgets {
+		buf.WriteString("\t\"github.com/chslink/fairygui/pkg/fgui/widgets\"\n")
+	}
+	buf.WriteString(")\n")
+
+	for _, b := range list {
+		path := pkg.Name + "/" + b.item.Name
+		fmt.Fprintf(&buf, "\n// %sURL 是组件 %s 的资源地址\n", b.typeName, path)
+		fmt.Fprintf(&buf, "const %sURL = %q\n", b.typeName, "ui://"+pkg.ID+b.item.ID)
+
+		fmt.Fprintf(&buf, "\n// %s 是组件 %s 的绑定\n", b.typeName, path)
+		fmt.Fprintf(&buf, "type %s struct {\n\t%s\n", b.typeName, b.root)
+		if len(b.fields) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, f := range b.fields {
+			typ := f.typ
+			if f.kind == fieldNested {
+				typ = "*" + typ
+			}
+			fmt.Fprintf(&buf, "\t%s %s\n", f.name, typ)
+		}
+		buf.WriteString("}\n")
+
+		fmt.Fprintf(&buf, "\n// Bind 绑定 comp 的子对象、控制器和动效，结构与 %s 不一致时返回错误\n", path)
+		fmt.Fprintf(&buf, "func (c *%s) Bind(comp *core.GComponent) error {\n", b.typeName)
+		fmt.Fprintf(&buf, "\tb := binding.New(%q, comp)\n", path)
+		fmt.Fprintf(&buf, "\tc.%s = binding.Root[%s](b)\n", b.root[strings.LastIndex(b.root, ".")+1:], b.root)
+		for _, f := range b.fields {
+			switch f.kind {
+			case fieldChild:
+				fmt.Fprintf(&buf, "\tc.%s = binding.Child[%s](b, %q)\n", f.name, f.typ, f.src)
+			case fieldNested:
+				fmt.Fprintf(&buf, "\tc.%s = binding.Nested[%s](b, %q)\n", f.name, f.typ, f.src)
+			case fieldController:
+				fmt.Fprintf(&buf, "\tc.%s = b.Controller(%q)\n", f.name, f.src)
+			case fieldTransition:
+				fmt.Fprintf(&buf, "\tc.%s = b.Transition(%q)\n", f.name, f.src)
+			}
+		}
+		buf.WriteString("\treturn b.Err()\n}\n")
+	}
+	return format.Source(buf.Bytes())
+}
+
+// widgetType 返回对象类型在运行时对应的控件类型，与 widgets.CreateWidget 一致
+func widgetType(t assets.ObjectType) string {
+	switch t {
+	case assets.ObjectTypeImage:
+		return "*widgets.GImage"
+	case assets.ObjectTypeMovieClip:
+		return "*widgets.GMovieClip"
+	case assets.ObjectTypeGraph:
+		return "*widgets.GGraph"
+	case assets.ObjectTypeLoader:
+		return "*widgets.GLoader"
+	case assets.ObjectTypeGroup:
+		return "*widgets.GGroup"
+	case assets.ObjectTypeText:
+		return "*widgets.GTextField"
+	case assets.ObjectTypeRichText:
+		return "*widgets.GRichTextField"
+	case assets.ObjectTypeInputText:
+		return "*widgets.GTextInput"
+	case assets.ObjectTypeList:
+		return "*widgets.GList"
+	case assets.ObjectTypeLabel:
+		return "*widgets.GLabel"
+	case assets.ObjectTypeButton:
+		return "*widgets.GButton"
+	case assets.ObjectTypeComboBox:
+		return "*widgets.GComboBox"
+	case assets.ObjectTypeProgressBar:
+		return "*widgets.GProgressBar"
+	case assets.ObjectTypeSlider:
+		return "*widgets.GSlider"
+	case assets.ObjectTypeScrollBar:
+		return "*widgets.GScrollBar"
+	case assets.ObjectTypeTree:
+		return "*widgets.GTree"
+	case assets.ObjectTypeComponent:
+		return "*core.GComponent"
+	default:
+		return "*core.GObject"
+	}
+}
+
+// exportedName 将资源名称转换为导出的 Go 标识符：非字母数字字符作为分词符，
+// 每个词首字母大写；不以大写字母开头时加上 X 前缀
+func exportedName(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	s := sb.String()
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			return s
+		}
+		break
+	}
+	return "X" + s
+}
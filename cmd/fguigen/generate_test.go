@@ -0,0 +1,88 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generateDemo(t *testing.T, opts Options, names ...string) map[string]string {
+	t.Helper()
+	var paths []string
+	for _, name := range names {
+		paths = append(paths, filepath.Join("..", "..", "demo", "assets", name+".fui"))
+	}
+	pkgs, err := loadPackages(paths)
+	if err != nil {
+		t.Skipf("demo assets unavailable: %v", err)
+	}
+	files, err := Generate(pkgs, opts)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	out := make(map[string]string)
+	for _, file := range files {
+		out[file.Name] = string(file.Source)
+	}
+	return out
+}
+
+func TestGenerateBindings(t *testing.T) {
+	files := generateDemo(t, Options{}, "Bag", "Basics")
+	bag, basics := files["bag_binding.go"], files["basics_binding.go"]
+	if bag == "" || basics == "" {
+		t.Fatalf("expected one file per package, got %d files", len(files))
+	}
+	for _, want := range []string{
+		"package ui\n",
+		"type BagWin struct {\n\t*core.GComponent\n",
+		"\tList     *widgets.GList\n",
+		"\tPageCtrl *core.Controller\n",
+		`c.Frame = binding.Child[*widgets.GLabel](b, "frame")`,
+		`c.PageCtrl = b.Controller("page")`,
+		// Bag/BagButton 没有导出，按钮实例使用控件类型
+		`c.BagBtn = binding.Child[*widgets.GButton](b, "bagBtn")`,
+	} {
+		if !strings.Contains(bag, want) {
+			t.Errorf("Bag bindings missing %q", want)
+		}
+	}
+	for _, want := range []string{
+		// 与 Bag/Main 同名，加上包名前缀
+		"type BasicsMain struct {",
+		"type CircleProgress struct {\n\t*widgets.GProgressBar\n",
+		`c.GProgressBar = binding.Root[*widgets.GProgressBar](b)`,
+		// 引用导出组件的子对象使用其绑定类型
+		`binding.Nested[CircleProgress](b, "n9")`,
+		`c.T1Trans = b.Transition("t1")`,
+	} {
+		if !strings.Contains(basics, want) {
+			t.Errorf("Basics bindings missing %q", want)
+		}
+	}
+}
+
+func TestGenerateIgnoreNoname(t *testing.T) {
+	files := generateDemo(t, Options{Package: "bag", IgnoreNoname: true}, "Bag")
+	bag := files["bag_binding.go"]
+	if !strings.HasPrefix(bag, "// Code generated by fguigen from Bag.fui. DO NOT EDIT.\n\npackage bag\n") {
+		t.Fatalf("unexpected header:\n%s", bag[:min(len(bag), 120)])
+	}
+	if strings.Contains(bag, `"n9"`) || !strings.Contains(bag, `"list"`) {
+		t.Fatalf("expected only named children to be bound:\n%s", bag)
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	for in, want := range map[string]string{
+		"n3":               "N3",
+		"bagBtn":           "BagBtn",
+		"Demo_Clip&Scroll": "DemoClipScroll",
+		"1st":              "X1st",
+		"标题":               "X标题",
+	} {
+		if got := exportedName(in); got != want {
+			t.Errorf("exportedName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
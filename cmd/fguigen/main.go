@@ -0,0 +1,100 @@
+// Command fguigen 为发布的 .fui 包生成强类型的 Go 组件绑定代码。
+//
+// 每个导出的组件生成一个结构体：内嵌组件自身的控件（*core.GComponent、*widgets.GButton 等），
+// 并为子对象、控制器和动效声明类型化的字段。Bind 方法按名称查找并检查类型，
+// 构建出的组件结构与生成代码时不一致时返回错误。引用了其它导出组件的子对象使用对应的绑定类型，
+// 在 Bind 时一并绑定。对应 FairyGUI 编辑器的 TypeScript 代码导出。
+//
+// 参数可以是 .fui 文件或包含 .fui 文件的目录（默认当前目录），每个包生成一个 <包名>_binding.go。
+// 跨包引用只能在被引用的包也一同加载时确定具体类型，否则字段类型为 *core.GComponent。
+//
+// 用法：
+//
+//	fguigen [-o dir] [-package name] [-ignore-noname] [path ...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+)
+
+func main() {
+	out := flag.String("o", ".", "output directory")
+	goPkg := flag.String("package", "ui", "Go package name of the generated files")
+	ignoreNoname := flag.Bool("ignore-noname", false, "skip children, controllers and transitions with default names (n1, c1, t1)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: fguigen [-o dir] [-package name] [-ignore-noname] [path ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	pkgs, err := loadPackages(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fguigen:", err)
+		os.Exit(2)
+	}
+	files, err := Generate(pkgs, Options{Package: *goPkg, IgnoreNoname: *ignoreNoname})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fguigen:", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "fguigen:", err)
+		os.Exit(1)
+	}
+	for _, file := range files {
+		path := filepath.Join(*out, file.Name)
+		if err := os.WriteFile(path, file.Source, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "fguigen:", err)
+			os.Exit(1)
+		}
+		fmt.Println(path)
+	}
+}
+
+// loadPackages 解析参数中的 .fui 文件和目录下的所有 .fui 文件
+func loadPackages(paths []string) ([]*assets.Package, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.fui"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no .fui files in %s", path)
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	pkgs := make([]*assets.Package, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pkg, err := assets.ParsePackage(data, strings.TrimSuffix(file, ".fui"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
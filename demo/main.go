@@ -9,6 +9,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
 
@@ -85,6 +86,7 @@ func newGame(ctx context.Context) (*game, error) {
 	root.AttachStage(stage)
 	root.Resize(manager.Width(), manager.Height())
 	root.SetData(root)
+	root.SetObjectCreator(fgui.NewObjectCreator(factory))
 
 	for _, child := range root.Children() {
 		root.RemoveChild(child)
@@ -256,6 +258,8 @@ func (g *game) keyboardEvents() []laya.KeyboardEvent {
 		ebiten.KeyEnter:     laya.KeyCodeEnter,
 		ebiten.KeyEscape:    laya.KeyCodeEscape,
 		ebiten.KeySpace:     laya.KeyCodeSpace,
+		ebiten.KeyPageUp:    laya.KeyCodePageUp,
+		ebiten.KeyPageDown:  laya.KeyCodePageDown,
 		ebiten.KeyLeft:      laya.KeyCodeLeft,
 		ebiten.KeyUp:        laya.KeyCodeUp,
 		ebiten.KeyRight:     laya.KeyCodeRight,
@@ -301,6 +305,37 @@ func (g *game) keyboardEvents() []laya.KeyboardEvent {
 		}
 	}
 
+	return append(events, gamepadEvents(modifiers)...)
+}
+
+// gamepadButtons 把标准布局手柄的按键映射为键码，用于列表、树和下拉框的导航
+var gamepadButtons = map[ebiten.StandardGamepadButton]laya.KeyCode{
+	ebiten.StandardGamepadButtonLeftTop:       laya.KeyCodeUp,
+	ebiten.StandardGamepadButtonLeftBottom:    laya.KeyCodeDown,
+	ebiten.StandardGamepadButtonLeftLeft:      laya.KeyCodeLeft,
+	ebiten.StandardGamepadButtonLeftRight:     laya.KeyCodeRight,
+	ebiten.StandardGamepadButtonRightBottom:   laya.KeyCodeEnter,
+	ebiten.StandardGamepadButtonRightRight:    laya.KeyCodeEscape,
+	ebiten.StandardGamepadButtonFrontTopLeft:  laya.KeyCodePageUp,
+	ebiten.StandardGamepadButtonFrontTopRight: laya.KeyCodePageDown,
+}
+
+// gamepadEvents 把手柄按键的按下和松开转换为键盘事件
+func gamepadEvents(modifiers laya.KeyModifiers) []laya.KeyboardEvent {
+	var events []laya.KeyboardEvent
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for button, code := range gamepadButtons {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+				events = append(events, laya.KeyboardEvent{Code: code, Down: true, Modifiers: modifiers})
+			}
+			if inpututil.IsStandardGamepadButtonJustReleased(id, button) {
+				events = append(events, laya.KeyboardEvent{Code: code, Modifiers: modifiers})
+			}
+		}
+	}
 	return events
 }
 
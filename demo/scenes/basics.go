@@ -256,7 +256,7 @@ func (d *BasicsDemo) initText(component *core.GComponent) {
 	const linkTemplate = "[img]ui://9leh0eyft9fj5f[/img][color=#FF0000]你点击了链接[/color]：%s"
 	if rich := component.GetChild("n12"); rich != nil {
 		rich.OnLink(func(link string) {
-			if setter, ok := rich.Data().(interface{ SetText(string) }); ok && setter != nil {
+			if setter, err := core.WidgetOf[interface{ SetText(string) }](rich); err == nil {
 				setter.SetText(fmt.Sprintf(linkTemplate, link))
 			}
 		})
@@ -267,10 +267,10 @@ func (d *BasicsDemo) initText(component *core.GComponent) {
 		if dest != nil && src != nil {
 			copyBtn.OnClick(func() {
 				var content string
-				if reader, ok := src.Data().(interface{ Text() string }); ok && reader != nil {
+				if reader, err := core.WidgetOf[interface{ Text() string }](src); err == nil {
 					content = reader.Text()
 				}
-				if writer, ok := dest.Data().(interface{ SetText(string) }); ok && writer != nil {
+				if writer, err := core.WidgetOf[interface{ SetText(string) }](dest); err == nil {
 					writer.SetText(content)
 				}
 			})
@@ -280,12 +280,12 @@ func (d *BasicsDemo) initText(component *core.GComponent) {
 	// 为输入框添加焦点处理
 	if inputBox := component.GetChild("n22"); inputBox != nil {
 		inputBox.OnClickWithData(func(*laya.Event) {
-			if input, ok := inputBox.Data().(*widgets.GTextInput); ok && input != nil {
+			if input, err := core.WidgetOf[*widgets.GTextInput](inputBox); err == nil {
 				input.RequestFocus()
 			}
 		})
 		// 启动时自动请求焦点，直接显示光标
-		if input, ok := inputBox.Data().(*widgets.GTextInput); ok && input != nil {
+		if input, err := core.WidgetOf[*widgets.GTextInput](inputBox); err == nil {
 			input.RequestFocus()
 		}
 	}
@@ -302,7 +302,7 @@ func (d *BasicsDemo) initGrid(component *core.GComponent) {
 		if obj == nil {
 			return
 		}
-		if list, ok := obj.Data().(*widgets.GList); ok && list != nil {
+		if list, err := core.WidgetOf[*widgets.GList](obj); err == nil {
 			items := list.GComponent.Children()
 			for i := 0; i < len(items) && i < len(names); i++ {
 				if child := items[i]; child != nil {
@@ -328,17 +328,13 @@ func (d *BasicsDemo) initGrid(component *core.GComponent) {
 	})
 
 	fillList(component.GetChild("list2"), func(item *core.GComponent, idx int) {
-		if cb := item.GetChild("cb"); cb != nil {
-			if btn, ok := cb.Data().(*widgets.GButton); ok {
-				btn.SetSelected(false)
-			}
+		if btn, err := core.GetChildAs[*widgets.GButton](item, "cb"); err == nil {
+			btn.SetSelected(false)
 		}
 		// 参考 TypeScript 原版：BasicsDemo.ts playGrid()
 		// 设置 MovieClip 的播放状态：偶数索引播放，奇数索引停止
-		if mc := item.GetChild("mc"); mc != nil {
-			if clip, ok := mc.Data().(*widgets.GMovieClip); ok && clip != nil {
-				clip.SetPlaying(idx%2 == 0)
-			}
+		if clip, err := core.GetChildAs[*widgets.GMovieClip](item, "mc"); err == nil {
+			clip.SetPlaying(idx%2 == 0)
 		}
 		setComponentText(item, "t1", names[idx])
 		setComponentText(item, "t3", strconv.Itoa(rand.Intn(10000)))
@@ -395,11 +391,9 @@ func (d *BasicsDemo) initList(component *core.GComponent) {
 
 		var baseLabel string
 		var labelField *widgets.GTextField
-		if labelObj := component.GetChild(cfg.label); labelObj != nil {
-			if txt, ok := labelObj.Data().(*widgets.GTextField); ok {
-				labelField = txt
-				baseLabel = txt.Text()
-			}
+		if txt, err := core.GetChildAs[*widgets.GTextField](component, cfg.label); err == nil {
+			labelField = txt
+			baseLabel = txt.Text()
 		}
 
 		updateLabel := func() {
@@ -431,7 +425,7 @@ func (d *BasicsDemo) initProgress(component *core.GComponent) {
 			children := component.Children()
 			count := 0
 			for _, child := range children {
-				if bar, ok := child.Data().(*widgets.GProgressBar); ok {
+				if bar, err := core.WidgetOf[*widgets.GProgressBar](child); err == nil {
 					count++
 					next := bar.Value() + 1
 					if next > bar.Max() {
@@ -512,7 +506,7 @@ func (d *BasicsDemo) initDragDrop(component *core.GComponent) {
 	if btnBObj != nil && btnCObj != nil {
 		d.makeDraggable(btnBObj, dragOptions{
 			Payload: func() any {
-				if btn, ok := btnBObj.Data().(*widgets.GButton); ok {
+				if btn, err := core.WidgetOf[*widgets.GButton](btnBObj); err == nil {
 					return btn.Icon()
 				}
 				return nil
@@ -528,7 +522,7 @@ func (d *BasicsDemo) initDragDrop(component *core.GComponent) {
 						if !ok {
 							return
 						}
-						if btn, ok := btnCObj.Data().(*widgets.GButton); ok {
+						if btn, err := core.WidgetOf[*widgets.GButton](btnCObj); err == nil {
 							btn.SetIcon(icon)
 						}
 					},
@@ -623,12 +617,10 @@ func (d *BasicsDemo) populateWindowA() {
 		if child == nil {
 			continue
 		}
-		switch data := child.Data().(type) {
-		case *widgets.GButton:
-			data.SetTitle(strconv.Itoa(i))
-		case *widgets.GLabel:
-			data.SetTitle(strconv.Itoa(i))
-		default:
+		// GButton 和 GLabel 都可以设置标题
+		if titled, err := core.WidgetOf[interface{ SetTitle(string) }](child); err == nil {
+			titled.SetTitle(strconv.Itoa(i))
+		} else {
 			child.SetData(strconv.Itoa(i))
 		}
 	}
@@ -1155,17 +1147,8 @@ type dragOptions struct {
 }
 
 func childAsComponent(parent *core.GComponent, name string) *core.GComponent {
-	if parent == nil {
-		return nil
-	}
-	child := parent.GetChild(name)
-	if child == nil {
-		return nil
-	}
-	if comp, ok := child.Data().(*core.GComponent); ok {
-		return comp
-	}
-	return nil
+	comp, _ := core.GetChildAs[*core.GComponent](parent, name)
+	return comp
 }
 
 func removeAllChildren(comp *core.GComponent) {
@@ -1188,16 +1171,12 @@ func setComponentText(comp *core.GComponent, childName, value string) {
 	if child == nil {
 		return
 	}
-	switch data := child.Data().(type) {
-	case *widgets.GTextField:
-		data.SetText(value)
-	case *widgets.GLabel:
-		data.SetTitle(value)
-	case *widgets.GButton:
-		data.SetTitle(value)
-	case nil:
-		child.SetData(value)
-	default:
+	if field, err := core.WidgetOf[*widgets.GTextField](child); err == nil {
+		field.SetText(value)
+	} else if titled, err := core.WidgetOf[interface{ SetTitle(string) }](child); err == nil {
+		// GLabel、GButton
+		titled.SetTitle(value)
+	} else {
 		child.SetData(value)
 	}
 }
@@ -1207,13 +1186,10 @@ func setComponentColor(comp *core.GComponent, childName, color string) {
 	if child == nil {
 		return
 	}
-	switch data := child.Data().(type) {
-	case *widgets.GTextField:
-		data.SetColor(color)
-	case *widgets.GLabel:
-		data.SetTitleColor(color)
-	case *widgets.GButton:
-		data.SetTitleColor(color)
+	if field, err := core.WidgetOf[*widgets.GTextField](child); err == nil {
+		field.SetColor(color)
+	} else if titled, err := core.WidgetOf[interface{ SetTitleColor(string) }](child); err == nil {
+		titled.SetTitleColor(color)
 	}
 }
 
@@ -1222,33 +1198,17 @@ func getProgressBar(obj *core.GObject) *widgets.GProgressBar {
 		return nil
 	}
 	// 首先检查对象本身是否是GProgressBar
-	if bar, ok := obj.Data().(*widgets.GProgressBar); ok {
+	if bar, err := core.WidgetOf[*widgets.GProgressBar](obj); err == nil {
 		return bar
 	}
-	// 检查是否是GComponent
-	if comp := core.ComponentFrom(obj); comp != nil {
-		// 检查子组件
-		if inner := comp.GetChild("bar"); inner != nil {
-			if b, ok := inner.Data().(*widgets.GProgressBar); ok {
-				return b
-			}
-		}
-	}
-	return nil
+	// 否则检查名为 bar 的子组件
+	bar, _ := core.GetChildAs[*widgets.GProgressBar](core.ComponentFrom(obj), "bar")
+	return bar
 }
 
 func childAsList(parent *core.GComponent, name string) *widgets.GList {
-	if parent == nil {
-		return nil
-	}
-	child := parent.GetChild(name)
-	if child == nil {
-		return nil
-	}
-	if list, ok := child.Data().(*widgets.GList); ok {
-		return list
-	}
-	return nil
+	list, _ := core.GetChildAs[*widgets.GList](parent, name)
+	return list
 }
 
 type listEntry struct {
@@ -1269,24 +1229,18 @@ func applyListEntries(list *widgets.GList, entries []listEntry) {
 			return
 		}
 		entry := shuffled[idx%len(shuffled)]
-		switch data := obj.Data().(type) {
-		case *widgets.GButton:
-			data.SetTitle(entry.title)
-			data.SetIcon(entry.icon)
-		case *widgets.GLabel:
-			data.SetTitle(entry.title)
-		case *core.GComponent:
-			setComponentText(data, "title", entry.title)
-			if iconChild := data.GetChild("icon"); iconChild != nil {
-				switch iconData := iconChild.Data().(type) {
-				case *widgets.GLoader:
-					iconData.SetURL(entry.icon)
-				case *widgets.GButton:
-					iconData.SetIcon(entry.icon)
-				}
+		if button, err := core.WidgetOf[*widgets.GButton](obj); err == nil {
+			button.SetTitle(entry.title)
+			button.SetIcon(entry.icon)
+		} else if label, err := core.WidgetOf[*widgets.GLabel](obj); err == nil {
+			label.SetTitle(entry.title)
+		} else if comp, err := core.WidgetOf[*core.GComponent](obj); err == nil {
+			setComponentText(comp, "title", entry.title)
+			if loader, err := core.GetChildAs[*widgets.GLoader](comp, "icon"); err == nil {
+				loader.SetURL(entry.icon)
+			} else if button, err := core.GetChildAs[*widgets.GButton](comp, "icon"); err == nil {
+				button.SetIcon(entry.icon)
 			}
-		default:
-			// no-op
 		}
 	}
 
@@ -1338,30 +1292,19 @@ func listItemTitle(list *widgets.GList, index int) string {
 	if item == nil {
 		return fmt.Sprintf("#%d", index+1)
 	}
-	switch data := item.Data().(type) {
-	case *widgets.GButton:
-		if text := data.Title(); text != "" {
-			return text
-		}
-	case *widgets.GLabel:
-		if text := data.Title(); text != "" {
+	if titled, err := core.WidgetOf[interface{ Title() string }](item); err == nil {
+		// GButton、GLabel
+		if text := titled.Title(); text != "" {
 			return text
 		}
-	case *core.GComponent:
-		if titleChild := data.GetChild("title"); titleChild != nil {
-			switch titleData := titleChild.Data().(type) {
-			case *widgets.GTextField:
-				if text := titleData.Text(); text != "" {
-					return text
-				}
-			case *widgets.GLabel:
-				if text := titleData.Title(); text != "" {
-					return text
-				}
-			case *widgets.GButton:
-				if text := titleData.Title(); text != "" {
-					return text
-				}
+	} else if comp, err := core.WidgetOf[*core.GComponent](item); err == nil {
+		if field, err := core.GetChildAs[*widgets.GTextField](comp, "title"); err == nil {
+			if text := field.Text(); text != "" {
+				return text
+			}
+		} else if titled, err := core.GetChildAs[interface{ Title() string }](comp, "title"); err == nil {
+			if text := titled.Title(); text != "" {
+				return text
 			}
 		}
 	}
@@ -62,11 +62,9 @@ func (d *JoystickDemo) Load(ctx context.Context, mgr *Manager) (*core.GComponent
 	}
 	d.module = module
 
-	if child := component.ChildByName("n9"); child != nil {
-		if tf, ok := child.Data().(*widgets.GTextField); ok {
-			d.textField = tf
-			d.textField.SetText("")
-		}
+	if tf, err := core.GetChildAs[*widgets.GTextField](component, "n9"); err == nil {
+		d.textField = tf
+		d.textField.SetText("")
 	}
 
 	d.moveHandler = func(evt *laya.Event) {
@@ -427,15 +425,8 @@ func (m *JoystickModule) updateThumb(centerX, centerY float64) {
 }
 
 func childButton(parent *core.GComponent, name string) *widgets.GButton {
-	if parent == nil {
-		return nil
-	}
-	if obj := parent.ChildByName(name); obj != nil {
-		if btn, ok := obj.Data().(*widgets.GButton); ok {
-			return btn
-		}
-	}
-	return nil
+	btn, _ := core.GetChildAs[*widgets.GButton](parent, name)
+	return btn
 }
 
 func buttonChild(btn *widgets.GButton, name string) *core.GObject {
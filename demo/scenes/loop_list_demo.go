@@ -54,66 +54,58 @@ func (d *LoopListDemo) Load(ctx context.Context, mgr *Manager) (*core.GComponent
 	d.view = view
 
 	// 查找list组件
-	listObj := view.ChildByName("list")
-	if listObj == nil {
-		return nil, fmt.Errorf("找不到 list 组件")
+	list, err := core.GetChildAs[*widgets.GList](view, "list")
+	if err != nil {
+		return nil, err
 	}
+	d.list = list
 
-	// 转换为GList
-	if data := listObj.Data(); data != nil {
-		if list, ok := data.(*widgets.GList); ok {
-			d.list = list
-
-			// 调试：检查包加载情况
-			defaultItemURL := list.DefaultItem()
-			log.Printf("🔍 List defaultItem: %s", defaultItemURL)
-
-			// 测试URL解析
-			if defaultItemURL != "" {
-				if item := fgui.GetItemByURL(defaultItemURL); item != nil {
-					log.Printf("✅ 成功解析 defaultItem: 类型=%d, ID=%s, Name=%s",
-						item.Type, item.ID, item.Name)
-				} else {
-					log.Printf("❌ 无法解析 defaultItem: %s", defaultItemURL)
-				}
-			}
-
-			// 参考TypeScript版本：直接调用 SetVirtualAndLoop()
-			list.SetVirtual(true)
-			list.SetLoop(true)
-
-			// 调试：检查滚动类型和列间距
-			sp := list.GComponent.ScrollPane()
-			if sp != nil {
-				log.Printf("🔍 ScrollPane状态: 类型=%v, viewSize=%.0fx%.0f",
-					sp.ScrollType(), sp.ViewWidth(), sp.ViewHeight())
-			} else {
-				log.Printf("⚠️  ScrollPane为nil")
-			}
-			log.Printf("🔍 List配置: columnGap=%d, lineGap=%d, layout=%d, autoResizeItem=%v",
-				list.ColumnGap(), list.LineGap(), list.Layout(), list.AutoResizeItem())
-
-			// 设置项目渲染器
-			list.SetItemRenderer(d.renderListItem)
-
-			// 设置项目数量
-			list.SetNumItems(5)
-
-			// 添加滚动事件
-			list.On(laya.EventScroll, func(evt *laya.Event) {
-				d.doSpecialEffect()
-			})
-
-			// 初始执行特效
-			d.doSpecialEffect()
-
-			log.Printf("✅ 循环列表配置完成: NumItems=%d, IsLoop=%v",
-				list.NumItems(), list.IsLoop())
+	// 调试：检查包加载情况
+	defaultItemURL := list.DefaultItem()
+	log.Printf("🔍 List defaultItem: %s", defaultItemURL)
+
+	// 测试URL解析
+	if defaultItemURL != "" {
+		if item := fgui.GetItemByURL(defaultItemURL); item != nil {
+			log.Printf("✅ 成功解析 defaultItem: 类型=%d, ID=%s, Name=%s",
+				item.Type, item.ID, item.Name)
 		} else {
-			log.Printf("⚠️  list 不是 GList 类型: %T", data)
+			log.Printf("❌ 无法解析 defaultItem: %s", defaultItemURL)
 		}
 	}
 
+	// 参考TypeScript版本：直接调用 SetVirtualAndLoop()
+	list.SetVirtual(true)
+	list.SetLoop(true)
+
+	// 调试：检查滚动类型和列间距
+	sp := list.GComponent.ScrollPane()
+	if sp != nil {
+		log.Printf("🔍 ScrollPane状态: 类型=%v, viewSize=%.0fx%.0f",
+			sp.ScrollType(), sp.ViewWidth(), sp.ViewHeight())
+	} else {
+		log.Printf("⚠️  ScrollPane为nil")
+	}
+	log.Printf("🔍 List配置: columnGap=%d, lineGap=%d, layout=%d, autoResizeItem=%v",
+		list.ColumnGap(), list.LineGap(), list.Layout(), list.AutoResizeItem())
+
+	// 设置项目渲染器
+	list.SetItemRenderer(d.renderListItem)
+
+	// 设置项目数量
+	list.SetNumItems(5)
+
+	// 添加滚动事件
+	list.On(laya.EventScroll, func(evt *laya.Event) {
+		d.doSpecialEffect()
+	})
+
+	// 初始执行特效
+	d.doSpecialEffect()
+
+	log.Printf("✅ 循环列表配置完成: NumItems=%d, IsLoop=%v",
+		list.NumItems(), list.IsLoop())
+
 	log.Println("✅ 循环列表 demo 加载完成")
 	return view, nil
 }
@@ -157,20 +149,16 @@ func (d *LoopListDemo) doSpecialEffect() {
 	// 更新文本显示，使用GetFirstChildInView方法
 	// 修复：计算循环索引，对应TypeScript版本的逻辑
 	// (getFirstChildInView() + 1) % numItems
-	if textObj := d.view.ChildByName("n3"); textObj != nil {
-		if textData := textObj.Data(); textData != nil {
-			if textField, ok := textData.(*widgets.GTextField); ok {
-				firstVisibleIndex := d.list.GetFirstChildInView()
-				if firstVisibleIndex >= 0 {
-					// 计算循环索引：对5取模得到0-4的范围
-					cycledIndex := (firstVisibleIndex + 1) % d.list.NumItems()
-					textField.SetText(fmt.Sprintf("%d", cycledIndex))
-					log.Printf("🔄 循环索引: firstVisible=%d, cycled=%d, numItems=%d",
-						firstVisibleIndex, cycledIndex, d.list.NumItems())
-				} else {
-					textField.SetText("No visible items")
-				}
-			}
+	if textField, err := core.GetChildAs[*widgets.GTextField](d.view, "n3"); err == nil {
+		firstVisibleIndex := d.list.GetFirstChildInView()
+		if firstVisibleIndex >= 0 {
+			// 计算循环索引：对5取模得到0-4的范围
+			cycledIndex := (firstVisibleIndex + 1) % d.list.NumItems()
+			textField.SetText(fmt.Sprintf("%d", cycledIndex))
+			log.Printf("🔄 循环索引: firstVisible=%d, cycled=%d, numItems=%d",
+				firstVisibleIndex, cycledIndex, d.list.NumItems())
+		} else {
+			textField.SetText("No visible items")
 		}
 	}
 }
@@ -187,20 +175,13 @@ func (d *LoopListDemo) renderListItem(index int, obj *core.GObject) {
 	obj.SetPivot(0.5, 0.5)
 
 	// 设置图标
-	if button, ok := obj.Data().(*widgets.GButton); ok {
-		// 构建图标URL
-		iconURL := fmt.Sprintf("ui://LoopList/n%d", index+1)
+	// 构建图标URL
+	iconURL := fmt.Sprintf("ui://LoopList/n%d", index+1)
+	if button, err := core.WidgetOf[*widgets.GButton](obj); err == nil {
 		button.SetIcon(iconURL)
-	} else {
-		// 如果不是按钮，尝试通过其他方式设置图标
-		if comp := core.ComponentFrom(obj); comp != nil {
-			if iconObj := comp.ChildByName("icon"); iconObj != nil {
-				if loader, ok := iconObj.Data().(*widgets.GLoader); ok {
-					iconURL := fmt.Sprintf("ui://LoopList/n%d", index+1)
-					loader.SetURL(iconURL)
-				}
-			}
-		}
+	} else if loader, err := core.GetChildAs[*widgets.GLoader](core.ComponentFrom(obj), "icon"); err == nil {
+		// 如果不是按钮，直接设置 icon 装载器
+		loader.SetURL(iconURL)
 	}
 }
 
@@ -64,14 +64,11 @@ func (s *MainMenu) attachButtons(component *core.GComponent, mgr *Manager) {
 		"n16": "CooldownDemo",
 	}
 	for id, sceneName := range buttonToScene {
-		child := component.ChildByName(id)
-		if child == nil {
+		button, err := core.GetChildAs[*widgets.GButton](component, id)
+		if err != nil {
 			continue
 		}
-		if _, ok := child.Data().(*widgets.GButton); !ok {
-			continue
-		}
-		sprite := child.DisplayObject()
+		sprite := button.DisplayObject()
 		if sprite == nil || sprite.Dispatcher() == nil {
 			continue
 		}
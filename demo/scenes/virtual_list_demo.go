@@ -52,30 +52,22 @@ func (d *VirtualListDemo) Load(ctx context.Context, mgr *Manager) (*core.GCompon
 	d.view = view
 
 	// 查找mailList组件
-	mailListObj := view.ChildByName("mailList")
-	if mailListObj == nil {
-		return nil, fmt.Errorf("找不到 mailList 组件")
+	list, err := core.GetChildAs[*widgets.GList](view, "mailList")
+	if err != nil {
+		return nil, err
 	}
+	d.list = list
 
-	// 转换为GList
-	if data := mailListObj.Data(); data != nil {
-		if list, ok := data.(*widgets.GList); ok {
-			d.list = list
-
-			// 启用虚拟化
-			list.SetVirtual(true)
+	// 启用虚拟化
+	list.SetVirtual(true)
 
-			// 设置项目渲染器
-			list.SetItemRenderer(d.renderMailItem)
+	// 设置项目渲染器
+	list.SetItemRenderer(d.renderMailItem)
 
-			// 设置项目数量（模拟1000封邮件）
-			list.SetNumItems(1000)
+	// 设置项目数量（模拟1000封邮件）
+	list.SetNumItems(1000)
 
-			log.Printf("✅ 虚拟列表配置完成: NumItems=%d", list.NumItems())
-		} else {
-			log.Printf("⚠️  mailList 不是 GList 类型: %T", data)
-		}
-	}
+	log.Printf("✅ 虚拟列表配置完成: NumItems=%d", list.NumItems())
 
 	// 绑定按钮事件
 	d.bindButtons(view)
@@ -127,27 +119,17 @@ func (d *VirtualListDemo) renderMailItem(index int, obj *core.GObject) {
 	// 关键修复：如果 mailItem 是 GButton，使用 SetTitle() 而不是直接设置文本
 	// 这样当点击触发 SetSelected → applyTitleState 时，标题不会被清空
 	titleText := fmt.Sprintf("%d Mail title here", index)
-	if button, ok := obj.Data().(*widgets.GButton); ok {
+	if button, err := core.WidgetOf[*widgets.GButton](obj); err == nil {
 		button.SetTitle(titleText)
-	} else {
+	} else if textField, err := core.GetChildAs[*widgets.GTextField](comp, "title"); err == nil {
 		// 不是 GButton，直接设置 titleObject 的文本
-		if titleChild := comp.ChildByName("title"); titleChild != nil {
-			if textData := titleChild.Data(); textData != nil {
-				if textField, ok := textData.(*widgets.GTextField); ok {
-					textField.SetText(titleText)
-				}
-			}
-		}
+		textField.SetText(titleText)
 	}
 
 	// 设置时间
 	// 对应 MailItem.ts:20 - setTime() 方法使用 "timeText" 子对象
-	if timeText := comp.ChildByName("timeText"); timeText != nil {
-		if textData := timeText.Data(); textData != nil {
-			if textField, ok := textData.(*widgets.GTextField); ok {
-				textField.SetText("5 Nov 2015 16:24:33")
-			}
-		}
+	if textField, err := core.GetChildAs[*widgets.GTextField](comp, "timeText"); err == nil {
+		textField.SetText("5 Nov 2015 16:24:33")
 	}
 
 	// 设置调试名称
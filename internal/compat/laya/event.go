@@ -43,6 +43,9 @@ const (
 	EventDrop           EventType = "drop"
 	EventPullDownRelease EventType = "pullDownRelease"
 	EventPullUpRelease   EventType = "pullUpRelease"
+	EventScrollEnd       EventType = "scrollEnd"
+	EventPageChanged     EventType = "pageChanged"
+	EventClickItem       EventType = "clickItem"
 )
 
 // ListenerID uniquely identifies a registered event listener.
@@ -190,6 +193,20 @@ func (d *BasicEventDispatcher) OffByID(evt EventType, id ListenerID) {
 	}
 }
 
+// OffAll removes every listener, mirroring Laya's EventDispatcher.offAll().
+func (d *BasicEventDispatcher) OffAll() {
+	d.listeners = make(map[EventType][]listenerEntry)
+}
+
+// ListenerCount returns the number of registered listeners across all event types.
+func (d *BasicEventDispatcher) ListenerCount() int {
+	count := 0
+	for _, list := range d.listeners {
+		count += len(list)
+	}
+	return count
+}
+
 // Emit dispatches an event to registered listeners.
 func (d *BasicEventDispatcher) Emit(evt EventType, data any) {
 	list := d.listeners[evt]
@@ -204,18 +221,42 @@ func (d *BasicEventDispatcher) Emit(evt EventType, data any) {
 		event = &Event{Type: evt, Data: data}
 	}
 
-	remaining := list[:0]
-	for _, entry := range list {
+	// 派发期间监听器可能注册或移除监听（如拖动结束时移除舞台监听），按快照派发，
+	// 跳过派发过程中已移除的监听，与 Laya EventDispatcher 的行为一致
+	snapshot := append([]listenerEntry(nil), list...)
+	for _, entry := range snapshot {
+		if !d.hasEntry(evt, entry.id) {
+			continue
+		}
+		if entry.once {
+			d.removeEntry(evt, entry.id)
+		}
 		if entry.fn != nil {
 			entry.fn(event)
 		}
-		if !entry.once {
-			remaining = append(remaining, entry)
+	}
+}
+
+func (d *BasicEventDispatcher) hasEntry(evt EventType, id uint64) bool {
+	for _, entry := range d.listeners[evt] {
+		if entry.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *BasicEventDispatcher) removeEntry(evt EventType, id uint64) {
+	list := d.listeners[evt]
+	out := make([]listenerEntry, 0, len(list))
+	for _, entry := range list {
+		if entry.id != id {
+			out = append(out, entry)
 		}
 	}
-	if len(remaining) == 0 {
+	if len(out) == 0 {
 		delete(d.listeners, evt)
 	} else {
-		d.listeners[evt] = remaining
+		d.listeners[evt] = out
 	}
 }
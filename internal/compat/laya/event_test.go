@@ -0,0 +1,31 @@
+package laya_test
+
+import (
+	"testing"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+)
+
+func TestEmitHandlesListenersChangedDuringDispatch(t *testing.T) {
+	d := laya.NewEventDispatcher()
+	var calls []string
+	var selfID, secondID laya.ListenerID
+	selfID = d.OnWithID(laya.EventTouchEnd, func(*laya.Event) {
+		calls = append(calls, "self")
+		d.OffByID(laya.EventTouchEnd, selfID)
+		d.OffByID(laya.EventTouchEnd, secondID)
+		d.On(laya.EventTouchEnd, func(*laya.Event) { calls = append(calls, "added") })
+	})
+	secondID = d.OnWithID(laya.EventTouchEnd, func(*laya.Event) { calls = append(calls, "second") })
+	d.Once(laya.EventTouchEnd, func(*laya.Event) { calls = append(calls, "once") })
+
+	d.Emit(laya.EventTouchEnd, nil)
+	if len(calls) != 2 || calls[0] != "self" || calls[1] != "once" {
+		t.Fatalf("expected removed listeners to be skipped, got %v", calls)
+	}
+	calls = nil
+	d.Emit(laya.EventTouchEnd, nil)
+	if len(calls) != 1 || calls[0] != "added" || d.ListenerCount() != 1 {
+		t.Fatalf("expected only the listener added during dispatch to remain, got %v (%d listeners)", calls, d.ListenerCount())
+	}
+}
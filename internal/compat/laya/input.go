@@ -25,6 +25,8 @@ const (
 	KeyCodeEnter     KeyCode = 13
 	KeyCodeEscape    KeyCode = 27
 	KeyCodeSpace     KeyCode = 32
+	KeyCodePageUp    KeyCode = 33
+	KeyCodePageDown  KeyCode = 34
 	KeyCodeLeft      KeyCode = 37
 	KeyCodeUp        KeyCode = 38
 	KeyCodeRight     KeyCode = 39
@@ -225,6 +225,24 @@ func (s *Sprite) Dispatcher() EventDispatcher {
 	return s.dispatcher
 }
 
+// ListenerCount returns the number of listeners registered on the sprite's dispatcher.
+func (s *Sprite) ListenerCount() int {
+	return s.dispatcher.ListenerCount()
+}
+
+// Destroy detaches the sprite from its parent and removes all listeners and hit testers,
+// mirroring Laya's Sprite.destroy(). Children are left to their owners.
+func (s *Sprite) Destroy() {
+	if s.parent != nil {
+		s.parent.RemoveChild(s)
+	}
+	s.dispatcher.OffAll()
+	s.hitTester = nil
+	s.hitArea = nil
+	s.mask = nil
+	s.owner = nil
+}
+
 // SetHitTester registers a custom hit testing function evaluated in local coordinates.
 func (s *Sprite) SetHitTester(fn func(x, y float64) bool) {
 	s.hitTester = fn
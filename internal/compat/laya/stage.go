@@ -45,6 +45,7 @@ type Stage struct {
 	capture      *Sprite
 	focus        *Sprite
 	touchTargets map[int]*Sprite
+	touchPoints  map[int]Point
 	keysDown     map[KeyCode]bool
 	modState     KeyModifiers
 }
@@ -61,6 +62,7 @@ func NewStage(width, height int) *Stage {
 		height:       height,
 		activeID:     -1,
 		touchTargets: make(map[int]*Sprite),
+		touchPoints:  make(map[int]Point),
 		keysDown:     make(map[KeyCode]bool),
 	}
 }
@@ -92,6 +94,16 @@ func (s *Stage) Mouse() MouseState {
 	return s.mouse
 }
 
+// PointerPosition returns the latest position of the given touch, or the mouse
+// position when no such touch is active (mouse presses use their own IDs).
+// 对应 TypeScript 版本的 Laya.stage.mouseX/mouseY（触摸时为对应手指的位置）
+func (s *Stage) PointerPosition(touchID int) Point {
+	if pt, ok := s.touchPoints[touchID]; ok {
+		return pt
+	}
+	return Point{X: s.mouse.X, Y: s.mouse.Y}
+}
+
 // Focus returns the sprite that currently holds keyboard focus.
 func (s *Stage) Focus() *Sprite {
 	return s.focus
@@ -275,6 +287,9 @@ func (s *Stage) handleTouches(inputs []TouchInput) {
 			target = s.capture
 		}
 		event := s.touchPointerEvent(touch, target, actual)
+		if touch.Phase == TouchPhaseBegin || touch.Phase == TouchPhaseMove {
+			s.touchPoints[touch.ID] = point
+		}
 
 		switch touch.Phase {
 		case TouchPhaseBegin:
@@ -302,6 +317,7 @@ func (s *Stage) handleTouches(inputs []TouchInput) {
 			stageEvent.Hit = actual
 			s.root.Dispatcher().Emit(EventTouchEnd, stageEvent)
 			delete(s.touchTargets, touch.ID)
+			delete(s.touchPoints, touch.ID)
 		case TouchPhaseCancel:
 			if target != nil {
 				target.EmitWithBubble(EventTouchEnd, event)
@@ -311,6 +327,7 @@ func (s *Stage) handleTouches(inputs []TouchInput) {
 			stageEvent.Hit = actual
 			s.root.Dispatcher().Emit(EventTouchCancel, stageEvent)
 			delete(s.touchTargets, touch.ID)
+			delete(s.touchPoints, touch.ID)
 		}
 	}
 }
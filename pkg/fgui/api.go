@@ -2,6 +2,7 @@ package fgui
 
 import (
 	"context"
+	"io/fs"
 	"time"
 
 	"github.com/chslink/fairygui/internal/compat/laya"
@@ -55,13 +56,20 @@ type (
 	GScrollBar     = widgets.GScrollBar
 	GTree          = widgets.GTree
 	GTreeNode      = widgets.GTreeNode
+	ExtensionFunc  = widgets.ExtensionFunc
 
 	// Asset types
 	Package        = assets.Package
 	PackageItem    = assets.PackageItem
 	Loader         = assets.Loader
 	FileLoader     = assets.FileLoader
+	FSLoader       = assets.FSLoader
+	BatchLoader    = assets.BatchLoader
+	BatchProgress  = assets.BatchProgress
+	BatchError     = assets.BatchError
 	ResourceType   = assets.ResourceType
+	StringTable    = assets.StringTable
+	WriteOptions   = assets.WriteOptions
 )
 
 const (
@@ -208,12 +216,72 @@ func NewFactoryWithLoader(resolver AtlasResolver, loader assets.Loader) *Factory
 	return builder.NewFactoryWithLoader(resolver, loader)
 }
 
+// NewObjectCreator 返回按 ui:// URL 创建对象的创建器，设置到根对象后用于创建默认提示组件
+//
+// Example:
+//   fgui.Root().SetObjectCreator(fgui.NewObjectCreator(factory))
+func NewObjectCreator(factory *Factory) core.ObjectCreator {
+	return builder.NewObjectCreator(factory)
+}
+
+// PackageManager adds and removes packages with dependency reference counting.
+type PackageManager = builder.PackageManager
+
+// NewPackageManager attaches a package lifecycle manager to factory.
+// Packages are read through loader (the factory's loader when nil).
+//
+// Example:
+//   mgr := fgui.NewPackageManager(factory, fgui.NewFileLoader("./assets"))
+//   pkg, err := mgr.AddPackage(ctx, "Bag")
+//   ...
+//   err = mgr.RemovePackage("Bag") // ErrRemovalDeferred while components are alive
+func NewPackageManager(factory *Factory, loader assets.Loader) *PackageManager {
+	return builder.NewPackageManager(factory, loader)
+}
+
+// HotReloader polls package files and reloads republished packages during development.
+type HotReloader = builder.HotReloader
+
+// HotReloadOptions configures NewHotReloader.
+type HotReloadOptions = builder.HotReloadOptions
+
+// ReloadEvent describes one package reload performed by a HotReloader.
+type ReloadEvent = builder.ReloadEvent
+
+// NewHotReloader watches packages loaded through loader (the factory's loader when nil).
+// Call Update from the game loop; tracked components are rebuilt in place.
+//
+// Example:
+//   reloader, _ := fgui.NewHotReloader(factory, nil, fgui.HotReloadOptions{})
+//   reloader.Watch(pkg)
+//   reloader.Track(view, item)
+//   // in Update():
+//   reloader.Update(ctx)
+func NewHotReloader(factory *Factory, loader assets.Loader, opts HotReloadOptions) (*HotReloader, error) {
+	return builder.NewHotReloader(factory, loader, opts)
+}
+
 // BuildComponent is a convenience wrapper for Factory.BuildComponent.
 // Requires a factory to be created first via NewFactory or NewFactoryWithLoader.
 func BuildComponent(ctx context.Context, factory *Factory, pkg *assets.Package, item *assets.PackageItem) (*core.GComponent, error) {
 	return factory.BuildComponent(ctx, pkg, item)
 }
 
+// AsyncBuild is a component build spread over several frames (TypeScript AsyncOperation).
+type AsyncBuild = builder.AsyncBuild
+
+// BuildComponentAsync builds a component over several frames, spending at most
+// UIConfig.FrameTimeForAsyncUIConstruction per GRoot.Advance. done receives the
+// component, or the error when the build fails or ctx is cancelled.
+//
+// Example:
+//   fgui.BuildComponentAsync(ctx, factory, pkg, pkg.ItemByName("BagWin"), func(win *fgui.GComponent, err error) {
+//       if err == nil { fgui.Root().AddChild(win.GObject) }
+//   })
+func BuildComponentAsync(ctx context.Context, factory *Factory, pkg *assets.Package, item *assets.PackageItem, done func(*core.GComponent, error)) *AsyncBuild {
+	return factory.BuildComponentAsync(ctx, pkg, item, done)
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // Asset Loading API
 // ────────────────────────────────────────────────────────────────────────────
@@ -231,6 +299,33 @@ func ParsePackage(data []byte, resKey string) (*assets.Package, error) {
 	return assets.ParsePackage(data, resKey)
 }
 
+// LoadProjectPackage builds a package directly from a FairyGUI editor project
+// (package.xml, component XML and raw images), without publishing to .fui.
+// Every image is its own texture; the result works with Factory and AtlasManager.
+//
+// Parameters:
+//   - loader: Loader rooted at the project's assets directory
+//   - dir: Package directory relative to the loader root
+//
+// Example:
+//   loader := fgui.NewFileLoader("demo/UIProject/assets")
+//   pkg, err := fgui.LoadProjectPackage(ctx, loader, "Basics")
+func LoadProjectPackage(ctx context.Context, loader assets.Loader, dir string) (*assets.Package, error) {
+	return assets.LoadProjectPackage(ctx, loader, dir)
+}
+
+// WritePackage serializes a package back to .fui bytes. Combined with
+// Package.RenameItem, RemoveItem and ReplaceString it allows patching
+// published packages without the editor.
+//
+// Example:
+//   pkg, _ := fgui.ParsePackage(data, "assets/Basics")
+//   pkg.ReplaceString("Old title", "New title")
+//   out, err := fgui.WritePackage(pkg, fgui.WriteOptions{Compress: true})
+func WritePackage(pkg *assets.Package, opts WriteOptions) ([]byte, error) {
+	return assets.WritePackage(pkg, opts)
+}
+
 // NewFileLoader creates a loader that reads assets from the filesystem.
 //
 // Parameters:
@@ -242,6 +337,35 @@ func NewFileLoader(root string) *assets.FileLoader {
 	return assets.NewFileLoader(root)
 }
 
+// NewFSLoader creates a loader that reads assets from an io/fs.FS (embed.FS, zip.Reader, os.DirFS...).
+//
+// Parameters:
+//   - fsys: Filesystem containing .fui files
+//   - root: Directory inside fsys holding the assets ("" for the top level)
+//
+// Example:
+//   //go:embed assets
+//   var uiFS embed.FS
+//   loader := fgui.NewFSLoader(uiFS, "assets")
+func NewFSLoader(fsys fs.FS, root string) *assets.FSLoader {
+	return assets.NewFSLoader(fsys, root)
+}
+
+// NewBatchLoader wraps a loader so batch loads run in parallel, report progress
+// and stop promptly when the context is cancelled.
+//
+// Parameters:
+//   - loader: Underlying loader (FileLoader, FSLoader, ...)
+//   - concurrency: Maximum parallel loads (<= 0 uses GOMAXPROCS)
+//
+// Example:
+//   batch := fgui.NewBatchLoader(fgui.NewFileLoader("./assets"), 4)
+//   batch.OnProgress = func(p fgui.BatchProgress) { bar.SetValue(float64(p.Completed)) }
+//   atlas := render.NewAtlasManager(batch)
+func NewBatchLoader(loader assets.Loader, concurrency int) *assets.BatchLoader {
+	return assets.NewBatchLoader(loader, concurrency)
+}
+
 // GetPackageByName 通过包名获取包
 // 对应 TypeScript 版本的 UIPackage.getByName
 func GetPackageByName(name string) *assets.Package {
@@ -260,6 +384,36 @@ func GetItemByURL(url string) *assets.PackageItem {
 	return assets.GetItemByURL(url)
 }
 
+// SetBranch 切换全局分支，并刷新 GRoot 下所有 GLoader 的内容
+// 已经构建的组件不会自动替换，需要时请重新调用 BuildComponent
+// 对应 TypeScript 版本的 UIPackage.branch
+func SetBranch(name string) {
+	assets.SetBranch(name)
+	widgets.ReloadLoaders(core.Inst().GComponent)
+}
+
+// Branch 返回当前分支名称
+func Branch() string {
+	return assets.Branch()
+}
+
+// ReloadLoaders 刷新 root 下所有 GLoader，使其指向当前分支的资源
+func ReloadLoaders(root *core.GComponent) int {
+	return widgets.ReloadLoaders(root)
+}
+
+// LoadTranslationXML 加载编辑器导出的 XML 字符串表并切换为当前语言
+// 之后构建的组件会使用翻译后的文本，已构建的组件会被重新翻译
+// 对应 TypeScript 版本的 TranslationHelper.loadFromXML
+func LoadTranslationXML(data []byte) error {
+	return assets.LoadTranslationXML(data)
+}
+
+// SetStringTable 切换当前语言的字符串表，nil 表示恢复为包内原文
+func SetStringTable(table StringTable) {
+	assets.SetStringTable(table)
+}
+
 // CreateObject 从包中创建对象
 // 对应 TypeScript 版本的 UIPackage.createObject
 func CreateObject(pkgName, resName string) *core.GObject {
@@ -281,6 +435,31 @@ func CreateObject(pkgName, resName string) *core.GObject {
 	return comp.GObject
 }
 
+// SetExtension 注册自定义组件类型，之后由该资源创建的对象都使用 fn 构造
+// 对应 TypeScript 版本的 UIObjectFactory.setExtension
+//
+// Parameters:
+//   - url: 资源URL (格式: ui://packageId+itemId、ui://包名/资源名 或 包名/资源名)
+//   - fn: 返回内置控件或内嵌内置控件的结构体指针
+//
+// Example:
+//   type ItemCell struct{ *fgui.GButton }
+//   fgui.SetExtension("ui://Bag/ItemCell", func() any { return &ItemCell{GButton: widgets.NewButton()} })
+//   cell := fgui.ExtensionOf(list.ChildAt(0)).(*ItemCell)
+func SetExtension(url string, fn ExtensionFunc) error {
+	return widgets.SetExtension(url, fn)
+}
+
+// RemoveExtension 移除 SetExtension 的注册
+func RemoveExtension(url string) {
+	widgets.RemoveExtension(url)
+}
+
+// ExtensionOf 返回由 SetExtension 注册的构造函数创建的自定义实例
+func ExtensionOf(obj *core.GObject) any {
+	return widgets.ExtensionOf(obj)
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // UIConfig API
 // ────────────────────────────────────────────────────────────────────────────
@@ -331,6 +510,21 @@ func SetDefaultPopupMenu(menuURL string) {
 	config.PopupMenu = menuURL
 }
 
+// SetDefaultTooltips 设置全局默认提示组件资源，悬停在设置了 tooltips 的对象上时显示
+// 对应 TypeScript 版本的 UIConfig.tooltipsWin
+//
+// Parameters:
+//   - url: 提示组件的资源URL (格式: ui://packageId/itemId)，组件的标题用于显示提示文字
+//
+// 根对象需要通过 Root().SetObjectCreator 设置创建器才能创建该组件。
+//
+// Example:
+//   fgui.SetDefaultTooltips("ui://Basics/WindowFrame")
+func SetDefaultTooltips(url string) {
+	config := core.GetUIConfig()
+	config.TooltipsWin = url
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // Audio API
 // ────────────────────────────────────────────────────────────────────────────
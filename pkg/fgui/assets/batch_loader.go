@@ -0,0 +1,230 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// BatchProgress reports the completion of a single resource in a batch load.
+type BatchProgress struct {
+	Key  string
+	Type ResourceType
+	// Bytes is the size of the resource that just finished (0 on failure).
+	Bytes int
+	// Err is non-nil when the resource failed to load.
+	Err error
+
+	// Completed counts finished resources (successful or failed) including this one.
+	Completed int
+	// Total is the number of distinct resources in the batch.
+	Total int
+	// LoadedBytes is the running byte total of successfully loaded resources.
+	LoadedBytes int64
+}
+
+// BatchProgressFunc receives progress notifications. Calls are serialised and
+// made from the goroutine that invoked Load.
+type BatchProgressFunc func(BatchProgress)
+
+// FailedResource describes a resource that could not be loaded in a batch.
+type FailedResource struct {
+	Key  string
+	Type ResourceType
+	Err  error
+}
+
+// BatchError is returned by BatchLoader.Load when some resources failed. The
+// map returned alongside it still contains every resource that loaded.
+type BatchError struct {
+	Failed []FailedResource
+}
+
+// Error lists the keys that failed.
+func (e *BatchError) Error() string {
+	if e == nil || len(e.Failed) == 0 {
+		return "assets: batch load failed"
+	}
+	return fmt.Sprintf("assets: %d resource(s) failed to load: %s", len(e.Failed), strings.Join(e.Keys(), ", "))
+}
+
+// Unwrap exposes the individual load errors to errors.Is / errors.As.
+func (e *BatchError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	errs := make([]error, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// Keys returns the keys of the resources that failed.
+func (e *BatchError) Keys() []string {
+	if e == nil {
+		return nil
+	}
+	keys := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		keys[i] = f.Key
+	}
+	return keys
+}
+
+// BatchLoader wraps any Loader and loads batches with bounded parallelism.
+// Unlike FileLoader.Load it keeps going after individual failures, reports
+// progress per resource and returns as soon as the context is cancelled.
+type BatchLoader struct {
+	Loader Loader
+	// Concurrency bounds the number of in-flight LoadOne calls. Values <= 0 use GOMAXPROCS.
+	Concurrency int
+	// OnProgress, when set, is invoked after every resource completes.
+	OnProgress BatchProgressFunc
+}
+
+// NewBatchLoader wraps loader with a bounded-parallel batch implementation.
+func NewBatchLoader(loader Loader, concurrency int) *BatchLoader {
+	return &BatchLoader{Loader: loader, Concurrency: concurrency}
+}
+
+// LoadOne delegates to the wrapped loader.
+func (b *BatchLoader) LoadOne(ctx context.Context, key string, typ ResourceType) ([]byte, error) {
+	if b == nil || b.Loader == nil {
+		return nil, errors.New("assets: batch loader has no backing loader")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.Loader.LoadOne(ctx, key, typ)
+}
+
+// Load fetches every request using the configured progress callback.
+func (b *BatchLoader) Load(ctx context.Context, requests []ResourceRequest) (map[string][]byte, error) {
+	var progress BatchProgressFunc
+	if b != nil {
+		progress = b.OnProgress
+	}
+	return b.LoadWithProgress(ctx, requests, progress)
+}
+
+// LoadWithProgress fetches every request in parallel and calls progress after each
+// completion. Duplicate keys are loaded once.
+//
+// The returned map always holds the resources that loaded successfully. When some
+// requests fail the error is a *BatchError; when ctx is cancelled Load returns
+// promptly with ctx.Err() without waiting for in-flight LoadOne calls.
+func (b *BatchLoader) LoadWithProgress(ctx context.Context, requests []ResourceRequest, progress BatchProgressFunc) (map[string][]byte, error) {
+	if b == nil || b.Loader == nil {
+		return nil, errors.New("assets: batch loader has no backing loader")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	unique := make([]ResourceRequest, 0, len(requests))
+	seen := make(map[string]struct{}, len(requests))
+	for _, req := range requests {
+		if _, ok := seen[req.Key]; ok {
+			continue
+		}
+		seen[req.Key] = struct{}{}
+		unique = append(unique, req)
+	}
+
+	result := make(map[string][]byte, len(unique))
+	if len(unique) == 0 {
+		return result, ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	workers := b.Concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(unique) {
+		workers = len(unique)
+	}
+
+	type outcome struct {
+		req  ResourceRequest
+		data []byte
+		err  error
+	}
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan ResourceRequest)
+	// Buffered so that workers never block on a caller that already returned.
+	results := make(chan outcome, len(unique))
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for req := range jobs {
+				if err := workCtx.Err(); err != nil {
+					results <- outcome{req: req, err: err}
+					continue
+				}
+				data, err := b.Loader.LoadOne(workCtx, req.Key, req.Type)
+				results <- outcome{req: req, data: data, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, req := range unique {
+			select {
+			case jobs <- req:
+			case <-workCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		failed      []FailedResource
+		completed   int
+		loadedBytes int64
+	)
+	for completed < len(unique) {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case out := <-results:
+			completed++
+			if out.err != nil {
+				failed = append(failed, FailedResource{Key: out.req.Key, Type: out.req.Type, Err: out.err})
+			} else {
+				result[out.req.Key] = out.data
+				loadedBytes += int64(len(out.data))
+			}
+			if progress != nil {
+				progress(BatchProgress{
+					Key:         out.req.Key,
+					Type:        out.req.Type,
+					Bytes:       len(out.data),
+					Err:         out.err,
+					Completed:   completed,
+					Total:       len(unique),
+					LoadedBytes: loadedBytes,
+				})
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Slice(failed, func(i, j int) bool { return failed[i].Key < failed[j].Key })
+		return result, &BatchError{Failed: failed}
+	}
+	return result, nil
+}
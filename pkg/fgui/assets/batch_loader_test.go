@@ -0,0 +1,152 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// countingLoader records the peak number of concurrent LoadOne calls.
+type countingLoader struct {
+	inner   Loader
+	delay   time.Duration
+	block   chan struct{}
+	active  int32
+	peak    int32
+	started int32
+}
+
+func (l *countingLoader) LoadOne(ctx context.Context, key string, typ ResourceType) ([]byte, error) {
+	n := atomic.AddInt32(&l.active, 1)
+	defer atomic.AddInt32(&l.active, -1)
+	atomic.AddInt32(&l.started, 1)
+	for {
+		peak := atomic.LoadInt32(&l.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&l.peak, peak, n) {
+			break
+		}
+	}
+	if l.block != nil {
+		<-l.block
+	}
+	if l.delay > 0 {
+		time.Sleep(l.delay)
+	}
+	return l.inner.LoadOne(ctx, key, typ)
+}
+
+func (l *countingLoader) Load(ctx context.Context, requests []ResourceRequest) (map[string][]byte, error) {
+	return nil, errors.New("not used")
+}
+
+func TestBatchLoaderParallelAndProgress(t *testing.T) {
+	fsys := fstest.MapFS{}
+	var reqs []ResourceRequest
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("f%02d.bin", i)
+		fsys[name] = &fstest.MapFile{Data: make([]byte, i+1)}
+		reqs = append(reqs, ResourceRequest{Key: name, Type: ResourceBinary})
+	}
+	reqs = append(reqs, reqs[0]) // duplicate key is loaded once
+
+	inner := &countingLoader{inner: NewFSLoader(fsys, ""), delay: 5 * time.Millisecond}
+	batch := NewBatchLoader(inner, 3)
+
+	var events []BatchProgress
+	data, err := batch.LoadWithProgress(context.Background(), reqs, func(p BatchProgress) {
+		events = append(events, p)
+	})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(data) != 12 {
+		t.Fatalf("expected 12 results, got %d", len(data))
+	}
+	if peak := atomic.LoadInt32(&inner.peak); peak > 3 || peak < 2 {
+		t.Fatalf("expected bounded parallelism of 3, peak was %d", peak)
+	}
+	if len(events) != 12 {
+		t.Fatalf("expected 12 progress events, got %d", len(events))
+	}
+	last := events[len(events)-1]
+	if last.Completed != 12 || last.Total != 12 {
+		t.Fatalf("unexpected final progress %+v", last)
+	}
+	if last.LoadedBytes != 78 { // 1+2+...+12
+		t.Fatalf("expected 78 loaded bytes, got %d", last.LoadedBytes)
+	}
+}
+
+func TestBatchLoaderPartialFailure(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("a")},
+		"c.txt": {Data: []byte("c")},
+	}
+	batch := NewBatchLoader(NewFSLoader(fsys, ""), 2)
+	reqs := []ResourceRequest{
+		{Key: "a.txt", Type: ResourceBinary},
+		{Key: "missing2.txt", Type: ResourceImage},
+		{Key: "c.txt", Type: ResourceBinary},
+		{Key: "missing1.txt", Type: ResourceBinary},
+	}
+	data, err := batch.Load(context.Background(), reqs)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	keys := batchErr.Keys()
+	if len(keys) != 2 || keys[0] != "missing1.txt" || keys[1] != "missing2.txt" {
+		t.Fatalf("unexpected failed keys %v", keys)
+	}
+	if batchErr.Failed[1].Type != ResourceImage {
+		t.Fatalf("expected failed resource type to be preserved, got %v", batchErr.Failed[1].Type)
+	}
+	if len(data) != 2 || string(data["a.txt"]) != "a" || string(data["c.txt"]) != "c" {
+		t.Fatalf("expected partial results, got %v", data)
+	}
+}
+
+func TestBatchLoaderCancel(t *testing.T) {
+	fsys := fstest.MapFS{}
+	var reqs []ResourceRequest
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("f%d.bin", i)
+		fsys[name] = &fstest.MapFile{Data: []byte{byte(i)}}
+		reqs = append(reqs, ResourceRequest{Key: name, Type: ResourceBinary})
+	}
+	block := make(chan struct{})
+	inner := &countingLoader{inner: NewFSLoader(fsys, ""), block: block}
+	batch := NewBatchLoader(inner, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var err error
+	go func() {
+		defer wg.Done()
+		_, err = batch.Load(ctx, reqs)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Load did not return after cancellation")
+	}
+	close(block)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if started := atomic.LoadInt32(&inner.started); started > 2 {
+		t.Fatalf("expected no more than 2 loads to start, got %d", started)
+	}
+}
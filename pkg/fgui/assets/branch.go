@@ -0,0 +1,114 @@
+package assets
+
+import "sync"
+
+// branchState 保存全局分支名称及切换回调
+// 对应 TypeScript 版本的 UIPackage._branch
+var branchState struct {
+	sync.RWMutex
+	name      string
+	listeners []func(string)
+}
+
+// SetBranch 设置当前分支并更新所有已注册包的 BranchIndex
+// 之后 ItemByID、GetItemByURL 等查找会返回分支变体；name 为空表示主干
+// 对应 TypeScript 版本的 UIPackage.branch setter
+func SetBranch(name string) {
+	branchState.Lock()
+	if branchState.name == name {
+		branchState.Unlock()
+		return
+	}
+	branchState.name = name
+	listeners := make([]func(string), len(branchState.listeners))
+	copy(listeners, branchState.listeners)
+	branchState.Unlock()
+
+	packageRegistry.RLock()
+	for _, pkg := range packageRegistry.byID {
+		pkg.applyBranch(name)
+	}
+	for _, pkg := range packageRegistry.byName {
+		pkg.applyBranch(name)
+	}
+	packageRegistry.RUnlock()
+
+	for _, fn := range listeners {
+		fn(name)
+	}
+}
+
+// Branch 返回当前分支名称
+func Branch() string {
+	branchState.RLock()
+	defer branchState.RUnlock()
+	return branchState.name
+}
+
+// OnBranchChanged 注册分支切换回调，用于刷新已创建的界面
+func OnBranchChanged(fn func(name string)) {
+	if fn == nil {
+		return
+	}
+	branchState.Lock()
+	defer branchState.Unlock()
+	branchState.listeners = append(branchState.listeners, fn)
+}
+
+// SetBranch 仅为当前包选择分支，不影响全局设置
+func (p *Package) SetBranch(name string) {
+	p.applyBranch(name)
+}
+
+func (p *Package) applyBranch(name string) {
+	if p == nil {
+		return
+	}
+	p.BranchIndex = -1
+	if name == "" {
+		return
+	}
+	for i, branch := range p.Branches {
+		if branch == name {
+			p.BranchIndex = i
+			return
+		}
+	}
+}
+
+// Branch 返回当前分支下的资源变体，没有变体时返回自身
+// 对应 TypeScript 版本的 PackageItem.getBranch
+func (item *PackageItem) Branch() *PackageItem {
+	if item == nil || len(item.Branches) == 0 || item.Owner == nil {
+		return item
+	}
+	idx := item.Owner.BranchIndex
+	if idx < 0 || idx >= len(item.Branches) {
+		return item
+	}
+	if id := item.Branches[idx]; id != "" {
+		if variant := item.Owner.itemsByID[id]; variant != nil {
+			return variant
+		}
+	}
+	return item
+}
+
+// BranchSource 返回分支变体对应的主干资源，非变体时返回自身
+func (item *PackageItem) BranchSource() *PackageItem {
+	if item == nil || item.branchSource == nil {
+		return item
+	}
+	return item.branchSource
+}
+
+// linkBranches 记录变体与主干资源的对应关系
+func linkBranches(pkg *Package) {
+	for _, item := range pkg.Items {
+		for _, id := range item.Branches {
+			if variant := pkg.itemsByID[id]; variant != nil && variant != item {
+				variant.branchSource = item
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package assets
+
+import "testing"
+
+func newBranchTestPackage() *Package {
+	pkg := &Package{
+		ID:          "brch0001",
+		Name:        "BranchPkg",
+		Branches:    []string{"en", "jp"},
+		BranchIndex: -1,
+		itemsByID:   make(map[string]*PackageItem),
+		itemsByName: make(map[string]*PackageItem),
+	}
+	add := func(item *PackageItem) {
+		item.Owner = pkg
+		pkg.Items = append(pkg.Items, item)
+		pkg.itemsByID[item.ID] = item
+		pkg.itemsByName[item.Name] = item
+	}
+	add(&PackageItem{ID: "logo", Name: "logo", Type: PackageItemTypeImage, Branches: []string{"logo_en", ""}})
+	add(&PackageItem{ID: "logo_en", Name: "en/logo", Type: PackageItemTypeImage})
+	add(&PackageItem{ID: "plain", Name: "plain", Type: PackageItemTypeImage})
+	linkBranches(pkg)
+	return pkg
+}
+
+func TestSetBranchResolvesVariants(t *testing.T) {
+	pkg := newBranchTestPackage()
+	RegisterPackage(pkg)
+	t.Cleanup(func() {
+		SetBranch("")
+		UnregisterPackage(pkg)
+	})
+
+	var notified []string
+	OnBranchChanged(func(name string) { notified = append(notified, name) })
+
+	if got := pkg.ItemByID("logo"); got.ID != "logo" {
+		t.Fatalf("expected trunk item without branch, got %s", got.ID)
+	}
+
+	SetBranch("en")
+	if pkg.BranchIndex != 0 {
+		t.Fatalf("expected branch index 0, got %d", pkg.BranchIndex)
+	}
+	if got := pkg.ItemByID("logo"); got.ID != "logo_en" {
+		t.Fatalf("ItemByID: expected logo_en, got %s", got.ID)
+	}
+	if got := pkg.ItemByName("logo"); got.ID != "logo_en" {
+		t.Fatalf("ItemByName: expected logo_en, got %s", got.ID)
+	}
+	if got := GetItemByURL("ui://brch0001logo"); got == nil || got.ID != "logo_en" {
+		t.Fatalf("GetItemByURL: expected logo_en, got %v", got)
+	}
+	if got := GetItemByURL("ui://BranchPkg/logo"); got == nil || got.ID != "logo_en" {
+		t.Fatalf("GetItemByURL by name: expected logo_en, got %v", got)
+	}
+	if got := pkg.ItemByID("plain"); got.ID != "plain" {
+		t.Fatalf("item without branches should resolve to itself, got %s", got.ID)
+	}
+	if src := pkg.ItemByID("logo").BranchSource(); src.ID != "logo" {
+		t.Fatalf("BranchSource: expected logo, got %s", src.ID)
+	}
+
+	// jp 分支没有变体时回退到主干资源
+	SetBranch("jp")
+	if got := pkg.ItemByID("logo"); got.ID != "logo" {
+		t.Fatalf("expected fallback to trunk item, got %s", got.ID)
+	}
+
+	SetBranch("unknown")
+	if pkg.BranchIndex != -1 {
+		t.Fatalf("expected branch index -1 for unknown branch, got %d", pkg.BranchIndex)
+	}
+
+	if len(notified) != 3 || notified[0] != "en" || notified[2] != "unknown" {
+		t.Fatalf("unexpected branch notifications %v", notified)
+	}
+}
+
+func TestRegisterPackageAppliesCurrentBranch(t *testing.T) {
+	SetBranch("en")
+	pkg := newBranchTestPackage()
+	RegisterPackage(pkg)
+	t.Cleanup(func() {
+		SetBranch("")
+		UnregisterPackage(pkg)
+	})
+	if got := pkg.ItemByID("logo"); got.ID != "logo_en" {
+		t.Fatalf("expected logo_en after registering under branch en, got %s", got.ID)
+	}
+}
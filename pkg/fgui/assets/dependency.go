@@ -0,0 +1,312 @@
+package assets
+
+import (
+	"sort"
+	"strings"
+)
+
+// DependencyReport 是一组包之间资源引用关系的分析结果
+type DependencyReport struct {
+	Packages []*Package
+	// References 记录每个组件、字体引用的资源，Target 已解析
+	References map[*PackageItem][]Reference
+	// Missing 列出目标包或资源不存在的引用，包括包头中声明但未加载的依赖包
+	Missing []Reference
+	// PackageCycles 列出相互引用的包，PackageManager 加载这些包时会报循环依赖
+	PackageCycles [][]*Package
+	// ComponentCycles 列出相互嵌套的组件，构建这些组件会无限递归
+	ComponentCycles [][]*PackageItem
+	// Unused 列出不被任何导出资源直接或间接引用的资源（图集除外）
+	Unused []*PackageItem
+	// UnusedAtlases 列出只被未使用资源占用的图集页
+	UnusedAtlases []*PackageItem
+	// Errors 记录读取组件数据时遇到的错误
+	Errors []error
+}
+
+// AnalyzeDependencies 建立 pkgs 之间的资源引用图，报告缺失的引用、循环引用、
+// 未使用的资源以及只被未使用资源占用的图集页。
+// 导出的资源视为入口：运行时可以通过 URL 直接创建它们。
+func AnalyzeDependencies(pkgs []*Package) *DependencyReport {
+	report := &DependencyReport{
+		Packages:   pkgs,
+		References: make(map[*PackageItem][]Reference),
+	}
+	g := newDependencyGraph(pkgs)
+
+	for _, pkg := range pkgs {
+		for _, dep := range pkg.Dependencies {
+			if g.byID[dep.ID] == nil {
+				report.Missing = append(report.Missing, Reference{Package: pkg, Kind: ReferenceDependency, URL: "ui://" + dep.ID})
+			}
+		}
+		for _, item := range pkg.Items {
+			refs, err := CollectReferences(item)
+			if err != nil {
+				report.Errors = append(report.Errors, err)
+			}
+			for i := range refs {
+				refs[i].Target = g.resolve(refs[i].URL)
+				if refs[i].Target == nil {
+					report.Missing = append(report.Missing, refs[i])
+				}
+			}
+			if len(refs) > 0 {
+				report.References[item] = refs
+			}
+		}
+	}
+
+	report.PackageCycles = g.packageCycles(report.References)
+	report.ComponentCycles = g.componentCycles(report.References)
+	used := g.reachable(report.References)
+	for _, pkg := range pkgs {
+		for _, item := range pkg.Items {
+			if item.Type != PackageItemTypeAtlas && !used[item] {
+				report.Unused = append(report.Unused, item)
+			}
+		}
+	}
+	report.UnusedAtlases = g.unusedAtlases(used)
+	return report
+}
+
+type dependencyGraph struct {
+	pkgs   []*Package
+	byID   map[string]*Package
+	byName map[string]*Package
+}
+
+func newDependencyGraph(pkgs []*Package) *dependencyGraph {
+	g := &dependencyGraph{
+		pkgs:   pkgs,
+		byID:   make(map[string]*Package, len(pkgs)),
+		byName: make(map[string]*Package, len(pkgs)),
+	}
+	for _, pkg := range pkgs {
+		g.byID[pkg.ID] = pkg
+		g.byName[strings.ToLower(pkg.Name)] = pkg
+	}
+	return g
+}
+
+// resolve 按 GetItemByURL 的规则在分析的包集合中查找资源
+func (g *dependencyGraph) resolve(url string) *PackageItem {
+	body := strings.TrimPrefix(url, "ui://")
+	if idx := strings.Index(body, "/"); idx >= 0 {
+		if pkg := g.byName[strings.ToLower(body[:idx])]; pkg != nil {
+			return pkg.ItemByName(body[idx+1:])
+		}
+		return nil
+	}
+	if len(body) <= 8 {
+		return nil
+	}
+	if pkg := g.byID[body[:8]]; pkg != nil {
+		return pkg.ItemByID(body[8:])
+	}
+	return nil
+}
+
+// edges 返回 item 直接使用的资源：数据中的引用，以及分支、高分辨率变体和图集
+func (g *dependencyGraph) edges(item *PackageItem, refs map[*PackageItem][]Reference) []*PackageItem {
+	var out []*PackageItem
+	for _, ref := range refs[item] {
+		if ref.Target != nil {
+			out = append(out, ref.Target)
+		}
+	}
+	for _, id := range item.Branches {
+		if variant := item.Owner.ItemByID(id); variant != nil {
+			out = append(out, variant)
+		}
+	}
+	for _, id := range item.HighResolution {
+		if variant := item.Owner.ItemByID(id); variant != nil {
+			out = append(out, variant)
+		}
+	}
+	return out
+}
+
+func (g *dependencyGraph) reachable(refs map[*PackageItem][]Reference) map[*PackageItem]bool {
+	used := make(map[*PackageItem]bool)
+	var stack []*PackageItem
+	for _, pkg := range g.pkgs {
+		for _, item := range pkg.Items {
+			if item.Exported {
+				used[item] = true
+				stack = append(stack, item)
+			}
+		}
+	}
+	for len(stack) > 0 {
+		item := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range g.edges(item, refs) {
+			if !used[next] {
+				used[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return used
+}
+
+// unusedAtlases 返回所有精灵都属于未使用资源的图集页，动画帧精灵归属于所在的动画
+func (g *dependencyGraph) unusedAtlases(used map[*PackageItem]bool) []*PackageItem {
+	var out []*PackageItem
+	for _, pkg := range g.pkgs {
+		owners := make(map[string]*PackageItem)
+		for _, item := range pkg.Items {
+			for _, frame := range item.Frames {
+				if frame != nil && frame.SpriteID != "" {
+					owners[frame.SpriteID] = item
+				}
+			}
+		}
+		inUse := make(map[*PackageItem]bool)
+		for id, sprite := range pkg.Sprites {
+			if sprite == nil || sprite.Atlas == nil {
+				continue
+			}
+			owner := owners[id]
+			if owner == nil {
+				owner = pkg.ItemByID(id)
+			}
+			// 找不到归属资源的精灵按已使用处理
+			if owner == nil || used[owner] {
+				inUse[sprite.Atlas] = true
+			}
+		}
+		for _, item := range pkg.Items {
+			if item.Type == PackageItemTypeAtlas && !inUse[item] {
+				out = append(out, item)
+			}
+		}
+	}
+	return out
+}
+
+func (g *dependencyGraph) packageCycles(refs map[*PackageItem][]Reference) [][]*Package {
+	index := make(map[*Package]int, len(g.pkgs))
+	for i, pkg := range g.pkgs {
+		index[pkg] = i
+	}
+	adj := make([][]int, len(g.pkgs))
+	link := func(from *Package, to *Package) {
+		if to != nil && to != from {
+			adj[index[from]] = append(adj[index[from]], index[to])
+		}
+	}
+	for _, pkg := range g.pkgs {
+		for _, dep := range pkg.Dependencies {
+			link(pkg, g.byID[dep.ID])
+		}
+		for _, item := range pkg.Items {
+			for _, ref := range refs[item] {
+				if ref.Target != nil {
+					link(pkg, ref.Target.Owner)
+				}
+			}
+		}
+	}
+	var cycles [][]*Package
+	for _, scc := range stronglyConnected(adj) {
+		if len(scc) > 1 {
+			cycle := make([]*Package, len(scc))
+			for i, n := range scc {
+				cycle[i] = g.pkgs[n]
+			}
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+func (g *dependencyGraph) componentCycles(refs map[*PackageItem][]Reference) [][]*PackageItem {
+	var nodes []*PackageItem
+	index := make(map[*PackageItem]int)
+	for _, pkg := range g.pkgs {
+		for _, item := range pkg.Items {
+			if item.Type == PackageItemTypeComponent {
+				index[item] = len(nodes)
+				nodes = append(nodes, item)
+			}
+		}
+	}
+	adj := make([][]int, len(nodes))
+	selfLoop := make([]bool, len(nodes))
+	for i, item := range nodes {
+		for _, next := range g.edges(item, refs) {
+			if j, ok := index[next]; ok {
+				adj[i] = append(adj[i], j)
+				if i == j {
+					selfLoop[i] = true
+				}
+			}
+		}
+	}
+	var cycles [][]*PackageItem
+	for _, scc := range stronglyConnected(adj) {
+		if len(scc) > 1 || selfLoop[scc[0]] {
+			cycle := make([]*PackageItem, len(scc))
+			for i, n := range scc {
+				cycle[i] = nodes[n]
+			}
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+// stronglyConnected 使用 Tarjan 算法返回强连通分量，分量内的节点按序号排序
+func stronglyConnected(adj [][]int) [][]int {
+	n := len(adj)
+	order := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range order {
+		order[i] = -1
+	}
+	var stack []int
+	var out [][]int
+	counter := 0
+
+	var visit func(v int)
+	visit = func(v int) {
+		order[v], low[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range adj[v] {
+			if order[w] < 0 {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], order[w])
+			}
+		}
+		if low[v] == order[v] {
+			var scc []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sort.Ints(scc)
+			out = append(out, scc)
+		}
+	}
+	for v := 0; v < n; v++ {
+		if order[v] < 0 {
+			visit(v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
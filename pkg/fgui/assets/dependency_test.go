@@ -0,0 +1,152 @@
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+)
+
+func loadDemoPackages(t *testing.T) []*Package {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "demo", "assets", "*.fui"))
+	if err != nil || len(files) == 0 {
+		t.Skipf("跳过测试：没有可用的 .fui 文件: %v", err)
+	}
+	pkgs := make([]*Package, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		pkg, err := ParsePackage(data, strings.TrimSuffix(file, ".fui"))
+		if err != nil {
+			t.Fatalf("parse %s: %v", file, err)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs
+}
+
+func TestAnalyzeDependenciesDemoPackages(t *testing.T) {
+	pkgs := loadDemoPackages(t)
+	report := AnalyzeDependencies(pkgs)
+	if len(report.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", report.Errors)
+	}
+	if len(report.Missing) > 0 {
+		t.Fatalf("unexpected missing references: %v", report.Missing)
+	}
+	if len(report.PackageCycles) > 0 || len(report.ComponentCycles) > 0 {
+		t.Fatalf("unexpected cycles: %v %v", report.PackageCycles, report.ComponentCycles)
+	}
+
+	var basics *Package
+	for _, pkg := range pkgs {
+		if pkg.Name == "Basics" {
+			basics = pkg
+		}
+	}
+	if basics == nil {
+		t.Skip("跳过测试：缺少 Basics 包")
+	}
+	kinds := make(map[ReferenceKind]bool)
+	for _, refs := range report.References {
+		for _, ref := range refs {
+			kinds[ref.Kind] = true
+		}
+	}
+	for _, kind := range []ReferenceKind{ReferenceChild, ReferenceIcon, ReferenceText, ReferenceFont, ReferenceListItem, ReferenceScroll, ReferenceDropdown} {
+		if !kinds[kind] {
+			t.Errorf("expected at least one %s reference in demo packages", kind)
+		}
+	}
+
+	// BMFontTest 的字形来自字体自身的精灵，编辑器中的纹理图片在运行时不会被使用
+	texture := basics.ItemByName("BMFontTest_atlas")
+	found := false
+	for _, item := range report.Unused {
+		if item == texture {
+			found = true
+		}
+		if item.Exported {
+			t.Errorf("exported item %s reported as unused", item.Name)
+		}
+	}
+	if texture != nil && !found {
+		t.Errorf("expected %s to be reported as unused", texture.Name)
+	}
+}
+
+func TestAnalyzeDependenciesMissingItem(t *testing.T) {
+	pkgs := loadDemoPackages(t)
+	var basics *Package
+	for _, pkg := range pkgs {
+		if pkg.Name == "Basics" {
+			basics = pkg
+		}
+	}
+	if basics == nil {
+		t.Skip("跳过测试：缺少 Basics 包")
+	}
+	removed := basics.ItemByName("ComboBoxPopup")
+	if removed == nil || !basics.RemoveItem(removed.ID) {
+		t.Fatalf("ComboBoxPopup not found")
+	}
+	report := AnalyzeDependencies(pkgs)
+	found := false
+	for _, ref := range report.Missing {
+		if ref.URL == "ui://"+basics.ID+removed.ID && ref.Package == basics && ref.Kind == ReferenceDropdown {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected missing reference to removed item, got %v", report.Missing)
+	}
+}
+
+// buildTestComponent 生成只包含子对象 src/pkg 的组件数据
+func buildTestComponent(pkg *Package, id string, children ...string) *PackageItem {
+	w := utils.NewByteWriter(nil)
+	block := w.BeginBlock(8, false)
+	block.Section(2)
+	w.WriteInt16(int16(len(children)))
+	for _, url := range children {
+		mark := w.MarkLength16()
+		child := w.BeginBlock(7, true)
+		child.Section(0)
+		w.WriteUint8(uint8(ObjectTypeComponent))
+		w.WriteS(url[8:])
+		w.WriteS(url[:8])
+		w.FillLength16(mark)
+	}
+	item := &PackageItem{Type: PackageItemTypeComponent, ObjectType: ObjectTypeComponent, ID: id, Name: id, RawData: w.Buffer(2)}
+	pkg.AddItem(item)
+	return item
+}
+
+func TestAnalyzeDependenciesCycles(t *testing.T) {
+	a := &Package{ID: "pkgaaaaa", Name: "A", Dependencies: []Dependency{{ID: "pkgbbbbb", Name: "B"}, {ID: "pkgzzzzz", Name: "Z"}}}
+	b := &Package{ID: "pkgbbbbb", Name: "B"}
+	buildTestComponent(a, "main", "pkgbbbbbpanel")
+	buildTestComponent(b, "panel", "pkgaaaaaloop", "pkgbbbbbnone")
+	buildTestComponent(a, "loop", "pkgbbbbbpanel")
+	a.ItemByID("main").Exported = true
+	unused := buildTestComponent(b, "unused")
+
+	report := AnalyzeDependencies([]*Package{a, b})
+	if len(report.PackageCycles) != 1 || len(report.PackageCycles[0]) != 2 {
+		t.Fatalf("expected A<->B package cycle, got %v", report.PackageCycles)
+	}
+	if len(report.ComponentCycles) != 1 || len(report.ComponentCycles[0]) != 2 {
+		t.Fatalf("expected loop<->panel component cycle, got %v", report.ComponentCycles)
+	}
+	if len(report.Missing) != 2 || report.Missing[0].Kind != ReferenceDependency || report.Missing[1].URL != "ui://pkgbbbbbnone" {
+		t.Fatalf("unexpected missing references: %v", report.Missing)
+	}
+	if len(report.Unused) != 1 || report.Unused[0] != unused {
+		t.Fatalf("expected only the unreferenced component to be unused, got %v", report.Unused)
+	}
+}
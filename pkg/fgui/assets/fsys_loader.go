@@ -0,0 +1,69 @@
+package assets
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FSLoader loads resources from an io/fs.FS, such as an embed.FS, a zip archive
+// (zip.Reader) or os.DirFS. Keys are normalised the same way as FileLoader.LoadOne,
+// using forward slashes relative to Root inside the filesystem.
+type FSLoader struct {
+	FS   fs.FS
+	Root string
+}
+
+// NewFSLoader constructs a loader that reads from fsys below the provided root
+// directory. An empty root (or ".") reads from the top of the filesystem.
+func NewFSLoader(fsys fs.FS, root string) *FSLoader {
+	return &FSLoader{FS: fsys, Root: root}
+}
+
+// LoadOne reads a single resource from the filesystem.
+func (l *FSLoader) LoadOne(ctx context.Context, key string, typ ResourceType) ([]byte, error) {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	name := l.resolve(key)
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: key, Err: fs.ErrInvalid}
+	}
+	return fs.ReadFile(l.FS, name)
+}
+
+// Load reads a batch of resources sequentially.
+func (l *FSLoader) Load(ctx context.Context, requests []ResourceRequest) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(requests))
+	for _, req := range requests {
+		data, err := l.LoadOne(ctx, req.Key, req.Type)
+		if err != nil {
+			return nil, err
+		}
+		result[req.Key] = data
+	}
+	return result, nil
+}
+
+// resolve maps a loader key onto an fs.FS path. Keys already rooted at Root are kept,
+// everything else is joined with Root; leading slashes are dropped because fs.FS
+// paths are always unrooted.
+func (l *FSLoader) resolve(key string) string {
+	name := path.Clean(filepath.ToSlash(key))
+	name = strings.TrimLeft(name, "/")
+	if name == "" {
+		name = "."
+	}
+	root := strings.Trim(path.Clean(filepath.ToSlash(l.Root)), "/")
+	if root == "" || root == "." {
+		return name
+	}
+	if name == root || strings.HasPrefix(name, root+"/") {
+		return name
+	}
+	return path.Join(root, name)
+}
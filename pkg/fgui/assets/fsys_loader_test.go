@@ -0,0 +1,104 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFSLoaderLoadOne(t *testing.T) {
+	fsys := fstest.MapFS{
+		"ui/test.bin":      {Data: []byte{0x01, 0x02, 0x03}},
+		"ui/sub/atlas.png": {Data: []byte("png")},
+	}
+
+	loader := NewFSLoader(fsys, "ui")
+	cases := []string{"test.bin", "ui/test.bin", "/test.bin", "./sub/../test.bin"}
+	for _, key := range cases {
+		data, err := loader.LoadOne(context.Background(), key, ResourceBinary)
+		if err != nil {
+			t.Fatalf("LoadOne(%q) failed: %v", key, err)
+		}
+		if len(data) != 3 {
+			t.Fatalf("LoadOne(%q) unexpected length %d", key, len(data))
+		}
+	}
+
+	if _, err := loader.LoadOne(context.Background(), "sub/atlas.png", ResourceImage); err != nil {
+		t.Fatalf("LoadOne nested failed: %v", err)
+	}
+	if _, err := loader.LoadOne(context.Background(), "missing.bin", ResourceBinary); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+}
+
+func TestFSLoaderEmptyRoot(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("hello")}}
+	for _, root := range []string{"", ".", "/"} {
+		loader := NewFSLoader(fsys, root)
+		data, err := loader.LoadOne(context.Background(), "a.txt", ResourceBinary)
+		if err != nil {
+			t.Fatalf("root %q: LoadOne failed: %v", root, err)
+		}
+		if string(data) != "hello" {
+			t.Fatalf("root %q: unexpected data %q", root, data)
+		}
+	}
+}
+
+func TestFSLoaderCancelled(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("hello")}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewFSLoader(fsys, "").LoadOne(ctx, "a.txt", ResourceBinary); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFSLoaderBatch(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("hello")},
+		"b.bin": {Data: []byte{0x10, 0x20}},
+	}
+	loader := NewFSLoader(fsys, "")
+	reqs := []ResourceRequest{
+		{Key: "a.txt", Type: ResourceBinary},
+		{Key: "b.bin", Type: ResourceBinary},
+	}
+	data, err := loader.Load(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(data) != len(reqs) {
+		t.Fatalf("expected %d results, got %d", len(reqs), len(data))
+	}
+}
+
+func TestFSLoaderDemoPackages(t *testing.T) {
+	const demoDir = "../../../demo/assets"
+	if _, err := os.Stat(demoDir); err != nil {
+		t.Skipf("demo assets not available: %v", err)
+	}
+	loader := NewFSLoader(os.DirFS("../../.."), "demo/assets")
+	for _, name := range []string{"Basics", "Bag"} {
+		data, err := loader.LoadOne(context.Background(), name+".fui", ResourceBinary)
+		if err != nil {
+			t.Fatalf("load %s: %v", name, err)
+		}
+		pkg, err := ParsePackage(data, name)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		for _, item := range pkg.Items {
+			if item.Type != PackageItemTypeAtlas {
+				continue
+			}
+			if _, err := loader.LoadOne(context.Background(), item.File, ResourceImage); err != nil {
+				t.Fatalf("load atlas %s for %s: %v", item.File, name, err)
+			}
+		}
+	}
+}
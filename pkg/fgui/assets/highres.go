@@ -0,0 +1,81 @@
+package assets
+
+// HighResolutionItem 返回 level（ContentScaleLevel，1 表示 @2x，2 表示 @3x……）对应的高清变体
+// 列表中没有该级别时依次回退到较低级别，最终返回自身
+// 对应 TypeScript 版本的 PackageItem.getHighResolution
+func (item *PackageItem) HighResolutionItem(level int) *PackageItem {
+	if item == nil || item.Owner == nil || level <= 0 || len(item.HighResolution) == 0 {
+		return item
+	}
+	if level > len(item.HighResolution) {
+		level = len(item.HighResolution)
+	}
+	for i := level - 1; i >= 0; i-- {
+		if id := item.HighResolution[i]; id != "" {
+			if variant := item.Owner.itemsByID[id]; variant != nil {
+				return variant
+			}
+		}
+	}
+	return item
+}
+
+// ResolutionScale 返回 variant 相对于 item 的像素密度（@2x 为 2）
+// 优先按尺寸计算，尺寸缺失时按 HighResolution 中的级别推算
+func (item *PackageItem) ResolutionScale(variant *PackageItem) float64 {
+	if item == nil || variant == nil || variant == item {
+		return 1
+	}
+	if item.Width > 0 && variant.Width > 0 {
+		return float64(variant.Width) / float64(item.Width)
+	}
+	if item.Height > 0 && variant.Height > 0 {
+		return float64(variant.Height) / float64(item.Height)
+	}
+	for i, id := range item.HighResolution {
+		if id == variant.ID {
+			return float64(i + 2)
+		}
+	}
+	return 1
+}
+
+// HitTestData 返回 level 下用于像素点击测试的数据，坐标始终是 item 的逻辑坐标
+// 基础资源没有点击数据而高清变体有时，按像素密度换算变体的数据
+func (item *PackageItem) HitTestData(level int) *PixelHitTestData {
+	if item == nil {
+		return nil
+	}
+	if item.PixelHitTest != nil {
+		return item.PixelHitTest
+	}
+	variant := item.HighResolutionItem(level)
+	if variant == item || variant.PixelHitTest == nil {
+		return nil
+	}
+	return variant.PixelHitTest.Scaled(item.ResolutionScale(variant))
+}
+
+// Scaled 返回按 density 换算后的点击数据副本：
+// 在逻辑坐标 (x, y) 上的查询等价于在原数据上查询 (x*density, y*density)
+func (d *PixelHitTestData) Scaled(density float64) *PixelHitTestData {
+	if d == nil || density == 1 || density <= 0 {
+		return d
+	}
+	scaled := *d
+	scaled.Scale = d.Scale * float32(density)
+	return &scaled
+}
+
+// ScaledRect 按 density 缩放矩形（用于九宫格等逻辑坐标到高清纹理坐标的换算）
+func ScaledRect(r *Rect, density float64) *Rect {
+	if r == nil || density == 1 {
+		return r
+	}
+	return &Rect{
+		X:      int(float64(r.X)*density + 0.5),
+		Y:      int(float64(r.Y)*density + 0.5),
+		Width:  int(float64(r.Width)*density + 0.5),
+		Height: int(float64(r.Height)*density + 0.5),
+	}
+}
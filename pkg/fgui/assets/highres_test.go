@@ -0,0 +1,69 @@
+package assets
+
+import "testing"
+
+func newHighResTestPackage() (*Package, *PackageItem) {
+	pkg := &Package{ID: "hres0001", Name: "HighRes"}
+	base := &PackageItem{ID: "icon", Name: "icon", Type: PackageItemTypeImage, Width: 10, Height: 8,
+		HighResolution: []string{"icon2x", ""}}
+	pkg.AddItem(base)
+	pkg.AddItem(&PackageItem{ID: "icon2x", Name: "icon@2x", Type: PackageItemTypeImage, Width: 20, Height: 16,
+		PixelHitTest: &PixelHitTestData{Width: 20, Height: 16, Scale: 1, Data: make([]byte, 40)}})
+	return pkg, base
+}
+
+func TestHighResolutionItem(t *testing.T) {
+	_, base := newHighResTestPackage()
+
+	if got := base.HighResolutionItem(0); got != base {
+		t.Fatalf("level 0 should resolve to the base item, got %s", got.ID)
+	}
+	if got := base.HighResolutionItem(1); got.ID != "icon2x" {
+		t.Fatalf("level 1 should resolve to icon2x, got %s", got.ID)
+	}
+	// @3x 缺失时回退到 @2x
+	if got := base.HighResolutionItem(2); got.ID != "icon2x" {
+		t.Fatalf("missing @3x should fall back to icon2x, got %s", got.ID)
+	}
+	if got := base.HighResolutionItem(3); got.ID != "icon2x" {
+		t.Fatalf("levels beyond the list should fall back to icon2x, got %s", got.ID)
+	}
+	if scale := base.ResolutionScale(base.HighResolutionItem(1)); scale != 2 {
+		t.Fatalf("expected density 2, got %v", scale)
+	}
+}
+
+func TestHitTestDataScalesHighResolutionVariant(t *testing.T) {
+	_, base := newHighResTestPackage()
+	if base.HitTestData(0) != nil {
+		t.Fatalf("base item has no hit test data at level 0")
+	}
+	data := base.HitTestData(1)
+	if data == nil {
+		t.Fatalf("expected hit test data from the @2x variant")
+	}
+	if data.Scale != 2 {
+		t.Fatalf("expected variant data scaled by 2, got %v", data.Scale)
+	}
+	variant := base.HighResolutionItem(1)
+	if variant.PixelHitTest.Scale != 1 {
+		t.Fatalf("variant data must not be modified")
+	}
+
+	own := &PixelHitTestData{Width: 10, Scale: 1}
+	base.PixelHitTest = own
+	if base.HitTestData(1) != own {
+		t.Fatalf("base item data is already in logical coordinates and should be used as is")
+	}
+}
+
+func TestScaledRect(t *testing.T) {
+	r := &Rect{X: 3, Y: 4, Width: 10, Height: 5}
+	if got := ScaledRect(r, 1); got != r {
+		t.Fatalf("density 1 should return the same rect")
+	}
+	got := ScaledRect(r, 2)
+	if *got != (Rect{X: 6, Y: 8, Width: 20, Height: 10}) {
+		t.Fatalf("unexpected scaled rect %+v", *got)
+	}
+}
@@ -140,6 +140,10 @@ func ParsePackage(data []byte, resKey string) (*Package, error) {
 	if err := parseItems(buf, pkg); err != nil {
 		return nil, err
 	}
+	if len(pkg.Branches) > 0 {
+		linkBranches(pkg)
+		pkg.applyBranch(Branch())
+	}
 
 	return pkg, nil
 }
@@ -181,9 +185,9 @@ func parseItems(buf *utils.ByteBuffer, pkg *Package) error {
 		item.Type = PackageItemType(uint8(buf.ReadByte()))
 		item.ID = stringValue(buf.ReadS())
 		item.Name = stringValue(buf.ReadS())
-		_ = buf.ReadS() // path (unused)
+		item.Path = stringValue(buf.ReadS())
 		item.File = stringValue(buf.ReadS())
-		_ = buf.ReadBool() // exported
+		item.Exported = buf.ReadBool()
 		item.Width = int(buf.ReadInt32())
 		item.Height = int(buf.ReadInt32())
 
@@ -294,20 +298,44 @@ func readSArrayValues(buf *utils.ByteBuffer, cnt int) []string {
 	return out
 }
 
-// ItemByID returns the package item with the given id.
+// AddItem registers an item created at runtime (tests, generated packages) so that
+// ItemByID and ItemByName can find it. The item's Owner is set to the package.
+func (p *Package) AddItem(item *PackageItem) {
+	if p == nil || item == nil {
+		return
+	}
+	if p.itemsByID == nil {
+		p.itemsByID = make(map[string]*PackageItem)
+	}
+	if p.itemsByName == nil {
+		p.itemsByName = make(map[string]*PackageItem)
+	}
+	item.Owner = p
+	p.Items = append(p.Items, item)
+	if item.ID != "" {
+		p.itemsByID[item.ID] = item
+	}
+	if item.Name != "" {
+		p.itemsByName[item.Name] = item
+	}
+}
+
+// ItemByID returns the package item with the given id, resolved to the
+// variant of the current branch (see SetBranch).
 func (p *Package) ItemByID(id string) *PackageItem {
 	if p == nil {
 		return nil
 	}
-	return p.itemsByID[id]
+	return p.itemsByID[id].Branch()
 }
 
-// ItemByName returns the package item with the given name.
+// ItemByName returns the package item with the given name, resolved to the
+// variant of the current branch.
 func (p *Package) ItemByName(name string) *PackageItem {
 	if p == nil {
 		return nil
 	}
-	return p.itemsByName[name]
+	return p.itemsByName[name].Branch()
 }
 
 func parseAtlasSprites(buf *utils.ByteBuffer, pkg *Package) error {
@@ -564,6 +592,15 @@ func parseComponentData(item *PackageItem) {
 		cd.Children = children
 	}
 
+	if buf.Seek(0, 5) {
+		count := int(buf.ReadInt16())
+		for i := 0; i < count; i++ {
+			nextPos := int(buf.ReadInt16()) + buf.Pos()
+			cd.TransitionNames = append(cd.TransitionNames, readSValue(buf))
+			_ = buf.SetPos(nextPos)
+		}
+	}
+
 	item.Component = cd
 	_ = buf.SetPos(saved)
 }
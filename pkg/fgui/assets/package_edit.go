@@ -0,0 +1,69 @@
+package assets
+
+import "fmt"
+
+// RenameItem 修改资源名称并更新按名称查找的索引，配合 WritePackage 用于发布后修补包内容
+func (p *Package) RenameItem(id, name string) error {
+	if p == nil {
+		return fmt.Errorf("assets: nil package")
+	}
+	item := p.itemsByID[id]
+	if item == nil {
+		return fmt.Errorf("assets: item %s not found in package %s", id, p.Name)
+	}
+	if other := p.itemsByName[name]; other != nil && other != item {
+		return fmt.Errorf("assets: item name %q already used by %s", name, other.ID)
+	}
+	if p.itemsByName[item.Name] == item {
+		delete(p.itemsByName, item.Name)
+	}
+	item.Name = name
+	if name != "" {
+		p.itemsByName[name] = item
+	}
+	return nil
+}
+
+// RemoveItem 从包中移除资源及其精灵、别名，返回资源是否存在
+// 引用该资源的组件数据不会被修改，调用方需确认资源已不再使用
+func (p *Package) RemoveItem(id string) bool {
+	if p == nil {
+		return false
+	}
+	item := p.itemsByID[id]
+	if item == nil {
+		return false
+	}
+	for i, it := range p.Items {
+		if it == item {
+			p.Items = append(p.Items[:i], p.Items[i+1:]...)
+			break
+		}
+	}
+	for key, it := range p.itemsByID {
+		if it == item {
+			delete(p.itemsByID, key)
+		}
+	}
+	if p.itemsByName[item.Name] == item {
+		delete(p.itemsByName, item.Name)
+	}
+	delete(p.Sprites, item.ID)
+	return true
+}
+
+// ReplaceString 将字符串表中等于 old 的条目替换为 value，返回替换的条目数
+// 组件数据按下标引用字符串表，替换后新构建的组件即使用新文本
+func (p *Package) ReplaceString(old, value string) int {
+	if p == nil {
+		return 0
+	}
+	count := 0
+	for i, s := range p.StringTable {
+		if s == old {
+			p.StringTable[i] = value
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,326 @@
+package assets
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+)
+
+// maxShortString 是字符串表中可直接保存的最大长度，更长的字符串写入长字符串分段
+const maxShortString = 0xffff
+
+// WriteOptions 控制 WritePackage 的输出
+type WriteOptions struct {
+	// Compress 使用 deflate 压缩包头之后的内容，与编辑器发布时的压缩选项一致
+	Compress bool
+}
+
+// WritePackage 将 Package 序列化为 .fui 数据，ParsePackage 读回的结果与 pkg 一致。
+// 组件、字体、动画的 RawData 原样写出，其中的字符串索引仍指向 pkg.StringTable，
+// 因此字符串表只会在末尾追加条目（例如改名后的资源名），已有条目的下标保持不变。
+func WritePackage(pkg *Package, opts WriteOptions) ([]byte, error) {
+	if pkg == nil {
+		return nil, errors.New("assets: nil package")
+	}
+	version := pkg.Version
+	w := utils.NewByteWriter(utils.NewStringIndex(pkg.StringTable))
+	pw := &packageWriter{pkg: pkg, w: w, version: version}
+
+	block := w.BeginBlock(6, false)
+	block.Section(0)
+	pw.writeDependencies()
+	block.Section(1)
+	pw.writeItems()
+	block.Section(2)
+	pw.writeSprites()
+	if pw.hasHitTests() {
+		block.Section(3)
+		pw.writeHitTests()
+	}
+	// 字符串表最后写出，前面各分段追加的字符串都已收集
+	block.Section(4)
+	long := pw.writeStringTable()
+	if len(long) > 0 {
+		block.Section(5)
+		pw.writeLongStrings(long)
+	}
+
+	header := utils.NewByteWriter(nil)
+	header.WriteUint32(packageSignature)
+	header.WriteInt32(int32(version))
+	header.WriteBool(opts.Compress)
+	header.WriteUTFString(pkg.ID)
+	header.WriteUTFString(pkg.Name)
+	header.WriteBytes(make([]byte, 20))
+
+	body := w.Bytes()
+	if opts.Compress {
+		var compressed bytes.Buffer
+		fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(body); err != nil {
+			return nil, err
+		}
+		if err := fw.Close(); err != nil {
+			return nil, err
+		}
+		body = compressed.Bytes()
+	}
+	header.WriteBytes(body)
+	return header.Bytes(), nil
+}
+
+type packageWriter struct {
+	pkg     *Package
+	w       *utils.ByteWriter
+	version int
+}
+
+func (pw *packageWriter) writeDependencies() {
+	w := pw.w
+	w.WriteInt16(int16(len(pw.pkg.Dependencies)))
+	for _, dep := range pw.pkg.Dependencies {
+		w.WriteS(dep.ID)
+		w.WriteS(dep.Name)
+	}
+	if pw.version >= 2 {
+		w.WriteInt16(int16(len(pw.pkg.Branches)))
+		for _, branch := range pw.pkg.Branches {
+			w.WriteS(branch)
+		}
+	}
+}
+
+func (pw *packageWriter) writeItems() {
+	w := pw.w
+	pkg := pw.pkg
+	w.WriteUint16(uint16(len(pkg.Items)))
+
+	aliases := make(map[*PackageItem]string)
+	if len(pkg.Branches) == 0 {
+		for id, item := range pkg.itemsByID {
+			if id != item.ID {
+				aliases[item] = id
+			}
+		}
+	}
+
+	for _, item := range pkg.Items {
+		mark := w.MarkLength32()
+		name, branchPath := item.Name, ""
+		if pw.version >= 2 {
+			if idx := strings.LastIndex(name, "/"); idx >= 0 {
+				branchPath, name = name[:idx], name[idx+1:]
+			}
+		}
+		w.WriteUint8(uint8(item.Type))
+		w.WriteS(item.ID)
+		w.WriteS(name)
+		w.WriteS(item.Path)
+		w.WriteS(pw.relativeFile(item))
+		w.WriteBool(item.Exported)
+		w.WriteInt32(int32(item.Width))
+		w.WriteInt32(int32(item.Height))
+
+		switch item.Type {
+		case PackageItemTypeImage:
+			switch {
+			case item.Scale9Grid != nil:
+				w.WriteUint8(1)
+				w.WriteInt32(int32(item.Scale9Grid.X))
+				w.WriteInt32(int32(item.Scale9Grid.Y))
+				w.WriteInt32(int32(item.Scale9Grid.Width))
+				w.WriteInt32(int32(item.Scale9Grid.Height))
+				w.WriteInt32(int32(item.TileGridIndice))
+			case item.ScaleByTile:
+				w.WriteUint8(2)
+			default:
+				w.WriteUint8(0)
+			}
+			w.WriteBool(item.Smoothing)
+		case PackageItemTypeMovieClip:
+			w.WriteBool(item.Smoothing)
+			pw.writeRawData(item)
+		case PackageItemTypeFont:
+			pw.writeRawData(item)
+		case PackageItemTypeComponent:
+			if item.ObjectType == ObjectTypeComponent {
+				w.WriteUint8(0)
+			} else {
+				w.WriteUint8(uint8(item.ObjectType))
+			}
+			pw.writeRawData(item)
+		case PackageItemTypeSpine, PackageItemTypeDragonBones:
+			anchor := Point{}
+			if item.SkeletonAnchor != nil {
+				anchor = *item.SkeletonAnchor
+			}
+			w.WriteFloat32(anchor.X)
+			w.WriteFloat32(anchor.Y)
+		}
+
+		if pw.version >= 2 {
+			w.WriteS(branchPath)
+			switch {
+			case len(pkg.Branches) > 0:
+				w.WriteUint8(uint8(len(item.Branches)))
+				for _, id := range item.Branches {
+					w.WriteS(id)
+				}
+			case aliases[item] != "":
+				w.WriteUint8(1)
+				w.WriteS(aliases[item])
+			default:
+				w.WriteUint8(0)
+			}
+			w.WriteUint8(uint8(len(item.HighResolution)))
+			for _, id := range item.HighResolution {
+				w.WriteS(id)
+			}
+		}
+		w.FillLength32(mark)
+	}
+}
+
+// relativeFile 去掉 ParsePackage 为图集、声音等文件添加的包路径前缀
+func (pw *packageWriter) relativeFile(item *PackageItem) string {
+	file := item.File
+	switch item.Type {
+	case PackageItemTypeAtlas, PackageItemTypeSound, PackageItemTypeMisc:
+		file = strings.TrimPrefix(file, pw.pkg.ResKey+"_")
+	case PackageItemTypeSpine, PackageItemTypeDragonBones:
+		if idx := strings.LastIndex(pw.pkg.ResKey, "/"); idx != -1 {
+			file = strings.TrimPrefix(file, pw.pkg.ResKey[:idx+1])
+		}
+	}
+	return file
+}
+
+func (pw *packageWriter) writeRawData(item *PackageItem) {
+	var data []byte
+	if item.RawData != nil {
+		data = item.RawData.Bytes()
+	}
+	pw.w.WriteUint32(uint32(len(data)))
+	pw.w.WriteBytes(data)
+}
+
+// writeSprites 按资源顺序写出精灵，不对应资源的精灵（如动画帧）按 ID 排序写在最后
+func (pw *packageWriter) writeSprites() {
+	w := pw.w
+	ids := make([]string, 0, len(pw.pkg.Sprites))
+	written := make(map[string]bool, len(pw.pkg.Sprites))
+	for _, item := range pw.pkg.Items {
+		if _, ok := pw.pkg.Sprites[item.ID]; ok && !written[item.ID] {
+			ids = append(ids, item.ID)
+			written[item.ID] = true
+		}
+	}
+	rest := make([]string, 0)
+	for id := range pw.pkg.Sprites {
+		if !written[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	ids = append(ids, rest...)
+
+	w.WriteUint16(uint16(len(ids)))
+	for _, id := range ids {
+		sprite := pw.pkg.Sprites[id]
+		mark := w.MarkLength16()
+		w.WriteS(id)
+		if sprite.Atlas != nil {
+			w.WriteS(sprite.Atlas.ID)
+		} else {
+			w.WriteS("")
+		}
+		w.WriteInt32(int32(sprite.Rect.X))
+		w.WriteInt32(int32(sprite.Rect.Y))
+		w.WriteInt32(int32(sprite.Rect.Width))
+		w.WriteInt32(int32(sprite.Rect.Height))
+		w.WriteBool(sprite.Rotated)
+		if pw.version >= 2 {
+			trimmed := sprite.Offset != (Point{}) ||
+				sprite.OriginalSize != Point{X: float32(sprite.Rect.Width), Y: float32(sprite.Rect.Height)}
+			w.WriteBool(trimmed)
+			if trimmed {
+				w.WriteInt32(int32(sprite.Offset.X))
+				w.WriteInt32(int32(sprite.Offset.Y))
+				w.WriteInt32(int32(sprite.OriginalSize.X))
+				w.WriteInt32(int32(sprite.OriginalSize.Y))
+			}
+		}
+		w.FillLength16(mark)
+	}
+}
+
+func (pw *packageWriter) hasHitTests() bool {
+	for _, item := range pw.pkg.Items {
+		if item.Type == PackageItemTypeImage && item.PixelHitTest != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// writeHitTests 写出像素点击数据，格式与 PixelHitTestData.Load 一致
+func (pw *packageWriter) writeHitTests() {
+	w := pw.w
+	var items []*PackageItem
+	for _, item := range pw.pkg.Items {
+		if item.Type == PackageItemTypeImage && item.PixelHitTest != nil {
+			items = append(items, item)
+		}
+	}
+	w.WriteUint16(uint16(len(items)))
+	for _, item := range items {
+		data := item.PixelHitTest
+		mark := w.MarkLength32()
+		w.WriteS(item.ID)
+		w.WriteInt32(0) // reserved
+		w.WriteInt32(int32(data.Width))
+		scale := 1
+		if data.Scale > 0 {
+			scale = int(math.Round(float64(1 / data.Scale)))
+		}
+		w.WriteUint8(uint8(scale))
+		w.WriteInt32(int32(len(data.Data)))
+		w.WriteBytes(data.Data)
+		w.FillLength32(mark)
+	}
+}
+
+// writeStringTable 写出字符串表，返回超出长度上限、需要写入长字符串分段的下标
+func (pw *packageWriter) writeStringTable() []int {
+	w := pw.w
+	table := w.Strings.Table
+	var long []int
+	w.WriteInt32(int32(len(table)))
+	for i, s := range table {
+		if len(s) > maxShortString {
+			long = append(long, i)
+			s = ""
+		}
+		w.WriteUTFString(s)
+	}
+	return long
+}
+
+func (pw *packageWriter) writeLongStrings(indices []int) {
+	w := pw.w
+	w.WriteInt32(int32(len(indices)))
+	for _, idx := range indices {
+		s := w.Strings.Table[idx]
+		w.WriteUint16(uint16(idx))
+		w.WriteInt32(int32(len(s)))
+		w.WriteBytes([]byte(s))
+	}
+}
@@ -0,0 +1,139 @@
+package assets
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWritePackageRoundTripDemoPackages(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "demo", "assets", "*.fui"))
+	if err != nil || len(files) == 0 {
+		t.Skipf("跳过测试：没有可用的 .fui 文件: %v", err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".fui")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("read %s: %v", file, err)
+			}
+			want, err := ParsePackage(data, "demo/"+name)
+			if err != nil {
+				t.Fatalf("parse %s: %v", file, err)
+			}
+			for _, compress := range []bool{false, true} {
+				out, err := WritePackage(want, WriteOptions{Compress: compress})
+				if err != nil {
+					t.Fatalf("write (compress=%v): %v", compress, err)
+				}
+				got, err := ParsePackage(out, "demo/"+name)
+				if err != nil {
+					t.Fatalf("parse written package (compress=%v): %v", compress, err)
+				}
+				comparePackages(t, got, want)
+			}
+		})
+	}
+}
+
+func comparePackages(t *testing.T, got, want *Package) {
+	t.Helper()
+	if got.ID != want.ID || got.Name != want.Name || got.Version != want.Version {
+		t.Fatalf("header: got %s/%s/v%d, want %s/%s/v%d", got.ID, got.Name, got.Version, want.ID, want.Name, want.Version)
+	}
+	if !reflect.DeepEqual(got.Dependencies, want.Dependencies) || !reflect.DeepEqual(got.Branches, want.Branches) {
+		t.Errorf("dependencies/branches: got %v %v, want %v %v", got.Dependencies, got.Branches, want.Dependencies, want.Branches)
+	}
+	if !reflect.DeepEqual(got.StringTable, want.StringTable) {
+		t.Errorf("string table differs: %d vs %d entries", len(got.StringTable), len(want.StringTable))
+	}
+	if len(got.Items) != len(want.Items) {
+		t.Fatalf("got %d items, want %d", len(got.Items), len(want.Items))
+	}
+	for i, w := range want.Items {
+		g := got.Items[i]
+		if g.Type != w.Type || g.ObjectType != w.ObjectType || g.ID != w.ID || g.Name != w.Name || g.Path != w.Path ||
+			g.File != w.File || g.Exported != w.Exported || g.Width != w.Width || g.Height != w.Height ||
+			!reflect.DeepEqual(g.Scale9Grid, w.Scale9Grid) || g.TileGridIndice != w.TileGridIndice ||
+			g.ScaleByTile != w.ScaleByTile || g.Smoothing != w.Smoothing ||
+			!reflect.DeepEqual(g.Branches, w.Branches) || !reflect.DeepEqual(g.HighResolution, w.HighResolution) ||
+			!reflect.DeepEqual(g.SkeletonAnchor, w.SkeletonAnchor) {
+			t.Errorf("item %s metadata differs:\n got  %+v\n want %+v", w.ID, g, w)
+			continue
+		}
+		if (g.RawData == nil) != (w.RawData == nil) || g.RawData != nil && !bytes.Equal(g.RawData.Bytes(), w.RawData.Bytes()) {
+			t.Errorf("item %s raw data differs", w.ID)
+		}
+		if !reflect.DeepEqual(g.Component, w.Component) {
+			t.Errorf("item %s component data differs", w.ID)
+		}
+		if !reflect.DeepEqual(g.PixelHitTest, w.PixelHitTest) {
+			t.Errorf("item %s hit test differs: %+v vs %+v", w.ID, g.PixelHitTest, w.PixelHitTest)
+		}
+		if g.Interval != w.Interval || len(g.Frames) != len(w.Frames) {
+			t.Errorf("item %s movie clip differs", w.ID)
+		}
+	}
+	if len(got.Sprites) != len(want.Sprites) {
+		t.Errorf("got %d sprites, want %d", len(got.Sprites), len(want.Sprites))
+	}
+	for id, w := range want.Sprites {
+		g := got.Sprites[id]
+		if g == nil || g.Rect != w.Rect || g.Offset != w.Offset || g.OriginalSize != w.OriginalSize || g.Rotated != w.Rotated ||
+			(g.Atlas == nil) != (w.Atlas == nil) || g.Atlas != nil && g.Atlas.ID != w.Atlas.ID {
+			t.Errorf("sprite %s differs:\n got  %+v\n want %+v", id, g, w)
+		}
+	}
+}
+
+func TestPackageEditing(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "demo", "assets", "Basics.fui"))
+	if err != nil {
+		t.Skipf("跳过测试：无法读取 .fui 文件: %v", err)
+	}
+	pkg, err := ParsePackage(data, "demo/Basics")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	main := pkg.ItemByName("Main")
+	if main == nil {
+		t.Fatalf("Main not found")
+	}
+	if err := pkg.RenameItem(main.ID, "Entry"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := pkg.RenameItem(main.ID, "Demo_Button"); err == nil {
+		t.Fatalf("expected error when renaming onto an existing name")
+	}
+	removed := pkg.ItemByName("Demo_Text")
+	if !pkg.RemoveItem(removed.ID) || pkg.RemoveItem(removed.ID) {
+		t.Fatalf("RemoveItem should report the first removal only")
+	}
+	if n := pkg.ReplaceString("Text with outline", "Outlined"); n == 0 {
+		t.Fatalf("expected string table replacement")
+	}
+
+	out, err := WritePackage(pkg, WriteOptions{Compress: true})
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	edited, err := ParsePackage(out, "demo/Basics")
+	if err != nil {
+		t.Fatalf("parse edited: %v", err)
+	}
+	if edited.ItemByName("Entry") == nil || edited.ItemByName("Main") != nil {
+		t.Fatalf("rename not persisted")
+	}
+	if edited.ItemByID(removed.ID) != nil || len(edited.Items) != len(pkg.Items) {
+		t.Fatalf("removed item still present")
+	}
+	for _, s := range edited.StringTable {
+		if s == "Text with outline" {
+			t.Fatalf("replaced string still present")
+		}
+	}
+}
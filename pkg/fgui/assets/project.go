@@ -0,0 +1,511 @@
+package assets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+)
+
+// projectPackageVersion 是编辑器工程编译出的组件数据所对应的发布格式版本
+const projectPackageVersion = 7
+
+// LoadProjectPackage 直接读取编辑器工程目录（package.xml、组件 XML、原始图片），
+// 构建与发布后的 .fui 等价的 Package，省去发布步骤，便于设计迭代。
+// dir 是包目录相对 loader 根目录的路径，例如以 demo/UIProject/assets 为根时的 "Basics"。
+//
+// 与发布结果的差异：
+//   - 每张图片都是独立的纹理：图片条目的 Atlas 指向 ID 为 "<图片ID>_atlas" 的合成图集条目，
+//     其 File 即图片文件本身，render.AtlasManager 按原样加载
+//   - 动画（.jta）的每帧图片内嵌在动画文件中，对应的合成图集条目通过 RawData 携带图片数据
+//   - 编辑器专用内容（非高级组等）不会出现在组件数据中
+func LoadProjectPackage(ctx context.Context, loader Loader, dir string) (*Package, error) {
+	if loader == nil {
+		return nil, fmt.Errorf("assets: loader is nil")
+	}
+	dir = strings.TrimSuffix(path.Clean(strings.ReplaceAll(dir, "\\", "/")), "/")
+	data, err := loader.LoadOne(ctx, path.Join(dir, "package.xml"), ResourceBinary)
+	if err != nil {
+		return nil, fmt.Errorf("assets: load project package %s: %w", dir, err)
+	}
+	desc, err := parseXMLNode(data)
+	if err != nil {
+		return nil, fmt.Errorf("assets: parse %s/package.xml: %w", dir, err)
+	}
+	if desc.Name != "packageDescription" {
+		return nil, fmt.Errorf("assets: %s/package.xml is not a package description", dir)
+	}
+
+	name := desc.Child("publish").Attr("name")
+	if name == "" {
+		name = path.Base(dir)
+	}
+	pkg := &Package{
+		ResKey:      dir,
+		ID:          desc.Attr("id"),
+		Name:        name,
+		Version:     projectPackageVersion,
+		BranchIndex: -1,
+		itemsByID:   make(map[string]*PackageItem),
+		itemsByName: make(map[string]*PackageItem),
+		Sprites:     make(map[string]*AtlasSprite),
+	}
+
+	p := &projectLoader{
+		ctx:        ctx,
+		loader:     loader,
+		dir:        dir,
+		pkg:        pkg,
+		strings:    utils.NewStringIndex(nil),
+		components: make(map[*PackageItem]*xmlNode),
+		fonts:      make(map[*PackageItem]*xmlNode),
+		images:     make(map[string][]byte),
+	}
+	for _, res := range desc.Child("resources").Children {
+		if err := p.addItem(res); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// ComponentSource 是一个编辑器格式的组件 XML，内容与编辑器工程中的组件文件相同
+type ComponentSource struct {
+	ID       string
+	Name     string
+	Exported bool
+	XML      []byte
+}
+
+// CompileComponents 把编辑器格式的组件 XML 编译为内存中的包，结果与 LoadProjectPackage
+// 读取只包含这些组件的工程相同，供不经过编辑器生成界面的工具（如 spec 包）使用。
+// 组件之间通过 src 属性引用彼此的 ID；图片等其它资源需通过 pkg 属性引用已加载的包
+func CompileComponents(id, name string, sources []ComponentSource) (*Package, error) {
+	pkg := &Package{
+		ResKey:      name,
+		ID:          id,
+		Name:        name,
+		Version:     projectPackageVersion,
+		BranchIndex: -1,
+		itemsByID:   make(map[string]*PackageItem),
+		itemsByName: make(map[string]*PackageItem),
+		Sprites:     make(map[string]*AtlasSprite),
+	}
+	p := &projectLoader{
+		pkg:        pkg,
+		strings:    utils.NewStringIndex(nil),
+		components: make(map[*PackageItem]*xmlNode),
+		fonts:      make(map[*PackageItem]*xmlNode),
+		images:     make(map[string][]byte),
+	}
+	for _, src := range sources {
+		if src.ID == "" || pkg.ItemByID(src.ID) != nil {
+			return nil, fmt.Errorf("assets: component %s: missing or duplicate id %q", src.Name, src.ID)
+		}
+		root, err := parseXMLNode(src.XML)
+		if err != nil {
+			return nil, fmt.Errorf("assets: parse component %s: %w", src.Name, err)
+		}
+		item := &PackageItem{ID: src.ID, Name: src.Name, Exported: src.Exported}
+		p.addComponent(item, root)
+		pkg.AddItem(item)
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// projectLoader 保存加载编辑器工程时的中间状态
+type projectLoader struct {
+	ctx     context.Context
+	loader  Loader
+	dir     string
+	pkg     *Package
+	strings *utils.StringIndex
+
+	components map[*PackageItem]*xmlNode
+	fonts      map[*PackageItem]*xmlNode
+	images     map[string][]byte // 图片 ID -> 文件内容，用于生成像素点击数据
+}
+
+// addItem 按 package.xml 中的资源条目创建 PackageItem
+func (p *projectLoader) addItem(res *xmlNode) error {
+	id := res.Attr("id")
+	if id == "" {
+		return nil
+	}
+	file := path.Join(p.dir, res.Attr("path"), res.Attr("name"))
+	item := &PackageItem{
+		ID:       id,
+		Name:     strings.TrimSuffix(res.Attr("name"), path.Ext(res.Attr("name"))),
+		Path:     res.Attr("path"),
+		File:     file,
+		Exported: res.Bool("exported", false),
+	}
+
+	switch res.Name {
+	case "image":
+		data, err := p.loader.LoadOne(p.ctx, file, ResourceImage)
+		if err != nil {
+			return fmt.Errorf("assets: load image %s: %w", file, err)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("assets: decode image %s: %w", file, err)
+		}
+		item.Type = PackageItemTypeImage
+		item.ObjectType = ObjectTypeImage
+		item.Width, item.Height = cfg.Width, cfg.Height
+		item.Smoothing = res.Bool("smoothing", true)
+		switch res.Attr("scale") {
+		case "9grid":
+			x, y, w, h := 0, 0, 0, 0
+			if vals := res.Ints("scale9grid"); len(vals) >= 4 {
+				x, y, w, h = vals[0], vals[1], vals[2], vals[3]
+			}
+			item.Scale9Grid = &Rect{X: x, Y: y, Width: w, Height: h}
+			item.TileGridIndice = res.Int("gridTile", 0)
+		case "tile":
+			item.ScaleByTile = true
+		}
+		// 每张图片对应一个只包含自身的图集
+		atlas := &PackageItem{
+			Type:   PackageItemTypeAtlas,
+			ID:     id + "_atlas",
+			File:   file,
+			Width:  cfg.Width,
+			Height: cfg.Height,
+		}
+		sprite := &AtlasSprite{
+			Atlas:        atlas,
+			Rect:         Rect{Width: cfg.Width, Height: cfg.Height},
+			OriginalSize: Point{X: float32(cfg.Width), Y: float32(cfg.Height)},
+		}
+		item.Atlas = atlas
+		item.Sprite = sprite
+		p.pkg.Sprites[id] = sprite
+		p.pkg.AddItem(atlas)
+		p.images[id] = data
+	case "movieclip":
+		data, err := p.loader.LoadOne(p.ctx, file, ResourceBinary)
+		if err != nil {
+			return fmt.Errorf("assets: load movie clip %s: %w", file, err)
+		}
+		item.Type = PackageItemTypeMovieClip
+		item.ObjectType = ObjectTypeMovieClip
+		item.Smoothing = res.Bool("smoothing", true)
+		if err := p.loadMovieClip(item, data); err != nil {
+			return fmt.Errorf("assets: decode movie clip %s: %w", file, err)
+		}
+	case "sound":
+		item.Type = PackageItemTypeSound
+	case "font":
+		item.Type = PackageItemTypeFont
+		p.fonts[item] = res
+	case "component":
+		data, err := p.loader.LoadOne(p.ctx, file, ResourceBinary)
+		if err != nil {
+			return fmt.Errorf("assets: load component %s: %w", file, err)
+		}
+		root, err := parseXMLNode(data)
+		if err != nil {
+			return fmt.Errorf("assets: parse component %s: %w", file, err)
+		}
+		p.addComponent(item, root)
+	default:
+		item.Type = PackageItemTypeMisc
+	}
+	p.pkg.AddItem(item)
+	return nil
+}
+
+// addComponent 把组件条目与其 XML 根节点关联，XML 在 compile 中统一编译
+func (p *projectLoader) addComponent(item *PackageItem, root *xmlNode) {
+	item.Type = PackageItemTypeComponent
+	item.ObjectType = componentExtension(root)
+	item.Width, item.Height = pairInts(root.Attr("size"))
+	p.components[item] = root
+}
+
+// loadMovieClip 解析编辑器动画文件（.jta）：
+// 头部依次为 UTF 标识、4 字节格式版本、8 字节保留、宽、高（int16）、速度（uint8，每帧的 1/24 秒数）、帧数（int32）；
+// 每帧为延迟帧数、x、y、宽、高、图片序号（int16）；之后是图片数（int16）和以 int32 长度为前缀的 PNG 数据
+func (p *projectLoader) loadMovieClip(item *PackageItem, data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("truncated data: %v", r)
+		}
+	}()
+	buf := utils.NewByteBuffer(data)
+	_ = buf.ReadUTFString()
+	if err := buf.Skip(12); err != nil {
+		return err
+	}
+	item.Width = int(buf.ReadInt16())
+	item.Height = int(buf.ReadInt16())
+	item.Interval = int(buf.ReadUint8()) * 1000 / transitionFPS
+
+	type frameInfo struct{ delay, x, y, w, h, image int }
+	frames := make([]frameInfo, buf.ReadInt32())
+	for i := range frames {
+		frames[i] = frameInfo{
+			delay: int(buf.ReadInt16()),
+			x:     int(buf.ReadInt16()),
+			y:     int(buf.ReadInt16()),
+			w:     int(buf.ReadInt16()),
+			h:     int(buf.ReadInt16()),
+			image: int(buf.ReadInt16()),
+		}
+	}
+
+	// 每张帧图片对应一个合成图集，图片数据保存在图集条目的 RawData 中
+	sprites := make([]string, buf.ReadInt16())
+	for i := range sprites {
+		png := buf.ReadBytes(int(buf.ReadInt32()))
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(png))
+		if err != nil {
+			return err
+		}
+		id := item.ID + "_" + strconv.Itoa(i)
+		atlas := &PackageItem{
+			Type:    PackageItemTypeAtlas,
+			ID:      id + "_atlas",
+			File:    item.File + "#" + strconv.Itoa(i),
+			Width:   cfg.Width,
+			Height:  cfg.Height,
+			RawData: utils.NewByteBuffer(png),
+		}
+		p.pkg.AddItem(atlas)
+		p.pkg.Sprites[id] = &AtlasSprite{
+			Atlas:        atlas,
+			Rect:         Rect{Width: cfg.Width, Height: cfg.Height},
+			OriginalSize: Point{X: float32(cfg.Width), Y: float32(cfg.Height)},
+		}
+		sprites[i] = id
+	}
+
+	item.Frames = make([]*MovieClipFrame, len(frames))
+	for i, f := range frames {
+		frame := &MovieClipFrame{
+			AddDelay: f.delay * 1000 / transitionFPS,
+			OffsetX:  f.x,
+			OffsetY:  f.y,
+			Width:    f.w,
+			Height:   f.h,
+		}
+		if f.image >= 0 && f.image < len(sprites) {
+			frame.SpriteID = sprites[f.image]
+			frame.Sprite = p.pkg.Sprites[frame.SpriteID]
+		}
+		item.Frames[i] = frame
+	}
+	return nil
+}
+
+// compile 编译组件与字体数据，并补全字符串表、依赖和像素点击数据
+func (p *projectLoader) compile() error {
+	raw := make(map[*PackageItem][]byte, len(p.components)+len(p.fonts))
+	hitTests := make(map[string]bool)
+	for _, item := range p.pkg.Items {
+		if root := p.components[item]; root != nil {
+			raw[item] = compileComponentXML(root, projectPackageVersion, p.strings)
+			for _, node := range root.Child("displayList").ChildrenNamed("image") {
+				if node.Bool("forHitTest", false) {
+					hitTests[node.Attr("src")] = true
+				}
+			}
+		}
+	}
+	for _, item := range p.pkg.Items {
+		res := p.fonts[item]
+		if res == nil {
+			continue
+		}
+		data, err := p.loader.LoadOne(p.ctx, item.File, ResourceBinary)
+		if err != nil {
+			return fmt.Errorf("assets: load font %s: %w", item.File, err)
+		}
+		texture := p.pkg.ItemByID(res.Attr("texture"))
+		if texture != nil {
+			item.Atlas = texture.Atlas
+			item.Sprite = texture.Sprite
+		}
+		raw[item] = compileBitmapFont(data, texture != nil, p.strings)
+	}
+
+	// 字符串表在全部编译完成后才确定，之后再创建各条目的缓冲区
+	p.pkg.StringTable = p.strings.Table
+	for _, item := range p.pkg.Items {
+		data, ok := raw[item]
+		if !ok {
+			continue
+		}
+		buf := utils.NewByteBuffer(data)
+		buf.StringTable = p.pkg.StringTable
+		buf.Version = projectPackageVersion
+		item.RawData = buf
+		if item.Type == PackageItemTypeComponent {
+			parseComponentData(item)
+		}
+	}
+
+	for id := range hitTests {
+		item := p.pkg.ItemByID(id)
+		if item == nil || p.images[id] == nil {
+			continue
+		}
+		img, _, err := image.Decode(bytes.NewReader(p.images[id]))
+		if err != nil {
+			return fmt.Errorf("assets: decode image %s: %w", item.File, err)
+		}
+		item.PixelHitTest = pixelHitTestFromImage(img)
+	}
+
+	p.pkg.Dependencies = projectDependencies(p.pkg.ID, p.pkg.StringTable)
+	return nil
+}
+
+// projectDependencies 从组件引用的 ui:// 地址中收集其它包的 ID
+// 编辑器工程中只能得到包 ID，Dependency.Name 为空
+func projectDependencies(ownID string, table []string) []Dependency {
+	const prefix = "ui://"
+	const idLen = 8
+	var deps []Dependency
+	seen := map[string]bool{ownID: true}
+	for _, s := range table {
+		if !strings.HasPrefix(s, prefix) || len(s) < len(prefix)+idLen {
+			continue
+		}
+		id := s[len(prefix) : len(prefix)+idLen]
+		if !seen[id] {
+			seen[id] = true
+			deps = append(deps, Dependency{ID: id})
+		}
+	}
+	return deps
+}
+
+// pixelHitTestFromImage 按图片透明度生成像素点击数据，不透明的像素对应置位的 bit
+func pixelHitTestFromImage(img image.Image) *PixelHitTestData {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	data := make([]byte, (w*h+7)/8)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if _, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA(); a > 0 {
+				index := y*w + x
+				data[index>>3] |= 1 << uint(index&7)
+			}
+		}
+	}
+	return &PixelHitTestData{Width: w, Height: h, Scale: 1, Data: data}
+}
+
+// compileBitmapFont 将 BMFont 文本（.fnt）编译为发布格式的字体数据。
+// textured 表示字形取自 texture 指定的整张图片（按 x、y 裁剪），否则每个字形通过 img 引用独立图片。
+func compileBitmapFont(data []byte, textured bool, table *utils.StringIndex) []byte {
+	var size, lineHeight int
+	type glyph struct {
+		char                     int
+		img                      string
+		x, y, offX, offY, width  int
+		height, advance, channel int
+	}
+	var glyphs []glyph
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		tag, attrs := parseFntLine(scanner.Text())
+		num := func(key string) int {
+			v, _ := strconv.Atoi(attrs[key])
+			return v
+		}
+		switch tag {
+		case "info":
+			size = num("size")
+		case "common":
+			lineHeight = num("lineHeight")
+		case "char":
+			if num("id") == 0 {
+				continue
+			}
+			g := glyph{
+				char:    num("id"),
+				offX:    num("xoffset"),
+				offY:    num("yoffset"),
+				advance: num("xadvance"),
+				channel: num("chnl"),
+			}
+			if textured {
+				g.x, g.y, g.width, g.height = num("x"), num("y"), num("width"), num("height")
+			} else {
+				g.img = attrs["img"]
+			}
+			glyphs = append(glyphs, g)
+		}
+	}
+
+	w := utils.NewByteWriter(table)
+	block := w.BeginBlock(2, false)
+	block.Section(0)
+	w.WriteBool(textured) // ttf
+	w.WriteBool(textured) // tint
+	w.WriteBool(false)    // autoScaleSize
+	w.WriteBool(false)    // hasChannel
+	w.WriteInt32(int32(size))
+	w.WriteInt32(0) // xadvance
+	w.WriteInt32(int32(lineHeight))
+
+	block.Section(1)
+	w.WriteInt32(int32(len(glyphs)))
+	for _, g := range glyphs {
+		mark := w.MarkLength16()
+		w.WriteUint16(uint16(g.char))
+		w.WriteS(g.img)
+		for _, v := range []int{g.x, g.y, g.offX, g.offY, g.width, g.height, g.advance} {
+			w.WriteInt32(int32(v))
+		}
+		w.WriteUint8(uint8(g.channel))
+		w.FillLength16(mark)
+	}
+	return w.Bytes()
+}
+
+// parseFntLine 解析 BMFont 文本格式中的一行，例如 `char id=35 x=22 y=37`
+func parseFntLine(line string) (string, map[string]string) {
+	line = strings.TrimSpace(line)
+	tag, rest, _ := strings.Cut(line, " ")
+	attrs := make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.TrimSpace(key)
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				attrs[key] = value[1:]
+				break
+			}
+			attrs[key] = value[1 : end+1]
+			rest = value[end+2:]
+			continue
+		}
+		value, rest, _ = strings.Cut(value, " ")
+		attrs[key] = value
+	}
+	return tag, attrs
+}
@@ -0,0 +1,1242 @@
+package assets
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+)
+
+// 编辑器 XML 中的枚举名称与发布格式中的取值，顺序与 TypeScript 版本 FieldTypes 一致
+var (
+	xmlOverflowTypes    = []string{"visible", "hidden", "scroll"}
+	xmlScrollTypes      = []string{"horizontal", "vertical", "both"}
+	xmlScrollBarDisplay = []string{"default", "visible", "auto", "hidden"}
+	xmlAlignTypes       = []string{"left", "center", "right"}
+	xmlVertAlignTypes   = []string{"top", "middle", "bottom"}
+	xmlAutoSizeTypes    = []string{"none", "both", "height", "shrink", "ellipsis"}
+	xmlListLayoutTypes  = []string{"column", "row", "flow_hz", "flow_vt", "pagination"}
+	xmlSelectionModes   = []string{"single", "multiple", "multipleSingleClick", "none"}
+	xmlRenderOrders     = []string{"ascent", "descent", "arch"}
+	xmlLoaderFillTypes  = []string{"none", "scale", "scaleMatchHeight", "scaleMatchWidth", "scaleFree", "scaleNoBorder"}
+	xmlFillMethods      = []string{"none", "hz", "vt", "radial90", "radial180", "radial360"}
+	xmlFlipTypes        = []string{"none", "hz", "vt", "both"}
+	xmlGroupLayouts     = []string{"none", "hz", "vt"}
+	xmlButtonModes      = []string{"Common", "Check", "Radio"}
+	xmlDownEffects      = []string{"none", "dark", "scale"}
+	xmlTitleTypes       = []string{"percent", "valueAndmax", "value", "max"}
+	xmlGraphTypes       = []string{"empty", "rect", "eclipse", "polygon", "regular_polygon"}
+	xmlBlendModes       = []string{"normal", "none", "add", "multiply", "screen", "erase", "mask", "below", "off"}
+	xmlPopupDirections  = []string{"auto", "up", "down"}
+	xmlGearTags         = []string{"gearDisplay", "gearXY", "gearSize", "gearLook", "gearColor", "gearAni", "gearText", "gearIcon", "gearDisplay2", "gearFontSize"}
+	xmlRelationTypes    = []string{
+		"left-left", "left-center", "left-right", "center-center", "right-left", "right-center", "right-right",
+		"top-top", "top-middle", "top-bottom", "middle-middle", "bottom-top", "bottom-middle", "bottom-bottom",
+		"width-width", "height-height",
+		"leftext-left", "leftext-right", "rightext-left", "rightext-right",
+		"topext-top", "topext-bottom", "bottomext-top", "bottomext-bottom",
+		"size",
+	}
+	xmlTransitionTypes = []string{
+		"XY", "Size", "Scale", "Pivot", "Alpha", "Rotation", "Color", "Animation", "Visible",
+		"Sound", "Transition", "Shake", "ColorFilter", "Skew", "Text", "Icon",
+	}
+	xmlEaseTypes = []string{
+		"Linear", "Sine.In", "Sine.Out", "Sine.InOut", "Quad.In", "Quad.Out", "Quad.InOut",
+		"Cubic.In", "Cubic.Out", "Cubic.InOut", "Quart.In", "Quart.Out", "Quart.InOut",
+		"Quint.In", "Quint.Out", "Quint.InOut", "Expo.In", "Expo.Out", "Expo.InOut",
+		"Circ.In", "Circ.Out", "Circ.InOut", "Elastic.In", "Elastic.Out", "Elastic.InOut",
+		"Back.In", "Back.Out", "Back.InOut", "Bounce.In", "Bounce.Out", "Bounce.InOut",
+	}
+	xmlExtensionTypes = map[string]ObjectType{
+		"Label":       ObjectTypeLabel,
+		"Button":      ObjectTypeButton,
+		"ComboBox":    ObjectTypeComboBox,
+		"ProgressBar": ObjectTypeProgressBar,
+		"Slider":      ObjectTypeSlider,
+		"ScrollBar":   ObjectTypeScrollBar,
+	}
+)
+
+// xmlRelationAliases 是 sidePair 中的简写
+var xmlRelationAliases = map[string]string{
+	"width":  "width-width",
+	"height": "height-height",
+}
+
+const (
+	easeQuadOut       = 5
+	transitionFPS     = 24
+	defaultGearTween  = 0.3
+	defaultTextSize   = 12
+	defaultTextLead   = 3
+	defaultDownEffect = 0.8
+)
+
+// xmlEnum 返回 value 在 names 中的下标，不存在时返回 def
+func xmlEnum(names []string, value string, def int) int {
+	if value == "" {
+		return def
+	}
+	for i, name := range names {
+		if strings.EqualFold(name, value) {
+			return i
+		}
+	}
+	return def
+}
+
+// componentExtension 返回组件 XML 根节点 extention 属性对应的对象类型
+func componentExtension(root *xmlNode) ObjectType {
+	if t, ok := xmlExtensionTypes[root.Attr("extention")]; ok {
+		return t
+	}
+	return ObjectTypeComponent
+}
+
+// childObjectType 返回 displayList 中子节点对应的对象类型
+func childObjectType(node *xmlNode) (ObjectType, bool) {
+	switch node.Name {
+	case "image":
+		return ObjectTypeImage, true
+	case "movieclip", "jta":
+		return ObjectTypeMovieClip, true
+	case "swf":
+		return ObjectTypeSwf, true
+	case "graph":
+		return ObjectTypeGraph, true
+	case "loader":
+		return ObjectTypeLoader, true
+	case "group":
+		return ObjectTypeGroup, true
+	case "text":
+		if node.Bool("input", false) {
+			return ObjectTypeInputText, true
+		}
+		return ObjectTypeText, true
+	case "richtext":
+		return ObjectTypeRichText, true
+	case "component":
+		return ObjectTypeComponent, true
+	case "list":
+		if node.Bool("treeView", false) {
+			return ObjectTypeTree, true
+		}
+		return ObjectTypeList, true
+	case "loader3D":
+		return ObjectTypeLoader3D, true
+	}
+	return 0, false
+}
+
+// componentCompiler 把编辑器组件 XML 编译为发布格式的组件数据，
+// 布局与 FairyGUI 编辑器发布的 .fui 一致，因此可以直接交给 parseComponentData 和构建器使用
+type componentCompiler struct {
+	w           *utils.ByteWriter
+	version     int
+	root        *xmlNode
+	children    []*xmlNode
+	childIndex  map[string]int
+	nodes       map[string]*xmlNode
+	controllers []*xmlNode
+}
+
+// compileComponentXML 编译组件 XML，strings 收集所有引用到的字符串
+func compileComponentXML(root *xmlNode, version int, strings *utils.StringIndex) []byte {
+	c := &componentCompiler{
+		w:          utils.NewByteWriter(strings),
+		version:    version,
+		root:       root,
+		childIndex: make(map[string]int),
+		nodes:      make(map[string]*xmlNode),
+	}
+	c.controllers = root.ChildrenNamed("controller")
+	if list := root.Child("displayList"); list != nil {
+		for _, node := range list.Children {
+			c.nodes[node.Attr("id")] = node
+			// 非高级组只在编辑器中使用，不发布
+			if node.Name == "group" && !node.Bool("advanced", false) {
+				continue
+			}
+			if _, ok := childObjectType(node); ok {
+				c.childIndex[node.Attr("id")] = len(c.children)
+				c.children = append(c.children, node)
+			}
+		}
+	}
+	c.writeComponent()
+	return c.w.Bytes()
+}
+
+func (c *componentCompiler) indexOfChild(id string) int {
+	if id == "" {
+		return -1
+	}
+	if idx, ok := c.childIndex[id]; ok {
+		return idx
+	}
+	return -1
+}
+
+// indexOfGroup 返回所属的已发布（高级）组，非高级组沿其所属组向上查找
+func (c *componentCompiler) indexOfGroup(id string) int {
+	for depth := 0; id != "" && depth < len(c.nodes); depth++ {
+		if idx := c.indexOfChild(id); idx >= 0 {
+			return idx
+		}
+		id = c.nodes[id].Attr("group")
+	}
+	return -1
+}
+
+func (c *componentCompiler) indexOfController(name string) int {
+	for i, ctrl := range c.controllers {
+		if ctrl.Attr("name") == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *componentCompiler) writeComponent() {
+	w, root := c.w, c.root
+	block := w.BeginBlock(8, false)
+
+	block.Section(0)
+	width, height := pairInts(root.Attr("size"))
+	w.WriteInt32(int32(width))
+	w.WriteInt32(int32(height))
+	c.writeRestrictSize(root)
+	c.writePivot(root)
+	if margin := root.Ints("margin"); len(margin) >= 4 {
+		w.WriteBool(true)
+		for _, v := range margin[:4] {
+			w.WriteInt32(int32(v))
+		}
+	} else {
+		w.WriteBool(false)
+	}
+	overflow := xmlEnum(xmlOverflowTypes, root.Attr("overflow"), 0)
+	w.WriteUint8(uint8(overflow))
+	c.writeClipSoftness(root)
+
+	block.Section(1)
+	w.WriteInt16(int16(len(c.controllers)))
+	for _, ctrl := range c.controllers {
+		mark := w.MarkLength16()
+		c.writeController(ctrl)
+		w.FillLength16(mark)
+	}
+
+	block.Section(2)
+	w.WriteInt16(int16(len(c.children)))
+	for _, node := range c.children {
+		mark := w.MarkLength16()
+		c.writeChild(node)
+		w.FillLength16(mark)
+	}
+
+	block.Section(3)
+	c.writeRelations(root)
+
+	block.Section(4)
+	w.WriteS(root.Attr("customData"))
+	w.WriteBool(root.Bool("opaque", true))
+	mask := c.indexOfChild(root.Attr("mask"))
+	w.WriteInt16(int16(mask))
+	if mask >= 0 {
+		w.WriteBool(root.Bool("reversedMask", false))
+	}
+	c.writeHitTest(root.Attr("hitTest"))
+	if c.version >= 5 {
+		w.WriteS(root.Attr("soundWhenAdded"))
+		w.WriteS(root.Attr("soundWhenRemoved"))
+	}
+
+	block.Section(5)
+	transitions := root.ChildrenNamed("transition")
+	w.WriteInt16(int16(len(transitions)))
+	for _, trans := range transitions {
+		mark := w.MarkLength16()
+		c.writeTransition(trans)
+		w.FillLength16(mark)
+	}
+
+	ext := componentExtension(root)
+	if ext != ObjectTypeComponent {
+		block.Section(6)
+		c.writeExtension(ext, root.Child(root.Attr("extention")))
+	}
+
+	if overflow == int(OverflowTypeScroll) {
+		block.Section(7)
+		c.writeScroll(root)
+	}
+}
+
+// writeHitTest 写入点击区域：图片资源 ID 加偏移，或 "子对象ID" 形式的子对象点击区域
+func (c *componentCompiler) writeHitTest(value string) {
+	w := c.w
+	if value == "" {
+		// 标记了 forHitTest 的图片以其像素作为点击区域
+		for _, node := range c.children {
+			if node.Name == "image" && node.Bool("forHitTest", false) {
+				x, y := pairInts(node.Attr("xy"))
+				w.WriteS(node.Attr("src"))
+				w.WriteInt32(int32(x))
+				w.WriteInt32(int32(y))
+				return
+			}
+		}
+		w.WriteS("")
+		w.WriteInt32(0)
+		w.WriteInt32(0)
+		return
+	}
+	parts := strings.Split(value, ",")
+	if idx := c.indexOfChild(parts[0]); idx >= 0 {
+		w.WriteS("")
+		w.WriteInt32(1)
+		w.WriteInt32(int32(idx))
+		return
+	}
+	w.WriteS(parts[0])
+	x, y := 0, 0
+	if len(parts) >= 3 {
+		x, _ = strconv.Atoi(parts[1])
+		y, _ = strconv.Atoi(parts[2])
+	}
+	w.WriteInt32(int32(x))
+	w.WriteInt32(int32(y))
+}
+
+func (c *componentCompiler) writeRestrictSize(node *xmlNode) {
+	if vals := node.Ints("restrictSize"); len(vals) >= 4 {
+		c.w.WriteBool(true)
+		for _, v := range vals[:4] {
+			c.w.WriteInt32(int32(v))
+		}
+		return
+	}
+	c.w.WriteBool(false)
+}
+
+func (c *componentCompiler) writePivot(node *xmlNode) {
+	if pivot := node.Floats("pivot"); len(pivot) >= 2 {
+		c.w.WriteBool(true)
+		c.w.WriteFloat32(pivot[0])
+		c.w.WriteFloat32(pivot[1])
+		c.w.WriteBool(node.Bool("anchor", false))
+		return
+	}
+	c.w.WriteBool(false)
+}
+
+func (c *componentCompiler) writeClipSoftness(node *xmlNode) {
+	if soft := node.Ints("clipSoftness"); len(soft) >= 2 && (soft[0] != 0 || soft[1] != 0) {
+		c.w.WriteBool(true)
+		c.w.WriteInt32(int32(soft[0]))
+		c.w.WriteInt32(int32(soft[1]))
+		return
+	}
+	c.w.WriteBool(false)
+}
+
+func (c *componentCompiler) writeMargin(value string) {
+	if margin := parseXMLInts(value); len(margin) >= 4 {
+		c.w.WriteBool(true)
+		for _, v := range margin[:4] {
+			c.w.WriteInt32(int32(v))
+		}
+		return
+	}
+	c.w.WriteBool(false)
+}
+
+// writeController 对应 TypeScript 版本 Controller.setup 读取的三个分段
+func (c *componentCompiler) writeController(ctrl *xmlNode) {
+	w := c.w
+	block := w.BeginBlock(3, true)
+
+	block.Section(0)
+	w.WriteS(ctrl.Attr("name"))
+	w.WriteBool(ctrl.Bool("autoRadioGroupDepth", false))
+
+	block.Section(1)
+	pages := splitXMLList(ctrl.Attr("pages"))
+	w.WriteInt16(int16(len(pages) / 2))
+	for i := 0; i+1 < len(pages); i += 2 {
+		w.WriteS(pages[i])
+		w.WriteSPtr(&pages[i+1])
+	}
+	switch ctrl.Attr("homePageType") {
+	case "specific":
+		w.WriteUint8(1)
+		w.WriteInt16(int16(ctrl.Int("homePage", 0)))
+	case "branch":
+		w.WriteUint8(2)
+	case "variable":
+		w.WriteUint8(3)
+		w.WriteS(ctrl.Attr("homePage"))
+	default:
+		w.WriteUint8(0)
+	}
+
+	block.Section(2)
+	actions := ctrl.ChildrenNamed("action")
+	w.WriteInt16(int16(len(actions)))
+	for _, action := range actions {
+		mark := w.MarkLength16()
+		c.writeControllerAction(action)
+		w.FillLength16(mark)
+	}
+}
+
+func (c *componentCompiler) writeControllerAction(action *xmlNode) {
+	w := c.w
+	kind := action.Attr("type")
+	if kind == "change_page" {
+		w.WriteUint8(1)
+	} else {
+		w.WriteUint8(0)
+	}
+	for _, attr := range []string{"fromPage", "toPage"} {
+		pages := splitXMLList(action.Attr(attr))
+		w.WriteInt16(int16(len(pages)))
+		for _, page := range pages {
+			w.WriteS(page)
+		}
+	}
+	if kind == "change_page" {
+		w.WriteS(action.Attr("objectId"))
+		w.WriteS(action.Attr("controller"))
+		w.WriteS(action.Attr("targetPage"))
+		return
+	}
+	w.WriteS(action.Attr("transition"))
+	w.WriteInt32(int32(action.Int("repeat", 1)))
+	w.WriteFloat32(action.Float("delay", 0))
+	w.WriteBool(action.Bool("stopOnExit", false))
+}
+
+// writeChild 写入 displayList 中的一个子对象，对应 GObject.setup_beforeAdd/setup_afterAdd 读取的分段
+func (c *componentCompiler) writeChild(node *xmlNode) {
+	w := c.w
+	typ, _ := childObjectType(node)
+	segCount := 7
+	switch typ {
+	case ObjectTypeList:
+		segCount = 9
+	case ObjectTypeTree:
+		segCount = 10
+	}
+	block := w.BeginBlock(segCount, true)
+
+	block.Section(0)
+	w.WriteUint8(uint8(typ))
+	w.WriteS(node.Attr("src"))
+	w.WriteS(node.Attr("pkg"))
+	w.WriteS(node.Attr("id"))
+	w.WriteS(node.Attr("name"))
+	x, y := pairInts(node.Attr("xy"))
+	w.WriteInt32(int32(x))
+	w.WriteInt32(int32(y))
+	if size := node.Ints("size"); len(size) >= 2 {
+		w.WriteBool(true)
+		w.WriteInt32(int32(size[0]))
+		w.WriteInt32(int32(size[1]))
+	} else {
+		w.WriteBool(false)
+	}
+	c.writeRestrictSize(node)
+	if scale := node.Floats("scale"); len(scale) >= 2 {
+		w.WriteBool(true)
+		w.WriteFloat32(scale[0])
+		w.WriteFloat32(scale[1])
+	} else {
+		w.WriteBool(false)
+	}
+	if skew := node.Floats("skew"); len(skew) >= 2 {
+		w.WriteBool(true)
+		w.WriteFloat32(skew[0])
+		w.WriteFloat32(skew[1])
+	} else {
+		w.WriteBool(false)
+	}
+	c.writePivot(node)
+	w.WriteFloat32(node.Float("alpha", 1))
+	w.WriteFloat32(node.Float("rotation", 0))
+	w.WriteBool(node.Bool("visible", true))
+	w.WriteBool(node.Bool("touchable", true))
+	w.WriteBool(node.Bool("grayed", false))
+	w.WriteUint8(uint8(xmlEnum(xmlBlendModes, node.Attr("blend"), 0)))
+	if node.Attr("filter") == "color" {
+		w.WriteUint8(1)
+		filter := node.Floats("filterData")
+		for len(filter) < 4 {
+			filter = append(filter, 0)
+		}
+		for _, v := range filter[:4] {
+			w.WriteFloat32(v)
+		}
+	} else {
+		w.WriteUint8(0)
+	}
+	w.WriteS(node.Attr("customData"))
+
+	block.Section(1)
+	w.WriteS(node.Attr("tooltips"))
+	w.WriteInt16(int16(c.indexOfGroup(node.Attr("group"))))
+
+	block.Section(2)
+	c.writeGears(node)
+
+	block.Section(3)
+	c.writeRelations(node)
+
+	switch typ {
+	case ObjectTypeComponent, ObjectTypeList, ObjectTypeTree:
+		block.Section(4)
+		c.writeComponentInstance(node)
+	case ObjectTypeInputText:
+		block.Section(4)
+		w.WriteS(node.Attr("prompt"))
+		w.WriteS(node.Attr("restrict"))
+		w.WriteInt32(int32(node.Int("maxLength", 0)))
+		w.WriteInt32(int32(node.Int("keyboardType", 0)))
+		w.WriteBool(node.Bool("password", false))
+	}
+
+	// 编辑器总是写出分段 5、6（空分段指向当前位置），列表的滚动分段只在 overflow 为 scroll 时写出
+	block.Section(5)
+	switch typ {
+	case ObjectTypeImage:
+		c.writeColorOption(node, "color")
+		w.WriteUint8(uint8(xmlEnum(xmlFlipTypes, node.Attr("flip"), 0)))
+		c.writeFillMethod(node)
+	case ObjectTypeMovieClip:
+		c.writeColorOption(node, "color")
+		w.WriteUint8(uint8(xmlEnum(xmlFlipTypes, node.Attr("flip"), 0)))
+		w.WriteInt32(int32(node.Int("frame", 0)))
+		w.WriteBool(node.Bool("playing", true))
+	case ObjectTypeGraph:
+		c.writeGraph(node)
+	case ObjectTypeLoader:
+		c.writeLoader(node)
+	case ObjectTypeGroup:
+		w.WriteUint8(uint8(xmlEnum(xmlGroupLayouts, node.Attr("layout"), 0)))
+		w.WriteInt32(int32(node.Int("lineGap", 0)))
+		w.WriteInt32(int32(node.Int("colGap", 0)))
+		if c.version >= 2 {
+			w.WriteBool(node.Bool("excludeInvisibles", false))
+			w.WriteBool(node.Bool("autoSizeDisabled", false))
+			w.WriteInt16(int16(node.Int("mainGridIndex", -1)))
+		}
+	case ObjectTypeText, ObjectTypeRichText, ObjectTypeInputText:
+		c.writeTextFormat(node)
+	case ObjectTypeList, ObjectTypeTree:
+		c.writeList(node)
+	}
+
+	block.Section(6)
+	switch typ {
+	case ObjectTypeText, ObjectTypeRichText, ObjectTypeInputText:
+		w.WriteS(node.Attr("text"))
+	case ObjectTypeComponent:
+		if ext, inst := c.instanceExtension(node); inst != nil {
+			c.writeInstanceExtension(ext, inst)
+		}
+	case ObjectTypeList, ObjectTypeTree:
+		w.WriteInt16(int16(c.indexOfController(node.Attr("selectionController"))))
+		if xmlEnum(xmlOverflowTypes, node.Attr("overflow"), 0) == int(OverflowTypeScroll) {
+			block.Section(7)
+			c.writeScroll(node)
+		}
+		block.Section(8)
+		c.writeListItems(node)
+		if typ == ObjectTypeTree {
+			block.Section(9)
+			w.WriteInt32(int32(node.Int("indent", 15)))
+			w.WriteUint8(uint8(node.Int("clickToExpand", 0)))
+		}
+	}
+}
+
+func (c *componentCompiler) writeColorOption(node *xmlNode, attr string) {
+	if node.Has(attr) {
+		c.w.WriteBool(true)
+		c.w.WriteColor(node.Color(attr, 0xffffffff))
+		return
+	}
+	c.w.WriteBool(false)
+}
+
+func (c *componentCompiler) writeFillMethod(node *xmlNode) {
+	w := c.w
+	method := xmlEnum(xmlFillMethods, node.Attr("fillMethod"), 0)
+	w.WriteUint8(uint8(method))
+	if method != 0 {
+		w.WriteUint8(uint8(node.Int("fillOrigin", 0)))
+		w.WriteBool(node.Bool("fillClockwise", true))
+		w.WriteFloat32(node.Float("fillAmount", 100) / 100)
+	}
+}
+
+func (c *componentCompiler) writeGraph(node *xmlNode) {
+	w := c.w
+	typ := xmlEnum(xmlGraphTypes, node.Attr("type"), 0)
+	w.WriteUint8(uint8(typ))
+	if typ == 0 {
+		return
+	}
+	w.WriteInt32(int32(node.Int("lineSize", 1)))
+	w.WriteColor(node.Color("lineColor", 0xff000000))
+	w.WriteColor(node.Color("fillColor", 0xffffffff))
+	if corner := node.Floats("corner"); len(corner) > 0 {
+		w.WriteBool(true)
+		for i := 0; i < 4; i++ {
+			if i < len(corner) {
+				w.WriteFloat32(corner[i])
+			} else {
+				w.WriteFloat32(corner[0])
+			}
+		}
+	} else {
+		w.WriteBool(false)
+	}
+	switch typ {
+	case 3:
+		points := node.Floats("points")
+		w.WriteInt16(int16(len(points)))
+		for _, v := range points {
+			w.WriteFloat32(v)
+		}
+	case 4:
+		w.WriteInt16(int16(node.Int("sides", 3)))
+		w.WriteFloat32(node.Float("startAngle", 0))
+		distances := node.Floats("distances")
+		w.WriteInt16(int16(len(distances)))
+		for _, v := range distances {
+			w.WriteFloat32(v)
+		}
+	}
+}
+
+func (c *componentCompiler) writeLoader(node *xmlNode) {
+	w := c.w
+	w.WriteS(node.Attr("url"))
+	w.WriteUint8(uint8(xmlEnum(xmlAlignTypes, node.Attr("align"), 0)))
+	w.WriteUint8(uint8(xmlEnum(xmlVertAlignTypes, node.Attr("vAlign"), 0)))
+	w.WriteUint8(uint8(xmlEnum(xmlLoaderFillTypes, node.Attr("fill"), 0)))
+	w.WriteBool(node.Bool("shrinkOnly", false))
+	w.WriteBool(node.Bool("autoSize", false))
+	w.WriteBool(node.Bool("errorSign", false))
+	w.WriteBool(node.Bool("playing", true))
+	w.WriteInt32(int32(node.Int("frame", 0)))
+	c.writeColorOption(node, "color")
+	c.writeFillMethod(node)
+	if c.version >= 7 {
+		w.WriteBool(node.Bool("useResize", false))
+	}
+}
+
+func (c *componentCompiler) writeTextFormat(node *xmlNode) {
+	w := c.w
+	w.WriteS(node.Attr("font"))
+	w.WriteInt16(int16(node.Int("fontSize", defaultTextSize)))
+	w.WriteColor(node.Color("color", 0xff000000))
+	w.WriteUint8(uint8(xmlEnum(xmlAlignTypes, node.Attr("align"), 0)))
+	w.WriteUint8(uint8(xmlEnum(xmlVertAlignTypes, node.Attr("vAlign"), 0)))
+	w.WriteInt16(int16(node.Int("leading", defaultTextLead)))
+	w.WriteInt16(int16(node.Int("letterSpacing", 0)))
+	w.WriteBool(node.Bool("ubb", false))
+	w.WriteUint8(uint8(xmlEnum(xmlAutoSizeTypes, node.Attr("autoSize"), 1)))
+	w.WriteBool(node.Bool("underline", false))
+	w.WriteBool(node.Bool("italic", false))
+	w.WriteBool(node.Bool("bold", false))
+	w.WriteBool(node.Bool("singleLine", false))
+	if node.Has("strokeColor") {
+		w.WriteBool(true)
+		w.WriteColor(node.Color("strokeColor", 0xff000000))
+		w.WriteFloat32(node.Float("strokeSize", 1))
+	} else {
+		w.WriteBool(false)
+	}
+	if node.Has("shadowColor") {
+		w.WriteBool(true)
+		w.WriteColor(node.Color("shadowColor", 0xff000000))
+		offset := node.Floats("shadowOffset")
+		for len(offset) < 2 {
+			offset = append(offset, 1)
+		}
+		w.WriteFloat32(offset[0])
+		w.WriteFloat32(offset[1])
+	} else {
+		w.WriteBool(false)
+	}
+	w.WriteBool(node.Bool("vars", false))
+	if c.version >= 3 {
+		w.WriteBool(node.Bool("strike", false))
+		// 编辑器保留的 12 字节，运行时不读取
+		w.WriteBytes(make([]byte, 12))
+	}
+}
+
+func (c *componentCompiler) writeList(node *xmlNode) {
+	w := c.w
+	layout := xmlEnum(xmlListLayoutTypes, node.Attr("layout"), 0)
+	w.WriteUint8(uint8(layout))
+	w.WriteUint8(uint8(xmlEnum(xmlSelectionModes, node.Attr("selectionMode"), 0)))
+	w.WriteUint8(uint8(xmlEnum(xmlAlignTypes, node.Attr("align"), 0)))
+	w.WriteUint8(uint8(xmlEnum(xmlVertAlignTypes, node.Attr("vAlign"), 0)))
+	w.WriteInt16(int16(node.Int("lineGap", 0)))
+	w.WriteInt16(int16(node.Int("colGap", 0)))
+	w.WriteInt16(int16(node.Int("lineItemCount", 0)))
+	w.WriteInt16(int16(node.Int("lineItemCount2", 0)))
+	// 单行、单列布局默认自动调整列表项尺寸
+	w.WriteBool(node.Bool("autoItemSize", layout <= 1))
+	w.WriteUint8(uint8(xmlEnum(xmlRenderOrders, node.Attr("renderOrder"), 0)))
+	w.WriteInt16(int16(node.Int("apex", 0)))
+	c.writeMargin(node.Attr("margin"))
+	w.WriteUint8(uint8(xmlEnum(xmlOverflowTypes, node.Attr("overflow"), 0)))
+	c.writeClipSoftness(node)
+	if c.version >= 2 {
+		w.WriteBool(node.Bool("scrollItemToViewOnClick", true))
+		w.WriteBool(node.Bool("foldInvisibleItems", false))
+	}
+}
+
+func (c *componentCompiler) writeListItems(node *xmlNode) {
+	w := c.w
+	w.WriteS(node.Attr("defaultItem"))
+	items := node.ChildrenNamed("item")
+	w.WriteInt16(int16(len(items)))
+	tree := node.Bool("treeView", false)
+	for i, item := range items {
+		mark := w.MarkLength16()
+		w.WriteS(item.Attr("url"))
+		if tree {
+			// 下一项层级更深时当前项是文件夹
+			level := item.Int("level", 0)
+			w.WriteBool(i+1 < len(items) && items[i+1].Int("level", 0) > level)
+			w.WriteUint8(uint8(level))
+		}
+		w.WriteS(item.Attr("title"))
+		w.WriteS(item.Attr("selectedTitle"))
+		w.WriteS(item.Attr("icon"))
+		w.WriteS(item.Attr("selectedIcon"))
+		w.WriteS(item.Attr("name"))
+		c.writeControllerPages(item.Attr("controllers"))
+		if c.version >= 2 {
+			c.writeProperties(item.Attr("properties"))
+		}
+		w.FillLength16(mark)
+	}
+}
+
+// writeControllerPages 写入 "控制器名,页面ID,..." 形式的控制器初始页面
+func (c *componentCompiler) writeControllerPages(value string) {
+	pairs := splitXMLList(value)
+	c.w.WriteInt16(int16(len(pairs) / 2))
+	for i := 0; i+1 < len(pairs); i += 2 {
+		c.w.WriteS(pairs[i])
+		c.w.WriteS(pairs[i+1])
+	}
+}
+
+// writeProperties 写入 "路径,属性ID,值,..." 形式的子对象属性覆盖
+func (c *componentCompiler) writeProperties(value string) {
+	parts := splitXMLList(value)
+	c.w.WriteInt16(int16(len(parts) / 3))
+	for i := 0; i+2 < len(parts); i += 3 {
+		c.w.WriteS(parts[i])
+		id, _ := strconv.Atoi(parts[i+1])
+		c.w.WriteInt16(int16(id))
+		c.w.WriteS(parts[i+2])
+	}
+}
+
+// writeComponentInstance 写入组件实例的控制器初始页面和属性覆盖（分段 4）
+func (c *componentCompiler) writeComponentInstance(node *xmlNode) {
+	w := c.w
+	w.WriteInt16(int16(c.indexOfController(node.Attr("pageController"))))
+	c.writeControllerPages(node.Attr("controller"))
+	if c.version >= 2 {
+		props := node.ChildrenNamed("property")
+		w.WriteInt16(int16(len(props)))
+		for _, prop := range props {
+			w.WriteS(prop.Attr("target"))
+			w.WriteInt16(int16(prop.Int("propertyId", 0)))
+			w.WriteS(prop.Attr("value"))
+		}
+	}
+}
+
+// instanceExtension 返回组件实例上的扩展属性节点（<Button>、<Label> 等）
+func (c *componentCompiler) instanceExtension(node *xmlNode) (ObjectType, *xmlNode) {
+	for _, child := range node.Children {
+		if ext, ok := xmlExtensionTypes[child.Name]; ok {
+			return ext, child
+		}
+	}
+	return ObjectTypeComponent, nil
+}
+
+func (c *componentCompiler) writeInstanceExtension(ext ObjectType, node *xmlNode) {
+	w := c.w
+	w.WriteUint8(uint8(ext))
+	switch ext {
+	case ObjectTypeButton:
+		w.WriteS(node.Attr("title"))
+		w.WriteS(node.Attr("selectedTitle"))
+		w.WriteS(node.Attr("icon"))
+		w.WriteS(node.Attr("selectedIcon"))
+		c.writeColorOption(node, "titleColor")
+		w.WriteInt32(int32(node.Int("titleFontSize", 0)))
+		w.WriteInt16(int16(c.indexOfController(node.Attr("controller"))))
+		w.WriteS(node.Attr("page"))
+		w.WriteS(node.Attr("sound"))
+		if node.Has("volume") {
+			w.WriteBool(true)
+			w.WriteFloat32(node.Float("volume", 100) / 100)
+		} else {
+			w.WriteBool(false)
+		}
+		w.WriteBool(node.Bool("checked", false))
+	case ObjectTypeLabel:
+		w.WriteS(node.Attr("title"))
+		w.WriteS(node.Attr("icon"))
+		c.writeColorOption(node, "titleColor")
+		w.WriteInt32(int32(node.Int("titleFontSize", 0)))
+		if node.Bool("input", false) {
+			w.WriteBool(true)
+			w.WriteS(node.Attr("prompt"))
+			w.WriteS(node.Attr("restrict"))
+			w.WriteInt32(int32(node.Int("maxLength", 0)))
+			w.WriteInt32(int32(node.Int("keyboardType", 0)))
+			w.WriteBool(node.Bool("password", false))
+		} else {
+			w.WriteBool(false)
+		}
+	case ObjectTypeComboBox:
+		items := node.ChildrenNamed("item")
+		w.WriteInt16(int16(len(items)))
+		for _, item := range items {
+			mark := w.MarkLength16()
+			w.WriteS(item.Attr("title"))
+			w.WriteS(item.Attr("value"))
+			w.WriteS(item.Attr("icon"))
+			w.FillLength16(mark)
+		}
+		w.WriteS(node.Attr("title"))
+		w.WriteS(node.Attr("icon"))
+		c.writeColorOption(node, "titleColor")
+		w.WriteInt32(int32(node.Int("visibleItemCount", 0)))
+		w.WriteUint8(uint8(xmlEnum(xmlPopupDirections, node.Attr("direction"), 0)))
+		w.WriteInt16(int16(c.indexOfController(node.Attr("selectionController"))))
+	case ObjectTypeProgressBar, ObjectTypeSlider:
+		w.WriteInt32(int32(node.Int("value", 0)))
+		w.WriteInt32(int32(node.Int("max", 100)))
+		if c.version >= 2 {
+			w.WriteInt32(int32(node.Int("min", 0)))
+		}
+	}
+	switch ext {
+	case ObjectTypeLabel, ObjectTypeComboBox, ObjectTypeProgressBar:
+		// 这几种扩展实例末尾还有音效及音量，运行时不读取
+		w.WriteS(node.Attr("sound"))
+		w.WriteFloat32(node.Float("volume", 100) / 100)
+	}
+}
+
+// writeExtension 写入组件自身的扩展属性（分段 6），对应各扩展控件的 constructExtension
+func (c *componentCompiler) writeExtension(ext ObjectType, node *xmlNode) {
+	w := c.w
+	switch ext {
+	case ObjectTypeButton:
+		w.WriteUint8(uint8(xmlEnum(xmlButtonModes, node.Attr("mode"), 0)))
+		w.WriteS(node.Attr("sound"))
+		w.WriteFloat32(node.Float("volume", 100) / 100)
+		w.WriteUint8(uint8(xmlEnum(xmlDownEffects, node.Attr("downEffect"), 0)))
+		w.WriteFloat32(node.Float("downEffectValue", defaultDownEffect))
+	case ObjectTypeProgressBar:
+		w.WriteUint8(uint8(xmlEnum(xmlTitleTypes, node.Attr("titleType"), 0)))
+		w.WriteBool(node.Bool("reverse", false))
+	case ObjectTypeSlider:
+		w.WriteUint8(uint8(xmlEnum(xmlTitleTypes, node.Attr("titleType"), 0)))
+		w.WriteBool(node.Bool("reverse", false))
+		if c.version >= 2 {
+			w.WriteBool(node.Bool("wholeNumbers", false))
+			w.WriteBool(node.Bool("changeOnClick", true))
+		}
+	case ObjectTypeScrollBar:
+		w.WriteBool(node.Bool("fixedGripSize", false))
+	case ObjectTypeComboBox:
+		w.WriteS(node.Attr("dropdown"))
+	}
+}
+
+// writeScroll 写入滚动配置（组件分段 7），对应 ScrollPane.setup
+func (c *componentCompiler) writeScroll(node *xmlNode) {
+	w := c.w
+	w.WriteUint8(uint8(xmlEnum(xmlScrollTypes, node.Attr("scroll"), 1)))
+	w.WriteUint8(uint8(xmlEnum(xmlScrollBarDisplay, node.Attr("scrollBar"), 0)))
+	w.WriteInt32(int32(node.Int("scrollBarFlags", 0)))
+	c.writeMargin(node.Attr("scrollBarMargin"))
+	res := splitXMLList(node.Attr("scrollBarRes"))
+	ptr := splitXMLList(node.Attr("ptrRes"))
+	for len(res) < 2 {
+		res = append(res, "")
+	}
+	for len(ptr) < 2 {
+		ptr = append(ptr, "")
+	}
+	w.WriteS(res[0])
+	w.WriteS(res[1])
+	w.WriteS(ptr[0])
+	w.WriteS(ptr[1])
+}
+
+// writeRelations 写入关联（child 分段 3 或组件分段 3）
+func (c *componentCompiler) writeRelations(node *xmlNode) {
+	w := c.w
+	relations := node.ChildrenNamed("relation")
+	w.WriteUint8(uint8(len(relations)))
+	for _, rel := range relations {
+		w.WriteInt16(int16(c.indexOfChild(rel.Attr("target"))))
+		pairs := splitXMLList(rel.Attr("sidePair"))
+		w.WriteUint8(uint8(len(pairs)))
+		for _, pair := range pairs {
+			percent := strings.HasSuffix(pair, "%")
+			pair = strings.TrimSuffix(pair, "%")
+			if alias, ok := xmlRelationAliases[pair]; ok {
+				pair = alias
+			}
+			w.WriteUint8(uint8(xmlEnum(xmlRelationTypes, pair, 0)))
+			w.WriteBool(percent)
+		}
+	}
+}
+
+// writeGears 写入控制器齿轮（child 分段 2），对应 TypeScript 版本 GearBase.setup
+func (c *componentCompiler) writeGears(node *xmlNode) {
+	w := c.w
+	var gears []*xmlNode
+	for _, child := range node.Children {
+		if xmlEnum(xmlGearTags, child.Name, -1) >= 0 {
+			gears = append(gears, child)
+		}
+	}
+	w.WriteInt16(int16(len(gears)))
+	for _, gear := range gears {
+		mark := w.MarkLength16()
+		c.writeGear(gear)
+		w.FillLength16(mark)
+	}
+}
+
+func (c *componentCompiler) writeGear(gear *xmlNode) {
+	w := c.w
+	index := xmlEnum(xmlGearTags, gear.Name, 0)
+	w.WriteUint8(uint8(index))
+	w.WriteInt16(int16(c.indexOfController(gear.Attr("controller"))))
+	pages := splitXMLList(gear.Attr("pages"))
+	w.WriteInt16(int16(len(pages)))
+	switch index {
+	case 0, 8:
+		for _, page := range pages {
+			w.WriteS(page)
+		}
+	default:
+		values := strings.Split(gear.Attr("values"), "|")
+		for i, page := range pages {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			if value == "-" {
+				w.WriteS("")
+				continue
+			}
+			w.WriteS(page)
+			c.writeGearValue(index, value)
+		}
+		if gear.Has("default") {
+			w.WriteBool(true)
+			c.writeGearValue(index, gear.Attr("default"))
+		} else {
+			w.WriteBool(false)
+		}
+	}
+	if gear.Bool("tween", false) {
+		w.WriteBool(true)
+		w.WriteUint8(uint8(xmlEnum(xmlEaseTypes, gear.Attr("ease"), easeQuadOut)))
+		w.WriteFloat32(gear.Float("duration", defaultGearTween))
+		w.WriteFloat32(gear.Float("delay", 0))
+	} else {
+		w.WriteBool(false)
+	}
+	if c.version >= 2 {
+		switch index {
+		case 1:
+			if gear.Has("positionsInPercent") {
+				w.WriteBool(true)
+				percents := strings.Split(gear.Attr("positionsInPercent"), "|")
+				for i, page := range pages {
+					if i >= len(percents) || percents[i] == "-" {
+						w.WriteS("")
+						continue
+					}
+					w.WriteS(page)
+					vals := parseXMLFloatList(percents[i], 2)
+					w.WriteFloat32(vals[0])
+					w.WriteFloat32(vals[1])
+				}
+				if def := gear.Attr("defaultPositionInPercent"); def != "" {
+					w.WriteBool(true)
+					vals := parseXMLFloatList(def, 2)
+					w.WriteFloat32(vals[0])
+					w.WriteFloat32(vals[1])
+				} else {
+					w.WriteBool(false)
+				}
+			} else {
+				w.WriteBool(false)
+			}
+		case 8:
+			w.WriteUint8(uint8(gear.Int("condition", 0)))
+		}
+	}
+	if c.version >= 6 && index == 5 {
+		// 动画齿轮的扩展取值：动画名称和皮肤名称（Spine/DragonBones）
+		values := strings.Split(gear.Attr("values"), "|")
+		for i, page := range pages {
+			if i >= len(values) || values[i] == "-" {
+				w.WriteS("")
+				continue
+			}
+			w.WriteS(page)
+			c.writeAnimationExt(values[i])
+		}
+		if gear.Has("default") {
+			w.WriteBool(true)
+			c.writeAnimationExt(gear.Attr("default"))
+		} else {
+			w.WriteBool(false)
+		}
+	}
+}
+
+// writeAnimationExt 写入 "帧,p|s,动画名称,皮肤名称" 中的动画名称和皮肤名称
+func (c *componentCompiler) writeAnimationExt(value string) {
+	parts := strings.Split(value, ",")
+	for i := 2; i < 4; i++ {
+		if i < len(parts) {
+			c.w.WriteS(parts[i])
+		} else {
+			c.w.WriteS("")
+		}
+	}
+}
+
+// writeGearValue 写入单个页面的齿轮取值，格式与各 Gear.addStatus 一致
+func (c *componentCompiler) writeGearValue(index int, value string) {
+	w := c.w
+	parts := strings.Split(value, ",")
+	part := func(i int) string {
+		if i < len(parts) {
+			return strings.TrimSpace(parts[i])
+		}
+		return ""
+	}
+	switch index {
+	case 1: // xy
+		x, y := pairInts(value)
+		w.WriteInt32(int32(x))
+		w.WriteInt32(int32(y))
+	case 2: // size
+		vals := parseXMLFloatList(value, 4)
+		if len(parts) < 4 {
+			vals[2], vals[3] = 1, 1
+		}
+		w.WriteInt32(int32(vals[0]))
+		w.WriteInt32(int32(vals[1]))
+		w.WriteFloat32(vals[2])
+		w.WriteFloat32(vals[3])
+	case 3: // look
+		w.WriteFloat32(parseXMLFloat(part(0), 1))
+		w.WriteFloat32(parseXMLFloat(part(1), 0))
+		w.WriteBool(part(2) == "1" || part(2) == "true")
+		w.WriteBool(part(3) != "0" && part(3) != "false")
+	case 4: // color
+		w.WriteColor(parseXMLColor(part(0), 0xffffffff))
+		w.WriteColor(parseXMLColor(part(1), 0xff000000))
+	case 5: // animation
+		w.WriteBool(part(1) == "p")
+		frame, _ := strconv.Atoi(part(0))
+		w.WriteInt32(int32(frame))
+	case 6, 7: // text, icon
+		w.WriteS(value)
+	case 9: // fontSize
+		size, _ := strconv.Atoi(part(0))
+		w.WriteInt32(int32(size))
+	}
+}
+
+// writeTransition 写入动效（组件分段 5），对应 TypeScript 版本 Transition.setup
+func (c *componentCompiler) writeTransition(trans *xmlNode) {
+	w := c.w
+	w.WriteS(trans.Attr("name"))
+	w.WriteInt32(int32(trans.Int("options", 0)))
+	w.WriteBool(trans.Bool("autoPlay", false))
+	w.WriteInt32(int32(trans.Int("autoPlayRepeat", 1)))
+	w.WriteFloat32(trans.Float("autoPlayDelay", 0))
+	items := trans.ChildrenNamed("item")
+	w.WriteInt16(int16(len(items)))
+	for _, item := range items {
+		mark := w.MarkLength16()
+		c.writeTransitionItem(item)
+		w.FillLength16(mark)
+	}
+}
+
+func (c *componentCompiler) writeTransitionItem(item *xmlNode) {
+	w := c.w
+	typ := xmlEnum(xmlTransitionTypes, item.Attr("type"), 0)
+	block := w.BeginBlock(4, true)
+
+	block.Section(0)
+	w.WriteUint8(uint8(typ))
+	w.WriteFloat32(frameTime(item.Int("time", 0)))
+	w.WriteInt16(int16(c.indexOfChild(item.Attr("target"))))
+	w.WriteS(item.Attr("label"))
+	tween := item.Bool("tween", false)
+	w.WriteBool(tween)
+
+	if !tween {
+		block.Section(2)
+		c.writeTransitionValue(typ, item.Attr("value"))
+		return
+	}
+	block.Section(1)
+	w.WriteFloat32(frameTime(item.Int("duration", 0)))
+	w.WriteUint8(uint8(xmlEnum(xmlEaseTypes, item.Attr("ease"), easeQuadOut)))
+	w.WriteInt32(int32(item.Int("repeat", 0)))
+	w.WriteBool(item.Bool("yoyo", false))
+	w.WriteS(item.Attr("label2"))
+
+	block.Section(2)
+	c.writeTransitionValue(typ, item.Attr("startValue"))
+
+	block.Section(3)
+	c.writeTransitionValue(typ, item.Attr("endValue"))
+	if c.version >= 2 {
+		c.writeTransitionPath(item.Attr("path"))
+	}
+}
+
+// writeTransitionPath 写入运动路径，XML 中每个点为 "曲线类型,x,y,cx1,cy1,cx2,cy2,smooth"
+func (c *componentCompiler) writeTransitionPath(value string) {
+	w := c.w
+	parts := splitXMLList(value)
+	count := len(parts) / 8
+	w.WriteInt32(int32(count))
+	for i := 0; i < count; i++ {
+		p := parts[i*8 : i*8+8]
+		curve, _ := strconv.Atoi(p[0])
+		w.WriteUint8(uint8(curve))
+		w.WriteFloat32(parseXMLFloat(p[1], 0))
+		w.WriteFloat32(parseXMLFloat(p[2], 0))
+		switch curve {
+		case 1:
+			w.WriteFloat32(parseXMLFloat(p[3], 0))
+			w.WriteFloat32(parseXMLFloat(p[4], 0))
+		case 2:
+			for _, v := range p[3:7] {
+				w.WriteFloat32(parseXMLFloat(v, 0))
+			}
+		}
+	}
+}
+
+// frameTime 将编辑器帧数换算为秒，与编辑器一样乘以单精度的帧间隔以保持发布结果一致
+func frameTime(frame int) float32 {
+	return float32(frame) * float32(1.0/transitionFPS)
+}
+
+// writeTransitionValue 写入动效取值，"-" 表示该分量保持不变
+func (c *componentCompiler) writeTransitionValue(typ int, value string) {
+	w := c.w
+	parts := strings.Split(value, ",")
+	part := func(i int) string {
+		if i < len(parts) {
+			return strings.TrimSpace(parts[i])
+		}
+		return ""
+	}
+	switch typ {
+	case 0, 1, 3, 13: // XY, Size, Pivot, Skew
+		w.WriteBool(part(0) != "-")
+		w.WriteBool(part(1) != "-")
+		w.WriteFloat32(parseXMLFloat(part(0), 0))
+		w.WriteFloat32(parseXMLFloat(part(1), 0))
+		if typ == 0 && c.version >= 2 {
+			w.WriteBool(false)
+		}
+	case 4, 5: // Alpha, Rotation
+		w.WriteFloat32(parseXMLFloat(part(0), 0))
+	case 2: // Scale
+		w.WriteFloat32(parseXMLFloat(part(0), 1))
+		w.WriteFloat32(parseXMLFloat(part(1), 1))
+	case 6: // Color
+		w.WriteColor(parseXMLColor(part(0), 0xffffffff))
+	case 7: // Animation
+		w.WriteBool(part(1) == "p")
+		frame, _ := strconv.Atoi(part(0))
+		w.WriteInt32(int32(frame))
+		if c.version >= 6 {
+			c.writeAnimationExt(value)
+		}
+	case 8: // Visible
+		w.WriteBool(part(0) == "true")
+	case 9: // Sound
+		w.WriteS(part(0))
+		w.WriteFloat32(parseXMLFloat(part(1), 100) / 100)
+	case 10: // Transition
+		w.WriteS(part(0))
+		times, err := strconv.Atoi(part(1))
+		if err != nil {
+			times = 1
+		}
+		w.WriteInt32(int32(times))
+	case 11: // Shake
+		w.WriteFloat32(parseXMLFloat(part(0), 0))
+		w.WriteFloat32(parseXMLFloat(part(1), 0))
+	case 12: // ColorFilter
+		for i := 0; i < 4; i++ {
+			w.WriteFloat32(parseXMLFloat(part(i), 0))
+		}
+	case 14, 15: // Text, Icon
+		w.WriteS(value)
+	}
+}
+
+// splitXMLList 按逗号拆分属性值，空字符串返回 nil
+func splitXMLList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// parseXMLFloatList 解析逗号分隔的浮点数，不足 n 个时补 0
+func parseXMLFloatList(value string, n int) []float32 {
+	out := make([]float32, n)
+	for i, p := range strings.Split(value, ",") {
+		if i >= n {
+			break
+		}
+		out[i] = parseXMLFloat(p, 0)
+	}
+	return out
+}
@@ -0,0 +1,204 @@
+package assets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var projectDemoPackages = []string{
+	"Bag", "Basics", "Chat", "Cooldown", "Guide", "HitTest", "Joystick", "ListEffect", "LoopList",
+	"MainMenu", "ModalWaiting", "PullToRefresh", "ScrollPane", "Transition", "TreeView", "VirtualList",
+}
+
+// 工程文件在发布 demo/assets 之后又有改动的组件，与发布结果不一致
+var projectDemoDrift = map[string]bool{
+	"Basics/Demo_List": true,
+	"Transition/BOSS":  true,
+}
+
+func loadProjectAndPublished(t *testing.T, name string) (*Package, *Package) {
+	t.Helper()
+	root := filepath.Join("..", "..", "..", "demo")
+	project, err := LoadProjectPackage(context.Background(), NewFileLoader(filepath.Join(root, "UIProject", "assets")), name)
+	if err != nil {
+		t.Fatalf("load project package %s: %v", name, err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "assets", name+".fui"))
+	if err != nil {
+		t.Skipf("published package not available: %v", err)
+	}
+	published, err := ParsePackage(data, name)
+	if err != nil {
+		t.Fatalf("parse published package %s: %v", name, err)
+	}
+	return project, published
+}
+
+func TestLoadProjectPackageMatchesPublished(t *testing.T) {
+	for _, name := range projectDemoPackages {
+		t.Run(name, func(t *testing.T) {
+			project, published := loadProjectAndPublished(t, name)
+			if project.ID != published.ID || project.Name != published.Name {
+				t.Fatalf("package identity mismatch: %s/%s vs %s/%s", project.ID, project.Name, published.ID, published.Name)
+			}
+			for _, want := range published.Items {
+				if want.Type == PackageItemTypeAtlas {
+					continue
+				}
+				got := project.ItemByID(want.ID)
+				if got == nil {
+					t.Errorf("item %s (%s) missing", want.ID, want.Name)
+					continue
+				}
+				if got.Type != want.Type || got.ObjectType != want.ObjectType || got.Name != want.Name {
+					t.Errorf("item %s: got %d/%d/%q, want %d/%d/%q", want.ID, got.Type, got.ObjectType, got.Name, want.Type, want.ObjectType, want.Name)
+				}
+				switch want.Type {
+				case PackageItemTypeImage:
+					if got.Sprite == nil || got.Sprite.Atlas == nil || got.Sprite.Atlas.File != got.File {
+						t.Errorf("image %s has no standalone sprite", want.Name)
+					}
+					if (got.Scale9Grid == nil) != (want.Scale9Grid == nil) || got.ScaleByTile != want.ScaleByTile {
+						t.Errorf("image %s scale option mismatch", want.Name)
+					}
+					if (want.PixelHitTest != nil) && got.PixelHitTest == nil {
+						t.Errorf("image %s missing pixel hit test data", want.Name)
+					}
+				case PackageItemTypeMovieClip:
+					compareMovieClips(t, got, want)
+				case PackageItemTypeComponent:
+					if !projectDemoDrift[name+"/"+want.Name] {
+						compareComponentData(t, want.Name, got.Component, want.Component)
+					}
+				case PackageItemTypeFont:
+					compareBitmapFonts(t, got, want)
+				}
+			}
+		})
+	}
+}
+
+func compareComponentData(t *testing.T, name string, got, want *ComponentData) {
+	t.Helper()
+	if got == nil || want == nil {
+		t.Errorf("component %s: missing data", name)
+		return
+	}
+	if got.InitWidth != want.InitWidth || got.InitHeight != want.InitHeight || got.Overflow != want.Overflow {
+		t.Errorf("component %s: got size %dx%d overflow %d, want %dx%d overflow %d", name,
+			got.InitWidth, got.InitHeight, got.Overflow, want.InitWidth, want.InitHeight, want.Overflow)
+	}
+	if len(got.Controllers) != len(want.Controllers) {
+		t.Errorf("component %s: got %d controllers, want %d", name, len(got.Controllers), len(want.Controllers))
+	}
+	if len(got.Children) != len(want.Children) {
+		t.Errorf("component %s: got %d children, want %d", name, len(got.Children), len(want.Children))
+		return
+	}
+	for i := range want.Children {
+		g, w := got.Children[i], want.Children[i]
+		g.RawDataOffset, g.RawDataLength = 0, 0
+		w.RawDataOffset, w.RawDataLength = 0, 0
+		if g != w {
+			t.Errorf("component %s child %d:\n got  %+v\n want %+v", name, i, g, w)
+		}
+	}
+}
+
+func compareMovieClips(t *testing.T, got, want *PackageItem) {
+	t.Helper()
+	if got.Width != want.Width || got.Height != want.Height || got.Interval != want.Interval || len(got.Frames) != len(want.Frames) {
+		t.Errorf("movie clip %s: got %dx%d interval %d with %d frames, want %dx%d interval %d with %d frames", got.Name,
+			got.Width, got.Height, got.Interval, len(got.Frames), want.Width, want.Height, want.Interval, len(want.Frames))
+		return
+	}
+	for i, w := range want.Frames {
+		g := got.Frames[i]
+		if g.OffsetX != w.OffsetX || g.OffsetY != w.OffsetY || g.Width != w.Width || g.Height != w.Height || g.AddDelay != w.AddDelay {
+			t.Errorf("movie clip %s frame %d:\n got  %+v\n want %+v", got.Name, i, g, w)
+			continue
+		}
+		if (w.Sprite == nil) != (g.Sprite == nil) {
+			t.Errorf("movie clip %s frame %d: sprite presence mismatch", got.Name, i)
+		} else if g.Sprite != nil && (g.Sprite.Rect.Width != w.Sprite.Rect.Width || g.Sprite.Rect.Height != w.Sprite.Rect.Height || g.Sprite.Atlas.RawData == nil) {
+			t.Errorf("movie clip %s frame %d: sprite %+v, want %+v", got.Name, i, g.Sprite.Rect, w.Sprite.Rect)
+		}
+	}
+}
+
+func compareBitmapFonts(t *testing.T, got, want *PackageItem) {
+	t.Helper()
+	gf, err := got.BitmapFontData()
+	if err != nil {
+		t.Errorf("font %s: %v", got.Name, err)
+		return
+	}
+	wf, err := want.BitmapFontData()
+	if err != nil {
+		t.Fatalf("published font %s: %v", want.Name, err)
+	}
+	if gf.TTF != wf.TTF || gf.FontSize != wf.FontSize || gf.LineHeight != wf.LineHeight || len(gf.Glyphs) != len(wf.Glyphs) {
+		t.Errorf("font %s: got %v/%v/%v/%d glyphs, want %v/%v/%v/%d glyphs", got.Name,
+			gf.TTF, gf.FontSize, gf.LineHeight, len(gf.Glyphs), wf.TTF, wf.FontSize, wf.LineHeight, len(wf.Glyphs))
+		return
+	}
+	for r, wg := range wf.Glyphs {
+		gg := gf.Glyphs[r]
+		if gg == nil || gg.Item == nil || wg.Item == nil {
+			t.Errorf("font %s glyph %q: missing", got.Name, r)
+			continue
+		}
+		// 纹理模式的字形取自图集，工程中的图集是单张图片，只比较独立图片模式的字形资源
+		if wg.Item.Type == PackageItemTypeImage && gg.Item.ID != wg.Item.ID ||
+			gg.AtlasX != wg.AtlasX || gg.AtlasY != wg.AtlasY || gg.Advance != wg.Advance || gg.OffsetX != wg.OffsetX || gg.OffsetY != wg.OffsetY ||
+			gg.Width != wg.Width || gg.Height != wg.Height {
+			t.Errorf("font %s glyph %q:\n got  %+v\n want %+v", got.Name, r, gg, wg)
+		}
+	}
+}
+
+func TestLoadProjectPackageMissingDir(t *testing.T) {
+	loader := NewFileLoader(filepath.Join("..", "..", "..", "demo", "UIProject", "assets"))
+	if _, err := LoadProjectPackage(context.Background(), loader, "NoSuchPackage"); err == nil {
+		t.Fatalf("expected error for missing package.xml")
+	}
+}
+
+func TestParseFntLine(t *testing.T) {
+	tag, attrs := parseFntLine(`info face="Some Font" size=32 padding=0,0,0,0`)
+	if tag != "info" || attrs["face"] != "Some Font" || attrs["size"] != "32" || attrs["padding"] != "0,0,0,0" {
+		t.Fatalf("unexpected parse result %q %v", tag, attrs)
+	}
+}
+
+// CompileComponents 编译的组件与从工程目录加载同一 XML 的结果相同
+func TestCompileComponentsMatchesProject(t *testing.T) {
+	loader := NewFileLoader(filepath.Join("..", "..", "..", "demo", "UIProject", "assets"))
+	project, err := LoadProjectPackage(context.Background(), loader, "Bag")
+	if err != nil {
+		t.Skipf("demo project unavailable: %v", err)
+	}
+	want := project.ItemByName("BagWin")
+	data, err := loader.LoadOne(context.Background(), want.File, ResourceBinary)
+	if err != nil {
+		t.Fatalf("load %s: %v", want.File, err)
+	}
+	pkg, err := CompileComponents("memory1", "Memory", []ComponentSource{{ID: want.ID, Name: want.Name, XML: data}})
+	if err != nil {
+		t.Fatalf("CompileComponents: %v", err)
+	}
+	got := pkg.ItemByName("BagWin")
+	if got == nil || got.Owner != pkg || got.ObjectType != want.ObjectType || got.Width != want.Width {
+		t.Fatalf("unexpected item %+v", got)
+	}
+	compareComponentData(t, "BagWin", got.Component, want.Component)
+
+	if _, err := CompileComponents("memory2", "Memory", []ComponentSource{{ID: "a", XML: data}, {ID: "a", XML: data}}); err == nil {
+		t.Fatalf("expected an error for duplicate ids")
+	}
+	if _, err := CompileComponents("memory3", "Memory", []ComponentSource{{ID: "a", Name: "Bad"}}); err == nil {
+		t.Fatalf("expected an error for empty xml")
+	}
+}
@@ -0,0 +1,210 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xmlNode 是编辑器工程 XML（package.xml、组件 XML）的通用节点
+type xmlNode struct {
+	Name     string
+	Attrs    map[string]string
+	Children []*xmlNode
+}
+
+// parseXMLNode 解析 XML 文档并返回根节点
+func parseXMLNode(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{Name: t.Name.Local, Attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("assets: empty xml document")
+	}
+	return root, nil
+}
+
+// Attr 返回属性值，不存在时返回空字符串
+func (n *xmlNode) Attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	return n.Attrs[name]
+}
+
+// Has 报告属性是否存在
+func (n *xmlNode) Has(name string) bool {
+	if n == nil {
+		return false
+	}
+	_, ok := n.Attrs[name]
+	return ok
+}
+
+// Child 返回第一个名为 name 的子节点
+func (n *xmlNode) Child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// ChildrenNamed 返回所有名为 name 的子节点
+func (n *xmlNode) ChildrenNamed(name string) []*xmlNode {
+	if n == nil {
+		return nil
+	}
+	var out []*xmlNode
+	for _, c := range n.Children {
+		if c.Name == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Bool 按编辑器约定解析布尔属性（"true"），属性不存在时返回 def
+func (n *xmlNode) Bool(name string, def bool) bool {
+	if n == nil {
+		return def
+	}
+	v, ok := n.Attrs[name]
+	if !ok {
+		return def
+	}
+	return v == "true"
+}
+
+// Int 解析整数属性，不存在或无效时返回 def
+func (n *xmlNode) Int(name string, def int) int {
+	if v := n.Attr(name); v != "" {
+		if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return int(f)
+		}
+	}
+	return def
+}
+
+// Float 解析浮点属性，不存在或无效时返回 def
+func (n *xmlNode) Float(name string, def float32) float32 {
+	if v := n.Attr(name); v != "" {
+		return parseXMLFloat(v, def)
+	}
+	return def
+}
+
+// Color 解析颜色属性，不存在时返回 def
+func (n *xmlNode) Color(name string, def uint32) uint32 {
+	if v := n.Attr(name); v != "" {
+		return parseXMLColor(v, def)
+	}
+	return def
+}
+
+// Ints 解析逗号分隔的整数属性，例如 xy="10,20"
+func (n *xmlNode) Ints(name string) []int {
+	return parseXMLInts(n.Attr(name))
+}
+
+// Floats 解析逗号分隔的浮点属性，例如 pivot="0.5,0.5"
+func (n *xmlNode) Floats(name string) []float32 {
+	v := n.Attr(name)
+	if v == "" {
+		return nil
+	}
+	parts := strings.Split(v, ",")
+	out := make([]float32, len(parts))
+	for i, p := range parts {
+		out[i] = parseXMLFloat(p, 0)
+	}
+	return out
+}
+
+func parseXMLInts(v string) []int {
+	if v == "" {
+		return nil
+	}
+	parts := strings.Split(v, ",")
+	out := make([]int, len(parts))
+	for i, p := range parts {
+		p = strings.TrimSpace(p)
+		if n, err := strconv.Atoi(p); err == nil {
+			out[i] = n
+		} else if f, err := strconv.ParseFloat(p, 64); err == nil {
+			out[i] = int(f)
+		}
+	}
+	return out
+}
+
+func parseXMLFloat(v string, def float32) float32 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 32)
+	if err != nil {
+		return def
+	}
+	return float32(f)
+}
+
+// parseXMLColor 解析 #rrggbb 或 #aarrggbb，返回 0xAARRGGBB
+func parseXMLColor(v string, def uint32) uint32 {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "#")
+	c, err := strconv.ParseUint(v, 16, 32)
+	if err != nil {
+		return def
+	}
+	switch len(v) {
+	case 6:
+		return 0xff000000 | uint32(c)
+	case 8:
+		return uint32(c)
+	}
+	return def
+}
+
+// pairInts 返回逗号分隔属性中的前两个整数，缺失项为 0
+func pairInts(v string) (int, int) {
+	vals := parseXMLInts(v)
+	for len(vals) < 2 {
+		vals = append(vals, 0)
+	}
+	return vals[0], vals[1]
+}
@@ -0,0 +1,380 @@
+package assets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+)
+
+// ReferenceKind 标识资源引用在组件数据中的来源
+type ReferenceKind int
+
+const (
+	// ReferenceChild 组件子对象的 src/pkg
+	ReferenceChild ReferenceKind = iota
+	// ReferenceIcon 按钮、标签、下拉框、列表项的图标，图标齿轮及装载器 url
+	ReferenceIcon
+	// ReferenceText 文本、标题中的 ui:// 地址（例如 [img] 图片）
+	ReferenceText
+	// ReferenceFont 文本字体
+	ReferenceFont
+	// ReferenceListItem 列表的默认项和列表项资源
+	ReferenceListItem
+	// ReferenceSound 按钮音效、组件出现/移除音效及动效中的音效
+	ReferenceSound
+	// ReferenceScroll 滚动条、下拉刷新 header/footer 资源
+	ReferenceScroll
+	// ReferenceDropdown 下拉框的弹出组件
+	ReferenceDropdown
+	// ReferenceHitTest 像素点击区域使用的图片
+	ReferenceHitTest
+	// ReferenceGlyph 位图字体的字形图片
+	ReferenceGlyph
+	// ReferenceDependency 包头中声明的依赖包
+	ReferenceDependency
+)
+
+var referenceKindNames = [...]string{
+	"child", "icon", "text", "font", "list item", "sound", "scroll", "dropdown", "hit test", "glyph", "dependency",
+}
+
+func (k ReferenceKind) String() string {
+	if k >= 0 && int(k) < len(referenceKindNames) {
+		return referenceKindNames[k]
+	}
+	return fmt.Sprintf("ReferenceKind(%d)", int(k))
+}
+
+// Reference 描述一次资源引用，URL 统一为 ui://包ID资源ID 或 ui://包名/资源名 形式
+type Reference struct {
+	Package *Package
+	Source  *PackageItem // 包头声明的依赖为 nil
+	Kind    ReferenceKind
+	URL     string
+	// Target 由 AnalyzeDependencies 解析填充，找不到目标时为 nil
+	Target *PackageItem
+}
+
+func (r Reference) String() string {
+	from := ""
+	if r.Package != nil {
+		from = r.Package.Name
+	}
+	if r.Source != nil {
+		from += "/" + r.Source.Name
+	}
+	return fmt.Sprintf("%s -> %s (%s)", from, r.URL, r.Kind)
+}
+
+var textURLPattern = regexp.MustCompile(`ui://[^\s\[\]"'<>]+`)
+
+// CollectReferences 返回组件、字体资源数据中引用的其它资源，Target 不做解析。
+// 遍历的位置对应构建时读取资源地址的各处：子对象、按钮/标签/下拉框扩展、列表项、
+// 装载器、文本字体与内容、图标/文本齿轮、动效、滚动条、下拉框弹出组件和点击区域。
+func CollectReferences(item *PackageItem) (refs []Reference, err error) {
+	if item == nil || item.RawData == nil || item.Owner == nil {
+		return nil, nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("assets: truncated data in %s/%s: %v", item.Owner.Name, item.Name, r)
+		}
+	}()
+	raw := item.RawData
+	buf := utils.NewByteBuffer(raw.Bytes())
+	buf.StringTable = raw.StringTable
+	buf.Version = raw.Version
+	c := &referenceCollector{item: item, buf: buf}
+	switch item.Type {
+	case PackageItemTypeComponent:
+		c.collectComponent()
+	case PackageItemTypeFont:
+		c.collectFont()
+	}
+	return c.refs, nil
+}
+
+type referenceCollector struct {
+	item *PackageItem
+	buf  *utils.ByteBuffer
+	refs []Reference
+}
+
+func (c *referenceCollector) add(kind ReferenceKind, url string) {
+	if strings.HasPrefix(url, "ui://") {
+		c.refs = append(c.refs, Reference{Package: c.item.Owner, Source: c.item, Kind: kind, URL: url})
+	}
+}
+
+// addItemID 记录按资源 ID 引用的资源，pkgID 为空表示同一个包
+func (c *referenceCollector) addItemID(kind ReferenceKind, pkgID, itemID string) {
+	if itemID == "" {
+		return
+	}
+	if pkgID == "" {
+		pkgID = c.item.Owner.ID
+	}
+	c.add(kind, "ui://"+pkgID+itemID)
+}
+
+func (c *referenceCollector) addText(text string) {
+	for _, url := range textURLPattern.FindAllString(text, -1) {
+		c.add(ReferenceText, url)
+	}
+}
+
+func (c *referenceCollector) readS() string {
+	return stringValue(c.buf.ReadS())
+}
+
+func (c *referenceCollector) readURL(kind ReferenceKind) {
+	c.add(kind, c.readS())
+}
+
+func (c *referenceCollector) readText() {
+	c.addText(c.readS())
+}
+
+func (c *referenceCollector) collectComponent() {
+	buf := c.buf
+	if buf.Seek(0, 2) {
+		count := int(buf.ReadInt16())
+		for i := 0; i < count; i++ {
+			next := int(buf.ReadInt16()) + buf.Pos()
+			c.collectChild(buf.Pos())
+			_ = buf.SetPos(next)
+		}
+	}
+
+	if buf.Seek(0, 4) {
+		_ = c.readS() // customData
+		_ = buf.ReadBool()
+		if buf.ReadInt16() >= 0 {
+			_ = buf.ReadBool()
+		}
+		c.addItemID(ReferenceHitTest, "", c.readS())
+		_ = buf.Skip(8)
+		if buf.Version >= 5 {
+			c.readURL(ReferenceSound)
+			c.readURL(ReferenceSound)
+		}
+	}
+
+	c.collectTransitions()
+
+	if buf.Seek(0, 6) {
+		switch c.item.ObjectType {
+		case ObjectTypeButton:
+			_ = buf.ReadUint8() // mode
+			c.readURL(ReferenceSound)
+		case ObjectTypeComboBox:
+			c.readURL(ReferenceDropdown)
+		}
+	}
+
+	if c.item.Component != nil && c.item.Component.Overflow == OverflowTypeScroll && buf.Seek(0, 7) {
+		c.collectScroll()
+	}
+}
+
+func (c *referenceCollector) collectChild(start int) {
+	buf := c.buf
+	if !buf.Seek(start, 0) {
+		return
+	}
+	typ := ObjectType(buf.ReadUint8())
+	src := c.readS()
+	pkgID := c.readS()
+	c.addItemID(ReferenceChild, pkgID, src)
+
+	if buf.Seek(start, 2) {
+		c.collectGears()
+	}
+
+	switch typ {
+	case ObjectTypeText, ObjectTypeRichText, ObjectTypeInputText:
+		if buf.Seek(start, 5) {
+			c.readURL(ReferenceFont)
+		}
+		if buf.Seek(start, 6) {
+			c.readText()
+		}
+	case ObjectTypeLoader, ObjectTypeLoader3D:
+		if buf.Seek(start, 5) {
+			c.readURL(ReferenceIcon)
+		}
+	case ObjectTypeComponent:
+		if buf.Seek(start, 6) {
+			c.collectInstanceExtension()
+		}
+	case ObjectTypeList, ObjectTypeTree:
+		if buf.Seek(start, 7) {
+			c.collectScroll()
+		}
+		if buf.Seek(start, 8) {
+			c.collectListItems(typ == ObjectTypeTree)
+		}
+	}
+}
+
+// collectGears 读取图标齿轮和文本齿轮中各页面的取值，格式对应 GearIcon/GearText.Setup
+func (c *referenceCollector) collectGears() {
+	buf := c.buf
+	count := int(buf.ReadInt16())
+	for i := 0; i < count; i++ {
+		next := int(buf.ReadInt16()) + buf.Pos()
+		index := int(buf.ReadUint8())
+		if index == 6 || index == 7 {
+			read := c.readText
+			if index == 7 {
+				read = func() { c.readURL(ReferenceIcon) }
+			}
+			_ = buf.ReadInt16() // controller
+			pages := int(buf.ReadInt16())
+			for j := 0; j < pages; j++ {
+				if buf.ReadS() == nil {
+					continue
+				}
+				read()
+			}
+			if buf.ReadBool() {
+				read()
+			}
+		}
+		_ = buf.SetPos(next)
+	}
+}
+
+// collectInstanceExtension 读取组件实例上按钮、标签、下拉框的扩展属性（子对象分段 6）
+func (c *referenceCollector) collectInstanceExtension() {
+	buf := c.buf
+	if buf.Remaining() <= 0 {
+		return
+	}
+	switch ObjectType(buf.ReadUint8()) {
+	case ObjectTypeButton:
+		c.readText()
+		c.readText()
+		c.readURL(ReferenceIcon)
+		c.readURL(ReferenceIcon)
+		if buf.ReadBool() {
+			_ = buf.Skip(4)
+		}
+		_ = buf.ReadInt32()
+		_ = buf.ReadInt16()
+		_ = c.readS()
+		c.readURL(ReferenceSound)
+	case ObjectTypeLabel:
+		c.readText()
+		c.readURL(ReferenceIcon)
+	case ObjectTypeComboBox:
+		count := int(buf.ReadInt16())
+		for i := 0; i < count; i++ {
+			next := int(buf.ReadInt16()) + buf.Pos()
+			c.readText()
+			_ = c.readS() // value
+			c.readURL(ReferenceIcon)
+			_ = buf.SetPos(next)
+		}
+		c.readText()
+		c.readURL(ReferenceIcon)
+	}
+}
+
+// collectListItems 读取列表分段 8：默认项及每个列表项的资源、标题和图标
+func (c *referenceCollector) collectListItems(tree bool) {
+	buf := c.buf
+	c.readURL(ReferenceListItem)
+	count := int(buf.ReadInt16())
+	for i := 0; i < count; i++ {
+		next := int(buf.ReadInt16()) + buf.Pos()
+		c.readURL(ReferenceListItem)
+		if tree {
+			_ = buf.ReadBool()
+			_ = buf.ReadUint8()
+		}
+		c.readText()
+		c.readText()
+		c.readURL(ReferenceIcon)
+		c.readURL(ReferenceIcon)
+		_ = buf.SetPos(next)
+	}
+}
+
+// collectScroll 读取滚动配置中的滚动条及 header/footer 资源，格式对应 GComponent.SetupScroll
+func (c *referenceCollector) collectScroll() {
+	buf := c.buf
+	_ = buf.Skip(6)
+	if buf.ReadBool() {
+		_ = buf.Skip(16)
+	}
+	for i := 0; i < 4; i++ {
+		c.readURL(ReferenceScroll)
+	}
+}
+
+// collectTransitions 读取动效中的音效、文本和图标取值（组件分段 5）
+func (c *referenceCollector) collectTransitions() {
+	buf := c.buf
+	if !buf.Seek(0, 5) {
+		return
+	}
+	count := int(buf.ReadInt16())
+	for i := 0; i < count; i++ {
+		next := int(buf.ReadInt16()) + buf.Pos()
+		_ = c.readS() // name
+		_ = buf.Skip(13)
+		items := int(buf.ReadInt16())
+		for j := 0; j < items; j++ {
+			length := int(buf.ReadInt16())
+			start := buf.Pos()
+			c.collectTransitionItem(start)
+			_ = buf.SetPos(start + length)
+		}
+		_ = buf.SetPos(next)
+	}
+}
+
+func (c *referenceCollector) collectTransitionItem(start int) {
+	buf := c.buf
+	if !buf.Seek(start, 0) {
+		return
+	}
+	var read func()
+	switch buf.ReadUint8() {
+	case 9: // sound
+		read = func() { c.readURL(ReferenceSound) }
+	case 14: // text
+		read = c.readText
+	case 15: // icon
+		read = func() { c.readURL(ReferenceIcon) }
+	default:
+		return
+	}
+	_ = buf.Skip(6) // time, target
+	_ = c.readS()   // label
+	tween := buf.ReadBool()
+	if buf.Seek(start, 2) {
+		read()
+	}
+	if tween && buf.Seek(start, 3) {
+		read()
+	}
+}
+
+// collectFont 读取位图字体中使用独立图片的字形
+func (c *referenceCollector) collectFont() {
+	buf := c.buf
+	if !buf.Seek(0, 1) {
+		return
+	}
+	count := int(buf.ReadInt32())
+	for i := 0; i < count; i++ {
+		next := int(buf.ReadInt16()) + buf.Pos()
+		_ = buf.ReadUint16()
+		c.addItemID(ReferenceGlyph, "", c.readS())
+		_ = buf.SetPos(next)
+	}
+}
@@ -21,6 +21,9 @@ func RegisterPackage(pkg *Package) {
 	if pkg == nil {
 		return
 	}
+	if len(pkg.Branches) > 0 {
+		pkg.applyBranch(Branch())
+	}
 	packageRegistry.Lock()
 	defer packageRegistry.Unlock()
 
@@ -40,11 +43,46 @@ func UnregisterPackage(pkg *Package) {
 	packageRegistry.Lock()
 	defer packageRegistry.Unlock()
 
-	if pkg.ID != "" {
+	if pkg.ID != "" && packageRegistry.byID[pkg.ID] == pkg {
 		delete(packageRegistry.byID, pkg.ID)
 	}
-	if pkg.Name != "" {
-		delete(packageRegistry.byName, strings.ToLower(pkg.Name))
+	key := strings.ToLower(pkg.Name)
+	if pkg.Name != "" && packageRegistry.byName[key] == pkg {
+		delete(packageRegistry.byName, key)
+	}
+}
+
+// unloadHooks 保存包卸载时需要通知的回调（字体、音频等全局缓存）
+var unloadHooks struct {
+	sync.RWMutex
+	fns []func(*Package)
+}
+
+// OnPackageUnload 注册包卸载回调
+// 持有按包缓存资源的子系统（位图字体、音频缓存等）通过它在 UnloadPackage 时释放资源
+func OnPackageUnload(fn func(*Package)) {
+	if fn == nil {
+		return
+	}
+	unloadHooks.Lock()
+	defer unloadHooks.Unlock()
+	unloadHooks.fns = append(unloadHooks.fns, fn)
+}
+
+// UnloadPackage 从全局注册表移除包，并通知所有 OnPackageUnload 回调释放缓存
+// 对应 TypeScript 版本 UIPackage.removePackage 中的 unloadAssets 部分
+func UnloadPackage(pkg *Package) {
+	if pkg == nil {
+		return
+	}
+	UnregisterPackage(pkg)
+
+	unloadHooks.RLock()
+	fns := make([]func(*Package), len(unloadHooks.fns))
+	copy(fns, unloadHooks.fns)
+	unloadHooks.RUnlock()
+	for _, fn := range fns {
+		fn(pkg)
 	}
 }
 
@@ -73,6 +111,7 @@ func GetPackageByName(name string) *Package {
 //   - ui://packageId+itemId (例如: ui://9leh0eyf6pmb6, 8+8字符)
 //   - ui://packageName/itemName (例如: ui://Basics/button)
 //
+// 设置了分支（SetBranch）时返回对应的分支变体
 // 参考 LayaAir UIPackage.ts:210-237
 func GetItemByURL(url string) *PackageItem {
 	if url == "" {
@@ -0,0 +1,114 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// StringTable 保存编辑器导出的多语言字符串
+// 第一层键为 包ID+组件ID（例如 "9leh0eyfrpmb6"），第二层键为元素键（例如 "n3_rpmb"、"n5_abcd-tips"）
+type StringTable map[string]map[string]string
+
+// Component 返回组件（包ID+组件ID）对应的字符串，没有时返回 nil
+func (t StringTable) Component(pkgID, itemID string) map[string]string {
+	if t == nil {
+		return nil
+	}
+	return t[pkgID+itemID]
+}
+
+// ParseStringTable 解析编辑器导出的 XML 字符串表：
+//
+//	<resources>
+//	  <string name="9leh0eyfrpmb6-n3_rpmb">Hello</string>
+//	</resources>
+//
+// name 中第一个 "-" 之前是 包ID+组件ID，之后是元素键
+// 对应 TypeScript 版本的 TranslationHelper.loadFromXML
+func ParseStringTable(data []byte) (StringTable, error) {
+	var doc struct {
+		XMLName xml.Name `xml:"resources"`
+		Strings []struct {
+			Name string `xml:"name,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"string"`
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("assets: parse string table: %w", err)
+	}
+	table := make(StringTable)
+	for _, entry := range doc.Strings {
+		idx := strings.Index(entry.Name, "-")
+		if idx == -1 {
+			continue
+		}
+		compKey, elemKey := entry.Name[:idx], entry.Name[idx+1:]
+		col := table[compKey]
+		if col == nil {
+			col = make(map[string]string)
+			table[compKey] = col
+		}
+		col[elemKey] = entry.Text
+	}
+	return table, nil
+}
+
+// translationState 保存当前生效的字符串表及切换回调
+var translationState struct {
+	sync.RWMutex
+	table     StringTable
+	listeners []func()
+}
+
+// LoadTranslationXML 解析 XML 字符串表并设为当前语言
+func LoadTranslationXML(data []byte) error {
+	table, err := ParseStringTable(data)
+	if err != nil {
+		return err
+	}
+	SetStringTable(table)
+	return nil
+}
+
+// SetStringTable 切换当前语言的字符串表，nil 表示关闭翻译并恢复为包内原文
+// 已构建的组件通过 OnTranslationChanged 回调重新翻译
+func SetStringTable(table StringTable) {
+	translationState.Lock()
+	translationState.table = table
+	listeners := make([]func(), len(translationState.listeners))
+	copy(listeners, translationState.listeners)
+	translationState.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// CurrentStringTable 返回当前字符串表，未加载时返回 nil
+func CurrentStringTable() StringTable {
+	translationState.RLock()
+	defer translationState.RUnlock()
+	return translationState.table
+}
+
+// TranslationEnabled 报告当前是否设置了字符串表
+func TranslationEnabled() bool {
+	translationState.RLock()
+	defer translationState.RUnlock()
+	return translationState.table != nil
+}
+
+// OnTranslationChanged 注册语言切换回调
+func OnTranslationChanged(fn func()) {
+	if fn == nil {
+		return
+	}
+	translationState.Lock()
+	defer translationState.Unlock()
+	translationState.listeners = append(translationState.listeners, fn)
+}
@@ -0,0 +1,52 @@
+package assets
+
+import "testing"
+
+func TestParseStringTable(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<resources>
+  <string name="9leh0eyfrpmb6-n3_rpmb">你好</string>
+  <string name="9leh0eyfrpmb6-n5_abcd-tips">提示</string>
+  <string name="9leh0eyfk2a10-n1_k2a1-2-0">选中</string>
+  <string name="invalid">ignored</string>
+</resources>`)
+	table, err := ParseStringTable(data)
+	if err != nil {
+		t.Fatalf("ParseStringTable: %v", err)
+	}
+	comp := table.Component("9leh0eyf", "rpmb6")
+	if comp["n3_rpmb"] != "你好" || comp["n5_abcd-tips"] != "提示" {
+		t.Fatalf("unexpected component strings %v", comp)
+	}
+	if got := table["9leh0eyfk2a10"]["n1_k2a1-2-0"]; got != "选中" {
+		t.Fatalf("expected element key split at first dash, got %q", got)
+	}
+	if len(table) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(table))
+	}
+
+	if _, err := ParseStringTable([]byte("<resources><string")); err == nil {
+		t.Fatalf("expected error for truncated xml")
+	}
+}
+
+func TestSetStringTableNotifiesListeners(t *testing.T) {
+	t.Cleanup(func() { SetStringTable(nil) })
+
+	calls := 0
+	OnTranslationChanged(func() { calls++ })
+
+	if err := LoadTranslationXML([]byte(`<resources><string name="a-b">c</string></resources>`)); err != nil {
+		t.Fatalf("LoadTranslationXML: %v", err)
+	}
+	if !TranslationEnabled() || CurrentStringTable()["a"]["b"] != "c" {
+		t.Fatalf("expected loaded table to be current")
+	}
+	SetStringTable(nil)
+	if TranslationEnabled() {
+		t.Fatalf("expected translation disabled after SetStringTable(nil)")
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 notifications, got %d", calls)
+	}
+}
@@ -79,11 +79,13 @@ type PackageItem struct {
 	Type       PackageItemType
 	ObjectType ObjectType
 
-	ID     string
-	Name   string
-	File   string
-	Width  int
-	Height int
+	ID       string
+	Name     string
+	Path     string
+	File     string
+	Exported bool
+	Width    int
+	Height   int
 
 	Scale9Grid     *Rect
 	TileGridIndice int
@@ -105,6 +107,8 @@ type PackageItem struct {
 	fontOnce   sync.Once
 	fontErr    error
 
+	branchSource *PackageItem
+
 	Interval    int
 	RepeatDelay int
 	Swing       bool
@@ -203,6 +207,8 @@ type ComponentData struct {
 
 	Children    []ComponentChild
 	Controllers []ControllerData
+	// TransitionNames 是组件动效（分段 5）的名称，动效的具体内容在构建时由 GComponent.SetupTransitions 读取
+	TransitionNames []string
 }
 
 // Margin describes component margins.
@@ -34,6 +34,34 @@ var (
 	globalLoader assets.Loader
 )
 
+func init() {
+	assets.OnPackageUnload(EvictPackage)
+}
+
+// EvictPackage 移除包内音效在缓存中的数据
+// 缓存键可能是文件路径（PackageItem.File）或 ui:// URL，两种形式都会被清理
+func EvictPackage(pkg *assets.Package) {
+	if pkg == nil {
+		return
+	}
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	for _, item := range pkg.Items {
+		if item == nil || item.Type != assets.PackageItemTypeSound {
+			continue
+		}
+		if item.File != "" {
+			delete(audioCache, item.File)
+		}
+		if pkg.ID != "" && item.ID != "" {
+			delete(audioCache, "ui://"+pkg.ID+item.ID)
+		}
+		if pkg.Name != "" && item.Name != "" {
+			delete(audioCache, "ui://"+pkg.Name+"/"+item.Name)
+		}
+	}
+}
+
 // AudioPlayer manages sound effect playback through the Ebiten audio context.
 type AudioPlayer struct {
 	audioContext  *audio2.Context
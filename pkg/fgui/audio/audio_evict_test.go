@@ -0,0 +1,37 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+)
+
+// TestEvictPackageOnUnload 测试包卸载时清理音效缓存
+func TestEvictPackageOnUnload(t *testing.T) {
+	pkg := &assets.Package{ID: "evict001", Name: "EvictPkg"}
+	pkg.Items = []*assets.PackageItem{
+		{ID: "snd0", Name: "click", Type: assets.PackageItemTypeSound, File: "EvictPkg_snd0.wav"},
+		{ID: "img0", Name: "icon", Type: assets.PackageItemTypeImage, File: "EvictPkg_atlas0.png"},
+	}
+
+	player := GetInstance()
+	player.RegisterAudioData("EvictPkg_snd0.wav", []byte{1})
+	player.RegisterAudioData("ui://evict001snd0", []byte{2})
+	player.RegisterAudioData("ui://EvictPkg/click", []byte{3})
+	player.RegisterAudioData("EvictPkg_atlas0.png", []byte{4})
+
+	assets.RegisterPackage(pkg)
+	assets.UnloadPackage(pkg)
+
+	for _, key := range []string{"EvictPkg_snd0.wav", "ui://evict001snd0", "ui://EvictPkg/click"} {
+		if hasAudioDataInCache(key) {
+			t.Errorf("音效缓存未清理: %s", key)
+		}
+	}
+	if !hasAudioDataInCache("EvictPkg_atlas0.png") {
+		t.Errorf("非音效资源不应被清理")
+	}
+	if assets.GetPackageByID(pkg.ID) != nil {
+		t.Errorf("包应已从注册表移除")
+	}
+}
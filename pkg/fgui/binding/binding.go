@@ -0,0 +1,126 @@
+// Package binding 提供 fguigen 生成的组件绑定代码使用的运行时辅助函数。
+//
+// 生成的结构体为组件的每个子对象、控制器和动效声明强类型字段，Bind 方法通过 Binder
+// 按名称查找并检查类型，结构与发布的包不一致时返回包含全部问题的错误，而不是在之后使用时才出错。
+// 对应 FairyGUI 编辑器的 TypeScript 代码导出（UI_xxx 类与 xxxBinder）。
+package binding
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// Binder 记录一次绑定过程中的组件和遇到的问题
+type Binder struct {
+	name string
+	comp *core.GComponent
+	// content 是子对象、控制器和动效所在的组件。作为子对象构建的按钮、标签等控件
+	// 把资源内容放在模板组件中，此时 content 为模板组件，否则与 comp 相同
+	content *core.GComponent
+	errs    []error
+}
+
+// New 创建绑定 comp 的 Binder，name 用于错误信息（通常为 "包名/组件名"）
+func New(name string, comp *core.GComponent) *Binder {
+	b := &Binder{name: name, comp: comp, content: core.ContentOf(comp)}
+	if comp == nil {
+		b.fail(errors.New("component is nil"))
+	}
+	return b
+}
+
+// Component 返回正在绑定的组件
+func (b *Binder) Component() *core.GComponent {
+	return b.comp
+}
+
+// Err 返回绑定过程中遇到的所有问题，没有问题时返回 nil
+func (b *Binder) Err() error {
+	return errors.Join(b.errs...)
+}
+
+func (b *Binder) fail(err error) {
+	b.errs = append(b.errs, fmt.Errorf("binding %s: %w", b.name, err))
+}
+
+// Controller 返回名为 name 的控制器
+func (b *Binder) Controller(name string) *core.Controller {
+	if b.comp == nil {
+		return nil
+	}
+	ctrl := b.content.GetController(name)
+	if ctrl == nil {
+		b.fail(fmt.Errorf("controller %q not found", name))
+	}
+	return ctrl
+}
+
+// Transition 返回名为 name 的动效
+func (b *Binder) Transition(name string) *core.Transition {
+	if b.comp == nil {
+		return nil
+	}
+	trans := b.content.Transition(name)
+	if trans == nil {
+		b.fail(fmt.Errorf("transition %q not found", name))
+	}
+	return trans
+}
+
+// Root 返回组件自身对应的控件，例如按钮组件的 *widgets.GButton，普通组件的 *core.GComponent
+func Root[T any](b *Binder) T {
+	var zero T
+	if b.comp == nil {
+		return zero
+	}
+	w, err := core.WidgetOf[T](b.comp.GObject)
+	if err != nil {
+		b.fail(fmt.Errorf("component is %T, want %T", b.comp.Data(), zero))
+	}
+	return w
+}
+
+// Child 返回名为 name 的子对象对应的控件，例如 *widgets.GList、*widgets.GImage
+func Child[T any](b *Binder, name string) T {
+	var zero T
+	if b.comp == nil {
+		return zero
+	}
+	obj := b.content.GetChild(name)
+	if obj == nil {
+		b.fail(fmt.Errorf("child %q not found", name))
+		return zero
+	}
+	w, err := core.WidgetOf[T](obj)
+	if err != nil {
+		b.fail(fmt.Errorf("child %q is %T, want %T", name, obj.Data(), zero))
+	}
+	return w
+}
+
+// Nested 绑定名为 name 的子组件，T 是为该组件生成的绑定类型
+func Nested[T any, P interface {
+	*T
+	Bind(*core.GComponent) error
+}](b *Binder, name string) P {
+	if b.comp == nil {
+		return nil
+	}
+	obj := b.content.GetChild(name)
+	if obj == nil {
+		b.fail(fmt.Errorf("child %q not found", name))
+		return nil
+	}
+	comp := core.ComponentFrom(obj)
+	if comp == nil {
+		b.fail(fmt.Errorf("child %q is %T, want a component", name, obj.Data()))
+		return nil
+	}
+	p := P(new(T))
+	if err := p.Bind(comp); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return p
+}
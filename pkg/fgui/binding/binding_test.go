@@ -0,0 +1,153 @@
+package binding
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/builder"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+// bagWin 与 fguigen 为 Bag/BagWin 生成的代码相同
+type bagWin struct {
+	*core.GComponent
+
+	Frame    *widgets.GLabel
+	List     *widgets.GList
+	N9       *widgets.GImage
+	PageCtrl *core.Controller
+}
+
+func (c *bagWin) Bind(comp *core.GComponent) error {
+	b := New("Bag/BagWin", comp)
+	c.GComponent = Root[*core.GComponent](b)
+	c.Frame = Child[*widgets.GLabel](b, "frame")
+	c.List = Child[*widgets.GList](b, "list")
+	c.N9 = Child[*widgets.GImage](b, "n9")
+	c.PageCtrl = b.Controller("page")
+	return b.Err()
+}
+
+// bagButton 绑定作为子对象构建的按钮，子对象和控制器位于按钮的模板组件中
+type bagButton struct {
+	*widgets.GButton
+
+	N1         *widgets.GImage
+	ButtonCtrl *core.Controller
+}
+
+func (c *bagButton) Bind(comp *core.GComponent) error {
+	b := New("Bag/BagButton", comp)
+	c.GButton = Root[*widgets.GButton](b)
+	c.N1 = Child[*widgets.GImage](b, "n1")
+	c.ButtonCtrl = b.Controller("button")
+	return b.Err()
+}
+
+type bagMain struct {
+	*core.GComponent
+
+	BagBtn *bagButton
+}
+
+func (c *bagMain) Bind(comp *core.GComponent) error {
+	b := New("Bag/Main", comp)
+	c.GComponent = Root[*core.GComponent](b)
+	c.BagBtn = Nested[bagButton](b, "bagBtn")
+	return b.Err()
+}
+
+func buildBag(t *testing.T, name string) *core.GComponent {
+	t.Helper()
+	loader := assets.NewFileLoader(filepath.Join("..", "..", "..", "demo", "assets"))
+	factory := builder.NewFactoryWithLoader(nil, loader)
+	pkg, err := builder.NewPackageManager(factory, loader).AddPackage(context.Background(), "Bag")
+	if err != nil {
+		t.Skipf("demo assets unavailable: %v", err)
+	}
+	item := pkg.ItemByName(name)
+	if item == nil {
+		t.Fatalf("Bag package missing %s", name)
+	}
+	comp, err := factory.BuildComponent(context.Background(), pkg, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	return comp
+}
+
+func TestBindComponent(t *testing.T) {
+	comp := buildBag(t, "BagWin")
+	var win bagWin
+	if err := win.Bind(comp); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if win.GComponent != comp || win.List == nil || win.List.GComponent.GObject != comp.GetChild("list") {
+		t.Fatalf("expected fields to reference the built objects")
+	}
+	if win.Frame == nil || win.N9 == nil || win.PageCtrl != comp.GetController("page") {
+		t.Fatalf("expected all fields to be bound")
+	}
+}
+
+func TestBindNestedTemplatedChild(t *testing.T) {
+	comp := buildBag(t, "Main")
+	var main bagMain
+	if err := main.Bind(comp); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if main.BagBtn == nil || main.BagBtn.GButton == nil || main.BagBtn.N1 == nil || main.BagBtn.ButtonCtrl == nil {
+		t.Fatalf("expected nested button to be bound through its template, got %+v", main.BagBtn)
+	}
+	if main.BagBtn.GButton.GComponent.GObject != comp.GetChild("bagBtn") {
+		t.Fatalf("expected nested binding to wrap the child button")
+	}
+}
+
+func TestBindReportsMismatches(t *testing.T) {
+	comp := buildBag(t, "BagWin")
+	b := New("Bag/BagWin", comp)
+	if got := Child[*widgets.GButton](b, "list"); got != nil {
+		t.Fatalf("expected nil for a mistyped child")
+	}
+	Child[*widgets.GImage](b, "missing")
+	b.Controller("c9")
+	b.Transition("t9")
+	err := b.Err()
+	if err == nil {
+		t.Fatalf("expected binding errors")
+	}
+	for _, want := range []string{
+		`child "list" is *widgets.GList, want *widgets.GButton`,
+		`child "missing" not found`,
+		`controller "c9" not found`,
+		`transition "t9" not found`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if err := New("Bag/BagWin", nil).Err(); err == nil {
+		t.Fatalf("expected an error for a nil component")
+	}
+}
+
+// bagExt 模拟 widgets.SetExtension 注册的自定义组件
+type bagExt struct{ *core.GComponent }
+
+func TestBindResolvesExtension(t *testing.T) {
+	comp := buildBag(t, "BagWin")
+	ext := &bagExt{GComponent: comp}
+	comp.GObject.SetExtension(ext)
+	b := New("Bag/BagWin", comp)
+	if got := Root[*bagExt](b); got != ext {
+		t.Fatalf("expected the extension instance, got %v", got)
+	}
+	if err := b.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// ErrAsyncBuildCanceled 是 AsyncBuild.Cancel 取消构建后返回的错误
+var ErrAsyncBuildCanceled = errors.New("builder: async build canceled")
+
+// AsyncBuild 是一次分帧进行的组件构建，对应 TypeScript 版本的 AsyncOperation。
+//
+// 构建被拆分为若干工作单元：准备包并创建根组件、构建每个子对象、完成组件设置。
+// 普通嵌套组件（不是按钮、列表等控件）会展开为独立的工作单元，因此大型界面的每一层都能分帧完成。
+// 工作单元由 core.RegisterTicker 驱动，每帧最多占用 UIConfig.FrameTimeForAsyncUIConstruction 秒
+// （至少执行一个单元），所有构建都在调用 GRoot.Advance 的线程中进行。
+type AsyncBuild struct {
+	factory *Factory
+	ctx     context.Context
+	onDone  func(*core.GComponent, error)
+
+	stack []*asyncFrame
+	// nested 保存已完成、等待父组件 buildChild 使用的嵌套组件
+	nested map[*assets.ComponentChild]*core.GComponent
+
+	stopTicker func()
+	done       chan struct{}
+	result     *core.GComponent
+	err        error
+	now        func() time.Time
+}
+
+// asyncFrame 是构建栈中的一个组件
+type asyncFrame struct {
+	pkg   *assets.Package
+	item  *assets.PackageItem
+	build *componentBuild
+	// child 是嵌套组件在父组件中的子对象描述，根组件为 nil
+	child *assets.ComponentChild
+	// expanded 记录已经尝试展开为独立构建的子对象，避免重复展开
+	expanded *assets.ComponentChild
+}
+
+// BuildComponentAsync 分帧构建 item 对应的组件。完成、出错或 ctx 被取消时调用 done（可为 nil），
+// 也可以通过 Done/Result 等待结果。done 在 GRoot.Advance 所在的线程中调用。
+func (f *Factory) BuildComponentAsync(ctx context.Context, pkg *assets.Package, item *assets.PackageItem, done func(*core.GComponent, error)) *AsyncBuild {
+	op := &AsyncBuild{
+		factory: f,
+		ctx:     ctx,
+		onDone:  done,
+		stack:   []*asyncFrame{{pkg: pkg, item: item}},
+		nested:  make(map[*assets.ComponentChild]*core.GComponent),
+		done:    make(chan struct{}),
+		now:     time.Now,
+	}
+	op.stopTicker = core.RegisterTicker(op.tick)
+	return op
+}
+
+// Done 返回构建结束（完成、失败或取消）时关闭的 channel
+func (op *AsyncBuild) Done() <-chan struct{} {
+	return op.done
+}
+
+// Result 返回构建结果，构建结束前返回 nil, nil
+func (op *AsyncBuild) Result() (*core.GComponent, error) {
+	select {
+	case <-op.done:
+		return op.result, op.err
+	default:
+		return nil, nil
+	}
+}
+
+// Cancel 停止构建，已构建的部分被丢弃，结果错误为 ErrAsyncBuildCanceled。
+// 对应 TypeScript 版本的 AsyncOperation.cancel
+func (op *AsyncBuild) Cancel() {
+	op.finish(nil, ErrAsyncBuildCanceled)
+}
+
+// tick 在帧时间预算内执行工作单元
+func (op *AsyncBuild) tick(time.Duration) {
+	budget := time.Duration(core.GetUIConfig().FrameTimeForAsyncUIConstruction * float64(time.Second))
+	start := op.now()
+	for !op.finished() {
+		if err := op.ctx.Err(); err != nil {
+			op.finish(nil, err)
+			return
+		}
+		op.step()
+		if op.now().Sub(start) >= budget {
+			return
+		}
+	}
+}
+
+// step 执行一个工作单元
+func (op *AsyncBuild) step() {
+	f := op.factory
+	top := op.stack[len(op.stack)-1]
+
+	// 与同步构建的嵌套深度保持一致：只有根组件是顶层组件
+	depth, saved := len(op.stack), f.asyncNested
+	f.asyncNested = op.nested
+	f.buildDepth += depth
+	defer func() {
+		f.buildDepth -= depth
+		f.asyncNested = saved
+	}()
+
+	if top.build == nil {
+		b, err := f.beginComponent(op.ctx, top.pkg, top.item, nil)
+		if err != nil {
+			if top.child == nil {
+				op.finish(nil, err)
+				return
+			}
+			// 与同步构建一致：嵌套组件失败时只记录日志，父组件中保留占位对象
+			fmt.Println("builder: nested component error", err)
+			op.stack = op.stack[:len(op.stack)-1]
+			return
+		}
+		top.build = b
+		return
+	}
+
+	if b := top.build; !b.childrenDone() {
+		child := &b.item.Component.Children[b.next]
+		if top.expanded != child {
+			top.expanded = child
+			if item := op.nestedItem(b); item != nil {
+				// 与 buildNestedComponent 相同，引用其它包的组件在其所在的包中构建
+				pkg := b.pkg
+				if item.Owner != nil {
+					pkg = item.Owner
+				}
+				op.stack = append(op.stack, &asyncFrame{pkg: pkg, item: item, child: child})
+				return
+			}
+		}
+		f.buildNextChild(b)
+		return
+	}
+
+	comp := f.finishComponent(top.build)
+	op.stack = op.stack[:len(op.stack)-1]
+	if top.child == nil {
+		op.finish(comp, nil)
+		return
+	}
+	comp.SetResourceID(top.item.ID)
+	op.nested[top.child] = comp
+}
+
+// nestedItem 返回可以展开为独立构建的普通嵌套组件资源，对应 buildChild 中调用 buildNestedComponent 的情况
+func (op *AsyncBuild) nestedItem(b *componentBuild) *assets.PackageItem {
+	child := &b.item.Component.Children[b.next]
+	if child.Type != assets.ObjectTypeComponent {
+		return nil
+	}
+	item := op.factory.resolveChild(op.ctx, b.pkg, b.item, child, &b.template.children[b.next])
+	branch := item.Branch()
+	if branch == nil || branch.Type != assets.PackageItemTypeComponent || branch.ObjectType != assets.ObjectTypeComponent || branch.Component == nil {
+		return nil
+	}
+	return item
+}
+
+func (op *AsyncBuild) finished() bool {
+	select {
+	case <-op.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (op *AsyncBuild) finish(comp *core.GComponent, err error) {
+	if op.finished() {
+		return
+	}
+	op.stopTicker()
+	op.result, op.err = comp, err
+	op.stack = nil
+	op.nested = nil
+	close(op.done)
+	if op.onDone != nil {
+		op.onDone(comp, err)
+	}
+}
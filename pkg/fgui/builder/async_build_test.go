@@ -0,0 +1,157 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// describeTree 输出对象树的名称、类型、位置和尺寸，用于比较两次构建的结果
+func describeTree(obj *core.GObject) string {
+	var sb strings.Builder
+	var walk func(obj *core.GObject, depth int)
+	walk = func(obj *core.GObject, depth int) {
+		fmt.Fprintf(&sb, "%s%s %T %s %.0f,%.0f %.0fx%.0f %v\n", strings.Repeat("  ", depth), obj.Name(), obj.Data(),
+			obj.ResourceID(), obj.X(), obj.Y(), obj.Width(), obj.Height(), obj.Visible())
+		if comp := core.ComponentFrom(obj); comp != nil {
+			for _, ctrl := range comp.Controllers() {
+				fmt.Fprintf(&sb, "%s[%s=%d]\n", strings.Repeat("  ", depth+1), ctrl.Name, ctrl.SelectedIndex())
+			}
+			for _, child := range comp.Children() {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(obj, 0)
+	return sb.String()
+}
+
+// runAsync 以 tick 驱动构建直到完成，每次读取时间前进 step，返回所用帧数和构建栈的最大深度
+func runAsync(t *testing.T, op *AsyncBuild, step time.Duration) (frames, depth int) {
+	t.Helper()
+	clock := time.Unix(0, 0)
+	op.now = func() time.Time {
+		clock = clock.Add(step)
+		return clock
+	}
+	for !op.finished() {
+		if frames > 10000 {
+			t.Fatalf("async build did not finish")
+		}
+		depth = max(depth, len(op.stack))
+		op.tick(16 * time.Millisecond)
+		frames++
+	}
+	return frames, depth
+}
+
+func TestBuildComponentAsyncMatchesSync(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "Basics")
+	item := pkg.ItemByName("Main")
+	if item == nil {
+		t.Skip("Basics package missing Main")
+	}
+	ctx := context.Background()
+	want, err := factory.BuildComponent(ctx, pkg, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+
+	var delivered *core.GComponent
+	calls := 0
+	op := factory.BuildComponentAsync(ctx, pkg, item, func(comp *core.GComponent, err error) {
+		calls++
+		delivered = comp
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if comp, err := op.Result(); comp != nil || err != nil {
+		t.Fatalf("expected no result before the build runs")
+	}
+	// 每次读取时间前进 1ms，2ms 的预算每帧只能执行一到两个单元
+	frames, depth := runAsync(t, op, time.Millisecond)
+	got, err := op.Result()
+	if err != nil || got == nil || got != delivered || calls != 1 {
+		t.Fatalf("expected one delivered result, got %v %v (calls %d)", got, err, calls)
+	}
+	if frames <= len(item.Component.Children)/2 {
+		t.Fatalf("expected the build to be split across frames, finished in %d", frames)
+	}
+	if depth < 2 {
+		t.Fatalf("expected nested components to be built as separate units")
+	}
+	if diff := describeTree(got.GObject); diff != describeTree(want.GObject) {
+		t.Fatalf("async tree differs from sync build:\n%s\nwant:\n%s", diff, describeTree(want.GObject))
+	}
+	if len(factory.asyncNested) != 0 || factory.buildDepth != 0 {
+		t.Fatalf("expected factory build state to be restored")
+	}
+	select {
+	case <-op.Done():
+	default:
+		t.Fatalf("expected Done to be closed")
+	}
+}
+
+func TestBuildComponentAsyncCancel(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "Basics")
+	item := pkg.ItemByName("Main")
+	if item == nil {
+		t.Skip("Basics package missing Main")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var gotErr error
+	op := factory.BuildComponentAsync(ctx, pkg, item, func(comp *core.GComponent, err error) {
+		gotErr = err
+		if comp != nil {
+			t.Errorf("expected no component after cancellation")
+		}
+	})
+	// 时间每次读取前进 1 小时，每帧只执行一个单元
+	clock := time.Unix(0, 0)
+	op.now = func() time.Time {
+		clock = clock.Add(time.Hour)
+		return clock
+	}
+	op.tick(0)
+	if op.finished() {
+		t.Fatalf("expected one frame to run a single unit")
+	}
+	cancel()
+	op.tick(0)
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", gotErr)
+	}
+
+	op = factory.BuildComponentAsync(context.Background(), pkg, item, nil)
+	op.Cancel()
+	if _, err := op.Result(); !errors.Is(err, ErrAsyncBuildCanceled) {
+		t.Fatalf("expected ErrAsyncBuildCanceled, got %v", err)
+	}
+}
+
+func TestBuildComponentAsyncError(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "Basics")
+	var image *assets.PackageItem
+	for _, it := range pkg.Items {
+		if it.Type == assets.PackageItemTypeImage {
+			image = it
+			break
+		}
+	}
+	if image == nil {
+		t.Skip("Basics package has no image")
+	}
+	op := factory.BuildComponentAsync(context.Background(), pkg, image, nil)
+	runAsync(t, op, time.Millisecond)
+	if comp, err := op.Result(); err == nil || comp != nil {
+		t.Fatalf("expected build error for non-component item, got %v %v", comp, err)
+	}
+}
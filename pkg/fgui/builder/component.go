@@ -29,6 +29,22 @@ type Factory struct {
 	// 性能优化：包状态缓存
 	loadedPackages  map[string]bool // 已加载Atlas的包
 	registeredFonts map[string]bool // 已注册字体的包
+
+	// 包生命周期管理：跟踪顶层组件（buildDepth 为 1 时构建的组件）
+	packages   *PackageManager
+	buildDepth int
+
+	// 多语言：已翻译组件的原文记录
+	translations      []*translatedComponent
+	translationHooked bool
+	translationGen    uint64 // 每次 Retranslate 递增，使模板中缓存的可翻译字段失效
+
+	// 异步构建：已在之前的帧中构建完成、等待 buildChild 使用的嵌套组件
+	asyncNested map[*assets.ComponentChild]*core.GComponent
+
+	// 组件构建模板缓存，见 componentTemplate；noTemplates 为 true 时每次构建都重新解析（用于对比测试）
+	templates   map[templateKey]*componentTemplate
+	noTemplates bool
 }
 
 // FactoryObjectCreator 将Factory包装为ObjectCreator，用于GList虚拟列表
@@ -38,6 +54,11 @@ type FactoryObjectCreator struct {
 	ctx     context.Context
 }
 
+// NewObjectCreator 返回按 ui:// URL 创建对象的创建器，可用于 core.GRoot.SetObjectCreator
+func NewObjectCreator(factory *Factory) *FactoryObjectCreator {
+	return &FactoryObjectCreator{factory: factory, ctx: context.Background()}
+}
+
 // CreateObject 实现ObjectCreator接口
 func (c *FactoryObjectCreator) CreateObject(url string) *core.GObject {
 	if c.factory == nil {
@@ -161,6 +182,48 @@ func (f *Factory) RegisterPackage(pkg *assets.Package) {
 	assets.RegisterPackage(pkg)
 }
 
+// UnregisterPackage 撤销 RegisterPackage：从 Factory 和全局注册表中移除包，并释放其图集等按包缓存的资源。
+// 通过 PackageManager 加载的包应使用 PackageManager.RemovePackage
+func (f *Factory) UnregisterPackage(pkg *assets.Package) {
+	if pkg == nil {
+		return
+	}
+	f.forgetPackage(pkg)
+	if unloader, ok := f.atlasManager.(PackageUnloader); ok {
+		unloader.UnloadPackage(pkg)
+	}
+	assets.UnloadPackage(pkg)
+}
+
+// isRegistered 判断 pkg 是否已同时登记在 Factory 和全局注册表中
+func (f *Factory) isRegistered(pkg *assets.Package) bool {
+	if pkg.ID == "" || f.packagesByID[pkg.ID] != pkg || assets.GetPackageByID(pkg.ID) != pkg {
+		return false
+	}
+	return pkg.Name == "" || f.packagesByName[pkg.Name] == pkg
+}
+
+// forgetPackage 清除 Factory 中与包相关的注册信息和缓存状态
+// 包被重新加载时会重新执行 Atlas 加载和字体注册
+func (f *Factory) forgetPackage(pkg *assets.Package) {
+	if pkg == nil {
+		return
+	}
+	if pkg.ID != "" && f.packagesByID[pkg.ID] == pkg {
+		delete(f.packagesByID, pkg.ID)
+		delete(f.packageDirs, pkg.ID)
+	}
+	if pkg.Name != "" && f.packagesByName[pkg.Name] == pkg {
+		delete(f.packagesByName, pkg.Name)
+		delete(f.packageDirs, pkg.Name)
+	}
+	for _, key := range []string{pkg.ID, pkg.Name} {
+		delete(f.loadedPackages, key)
+		delete(f.registeredFonts, key)
+	}
+	f.forgetTemplates(pkg)
+}
+
 // ensurePackageReady 确保包已准备好（Atlas已加载、已注册、字体已注册）
 // 使用缓存避免重复操作，提升构建性能
 func (f *Factory) ensurePackageReady(ctx context.Context, pkg *assets.Package) error {
@@ -177,6 +240,11 @@ func (f *Factory) ensurePackageReady(ctx context.Context, pkg *assets.Package) e
 		return nil // 匿名包，跳过缓存
 	}
 
+	// 快速路径：已准备好且仍在注册表中的包无需再次注册
+	if f.registeredFonts[pkgKey] && (f.atlasManager == nil || f.loadedPackages[pkgKey]) && f.isRegistered(pkg) {
+		return nil
+	}
+
 	// 只加载一次Atlas（避免重复加载纹理）
 	if f.atlasManager != nil && !f.loadedPackages[pkgKey] {
 		if err := f.atlasManager.LoadPackage(ctx, pkg); err != nil {
@@ -226,8 +294,67 @@ func extractGComponent(widget interface{}) *core.GComponent {
 	}
 }
 
+// extensionHook 返回接收构建钩子（ConstructExtension、SetupAfterAdd）的对象：
+// 由 widgets.SetExtension 注册的扩展创建时为自定义实例，否则为内置控件
+func extensionHook(obj *core.GObject, widget interface{}) interface{} {
+	if obj != nil {
+		if ext := obj.Extension(); ext != nil {
+			return ext
+		}
+	}
+	return widget
+}
+
 // BuildComponent instantiates a component hierarchy for the given package item.
+// Items registered through widgets.SetExtension are instantiated with their extension constructor.
 func (f *Factory) BuildComponent(ctx context.Context, pkg *assets.Package, item *assets.PackageItem) (*core.GComponent, error) {
+	return f.buildComponent(ctx, pkg, item, nil)
+}
+
+// buildTemplate 构建控件内部使用的模板组件。模板不是用户可见的对象，始终使用内置控件创建，
+// 避免自定义扩展被实例化两次
+func (f *Factory) buildTemplate(ctx context.Context, pkg *assets.Package, item *assets.PackageItem) (*core.GComponent, error) {
+	widget := widgets.CreateBuiltinWidget(item.Branch())
+	if widget == nil {
+		widget = core.NewGComponent()
+	}
+	return f.buildComponent(ctx, pkg, item, widget)
+}
+
+// componentBuild 保存一个组件构建过程的中间状态。同步构建一次执行完所有步骤，
+// AsyncBuild 则把子对象的构建分散到多帧执行。
+type componentBuild struct {
+	ctx      context.Context
+	pkg      *assets.Package
+	item     *assets.PackageItem
+	template *componentTemplate
+	root     *core.GComponent
+	// next 是下一个要构建的子对象序号
+	next int
+	// topLevel 表示构建开始时没有外层构建，完成后由 PackageManager 跟踪
+	topLevel bool
+}
+
+// buildComponent 构建组件，widget 不为空时作为根组件实例使用（由 buildChild 预先创建），
+// 避免自定义扩展的构造函数被调用两次
+func (f *Factory) buildComponent(ctx context.Context, pkg *assets.Package, item *assets.PackageItem, widget interface{}) (*core.GComponent, error) {
+	f.buildDepth++
+	defer func() { f.buildDepth-- }()
+
+	b, err := f.beginComponent(ctx, pkg, item, widget)
+	if err != nil {
+		return nil, err
+	}
+	for !b.childrenDone() {
+		f.buildNextChild(b)
+	}
+	return f.finishComponent(b), nil
+}
+
+// beginComponent 准备包并创建根组件及其控制器
+func (f *Factory) beginComponent(ctx context.Context, pkg *assets.Package, item *assets.PackageItem, widget interface{}) (*componentBuild, error) {
+	// 分支资源：构建当前分支的变体，参考 TypeScript UIPackage.createObject 中的 pi.getBranch()
+	item = item.Branch()
 	if item == nil || item.Type != assets.PackageItemTypeComponent {
 		return nil, fmt.Errorf("builder: package item must be a component")
 	}
@@ -243,7 +370,9 @@ func (f *Factory) BuildComponent(ctx context.Context, pkg *assets.Package, item
 	// 根据 ObjectType 创建对应的 widget
 	// 对于特殊类型（如 ScrollBar, Button 等），需要创建对应的 widget 实例
 	var root *core.GComponent
-	widget := widgets.CreateWidgetFromPackage(item)
+	if widget == nil {
+		widget = widgets.CreateWidgetFromPackage(item)
+	}
 	if widget != nil {
 		// 从 widget 中提取 GComponent
 		root = extractGComponent(widget)
@@ -312,19 +441,39 @@ func (f *Factory) BuildComponent(ctx context.Context, pkg *assets.Package, item
 		}
 	}
 
-	for idx := range item.Component.Children {
-		child := &item.Component.Children[idx]
-		childObj := f.buildChild(ctx, pkg, item, root, child)
-		root.AddChild(childObj)
-	}
+	return &componentBuild{
+		ctx:      ctx,
+		pkg:      pkg,
+		item:     item,
+		template: f.componentTemplate(ctx, pkg, item),
+		root:     root,
+		topLevel: f.buildDepth == 1,
+	}, nil
+}
+
+func (b *componentBuild) childrenDone() bool {
+	return b.next >= len(b.item.Component.Children)
+}
 
-	f.setupRelations(item, root)
-	f.setupGears(item, root)
+// buildNextChild 构建下一个子对象并添加到根组件
+func (f *Factory) buildNextChild(b *componentBuild) {
+	child := &b.item.Component.Children[b.next]
+	tc := &b.template.children[b.next]
+	b.next++
+	b.root.AddChild(f.buildChild(b.ctx, b.pkg, b.item, b.root, child, tc))
+}
+
+// finishComponent 在所有子对象构建完成后设置关系、控制器联动、扩展属性、滚动等
+func (f *Factory) finishComponent(b *componentBuild) *core.GComponent {
+	ctx, pkg, item, root, tpl := b.ctx, b.pkg, b.item, b.root, b.template
+
+	f.setupRelations(tpl, root)
+	f.setupGears(item, tpl, root)
 
 	// ===== 关键修改：在构建完成后调用 ConstructExtension =====
 	// 对应 TypeScript GComponent.ts 第 1207 行
-	// 只有特殊组件类型（Button、Label等）实现了 ExtensionConstructor
-	if widget, ok := root.GObject.Data().(widgets.ExtensionConstructor); ok {
+	// 只有特殊组件类型（Button、Label等）实现了 ExtensionConstructor，自定义扩展可以覆盖
+	if widget, ok := extensionHook(root.GObject, root.GObject.Data()).(widgets.ExtensionConstructor); ok {
 		if buf := item.RawData; buf != nil {
 			if err := widget.ConstructExtension(buf); err != nil {
 				fmt.Printf("builder: ConstructExtension failed: %v\n", err)
@@ -359,46 +508,20 @@ func (f *Factory) BuildComponent(ctx context.Context, pkg *assets.Package, item
 	// 3. SetupBeforeAdd 会错误地从 RawData Section 0 读取 ComponentData 元数据，而不是 GObject 基础属性
 	//
 	// 但我们需要手动设置 mask、hitTest 和 transitions
-	// 参考 GComponent.SetupBeforeAdd 的实现（gcomponent.go:616-659）
-	if buf := item.RawData; buf != nil {
-		saved := buf.Pos()
-		defer buf.SetPos(saved)
-
-		// 读取 mask 和 hitTest（Section 4）
-		if buf.Seek(0, 4) {
-			if err := buf.Skip(2); err == nil && buf.Remaining() >= 1+2 {
-				root.SetOpaque(buf.ReadBool())
-				maskIndex := int(buf.ReadInt16())
-				reversed := false
-				var maskObj *core.GObject
-				if maskIndex >= 0 {
-					if buf.Remaining() > 0 {
-						reversed = buf.ReadBool()
-					}
-					// 从根组件的子对象中查找 mask
-					maskObj = root.ChildAt(maskIndex)
-				}
-				root.SetMask(maskObj, reversed)
-
-				// 读取 hitTest
-				if buf.Remaining() >= 4+4 {
-					hitID := buf.ReadS()
-					offsetX := int(buf.ReadInt32())
-					offsetY := int(buf.ReadInt32())
-					hitMode := core.HitTest{Mode: core.HitTestModeNone}
-					if hitID != nil && *hitID != "" {
-						hitMode = core.HitTest{Mode: core.HitTestModePixel, ItemID: *hitID, OffsetX: offsetX, OffsetY: offsetY}
-					} else if offsetX != 0 && offsetY != -1 {
-						hitMode = core.HitTest{Mode: core.HitTestModeChild, OffsetX: offsetX, ChildIndex: offsetY}
-					}
-					root.SetHitTest(hitMode)
-				}
-			}
+	// 参考 GComponent.SetupBeforeAdd 的实现（gcomponent.go:616-659），数据已在模板中解析（Section 4、5）
+	if display := tpl.display; display.present {
+		root.SetOpaque(display.opaque)
+		var maskObj *core.GObject
+		if display.maskIndex >= 0 {
+			// 从根组件的子对象中查找 mask
+			maskObj = root.ChildAt(display.maskIndex)
+		}
+		root.SetMask(maskObj, display.reversed)
+		if display.hasHitTest {
+			root.SetHitTest(display.hitTest)
 		}
 	}
-
-	// 设置 transitions（Section 5）
-	root.SetupTransitions(item.RawData, 0)
+	root.AddTransitions(tpl.transitions)
 
 	// 设置 margin 和 overflow（已经由 parseComponentData 解析）
 	// 参考 TypeScript 版本：GComponent.ts setup (1039-1054行)
@@ -441,7 +564,19 @@ func (f *Factory) BuildComponent(ctx context.Context, pkg *assets.Package, item
 	root.SetBoundsChangedFlag()
 	root.EnsureBoundsCorrect()
 
-	return root, nil
+	// 顶层组件会让所属包保持加载状态，直到组件被释放
+	if f.packages != nil && b.topLevel {
+		owner := item.Owner
+		if owner == nil {
+			owner = pkg
+		}
+		f.packages.trackComponent(owner, root)
+	}
+
+	// 多语言：替换文本并记录原文，切换语言时重新翻译（对应 TypeScript TranslationHelper.translateComponent）
+	f.translateComponent(root, item, tpl)
+
+	return root
 }
 
 func childBuffer(owner *assets.PackageItem, child *assets.ComponentChild) *utils.ByteBuffer {
@@ -468,17 +603,26 @@ func (f *Factory) newSetupContext(ctx context.Context, pkg *assets.Package, owne
 	}
 }
 
-func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *assets.PackageItem, parent *core.GComponent, child *assets.ComponentChild) *core.GObject {
-	resolvedItem := f.resolvePackageItem(ctx, pkg, owner, child)
+// buildChild 创建子对象，tc 是子对象在组件模板中的解析结果
+func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *assets.PackageItem, parent *core.GComponent, child *assets.ComponentChild, tc *childTemplate) *core.GObject {
+	resolvedItem := f.resolveChild(ctx, pkg, owner, child, tc)
+	// 异步构建时，普通嵌套组件已经在之前的帧中构建完成
+	prebuilt := f.asyncNested[child]
+	delete(f.asyncNested, child)
 	w := widgets.CreateWidget(child)
-	if resolvedItem != nil {
-		if alt := widgets.CreateWidgetFromPackage(resolvedItem); alt != nil {
-			if w == nil || resolvedItem.ObjectType != child.Type {
-				w = alt
-			}
+	// 自定义扩展按当前分支的资源查找；嵌套组件会复用这里创建的实例
+	if item := resolvedItem.Branch(); prebuilt == nil && item != nil && (w == nil || item.ObjectType != child.Type || widgets.ExtensionFor(item) != nil) {
+		if alt := widgets.CreateWidgetFromPackage(item); alt != nil {
+			w = alt
 		}
 	}
-	sub := childBuffer(owner, child)
+	var sub *utils.ByteBuffer
+	if tc != nil {
+		sub = tc.buf
+	} else {
+		sub = childBuffer(owner, child)
+	}
+	var obj *core.GObject
 	var setupCtx *widgets.SetupContext
 	ensureCtx := func() *widgets.SetupContext {
 		if setupCtx == nil {
@@ -499,19 +643,20 @@ func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *as
 	}
 	callSetupAfterAdd := func(widget interface{}) {
 		if sub != nil {
-			if after, ok := widget.(widgets.AfterAdder); ok {
+			if after, ok := extensionHook(obj, widget).(widgets.AfterAdder); ok {
 				after.SetupAfterAdd(ensureCtx(), sub)
 			}
 		}
 	}
 
-	var obj *core.GObject
-
 	switch widget := w.(type) {
 	case *widgets.GImage:
 		obj = widget.GObject
-		if spriteItem := f.resolveImageSprite(ctx, pkg, owner, child); spriteItem != nil {
-			resolvedItem = spriteItem
+		// 模板解析成功时精灵已经准备好
+		if tc == nil || tc.resolved == nil {
+			if spriteItem := f.resolveImageSprite(ctx, pkg, owner, child); spriteItem != nil {
+				resolvedItem = spriteItem
+			}
 		}
 		widget.SetPackageItem(resolvedItem)
 		obj.SetData(widget)
@@ -556,8 +701,10 @@ func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *as
 			// 关键修复：为子组件也调用 ConstructExtension，读取扩展属性（如 downEffect）
 			// 对应 TypeScript 版本：所有 GButton 实例都会调用 constructExtension
 			if buf := resolvedItem.RawData; buf != nil {
-				if err := widget.ConstructExtension(buf); err != nil {
-					fmt.Printf("builder: child GButton ConstructExtension failed: %v\n", err)
+				if ctor, ok := extensionHook(obj, widget).(widgets.ExtensionConstructor); ok {
+					if err := ctor.ConstructExtension(buf); err != nil {
+						fmt.Printf("builder: child GButton ConstructExtension failed: %v\n", err)
+					}
 				}
 			}
 		}
@@ -662,7 +809,7 @@ func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *as
 		// 关键修复：对于GComboBox组件，也使用buildNestedComponent获取正确实例
 		// 而不是使用在switch语句中创建的实例
 		if resolvedItem != nil {
-			if nested, nestedItem := f.buildNestedComponent(ctx, pkg, owner, child); nested != nil {
+			if nested, nestedItem := f.buildNestedComponent(ctx, pkg, resolvedItem, widget); nested != nil {
 				// 使用buildNestedComponent返回的实例，它已经正确设置了dropdown/list
 				obj = nested.GObject
 				if nestedItem != nil {
@@ -723,8 +870,8 @@ func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *as
 					obj.SetSize(w, h)
 				}
 			}
-			if resolvedItem.PixelHitTest != nil {
-				render.ApplyPixelHitTest(obj.DisplayObject(), resolvedItem.PixelHitTest)
+			if data := resolvedItem.HitTestData(core.ContentScaleLevel); data != nil {
+				render.ApplyPixelHitTest(obj.DisplayObject(), data)
 			}
 		}
 	case assets.ObjectTypeComponent:
@@ -738,7 +885,16 @@ func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *as
 		case *widgets.GList:
 			// list is a composite widget; no additional handling yet.
 		case *core.GComponent, nil:
-			if nested, nestedItem := f.buildNestedComponent(ctx, pkg, owner, child); nested != nil {
+			nested, nestedItem := prebuilt, resolvedItem
+			if nested == nil {
+				// 普通组件只有注册了自定义扩展时才会预先创建实例
+				var widget interface{}
+				if comp, ok := w.(*core.GComponent); ok {
+					widget = comp
+				}
+				nested, nestedItem = f.buildNestedComponent(ctx, pkg, resolvedItem, widget)
+			}
+			if nested != nil {
 				// 关键修复：对于嵌套组件，直接使用 nested 的 GObject
 				// 而不是创建新的 GObject 并把 nested 存储在 Data 中
 				// 这确保 DisplayObject 层级正确连接
@@ -760,6 +916,8 @@ func (f *Factory) buildChild(ctx context.Context, pkg *assets.Package, owner *as
 					nested.GObject.SetVisible(child.Visible)
 					nested.GObject.SetAlpha(float64(child.Alpha))
 				}
+				// 普通组件没有内置的 SetupAfterAdd，只有自定义扩展会收到该钩子
+				callSetupAfterAdd(nil)
 			}
 		default:
 			// unsupported widget types fall back to existing behaviour.
@@ -881,20 +1039,20 @@ func (f *Factory) resolveImageSprite(ctx context.Context, pkg *assets.Package, o
 	if pi == nil {
 		return nil
 	}
-	if f.atlasManager != nil {
-		if _, err := f.atlasManager.ResolveSprite(pi); err != nil {
-			fmt.Printf("builder: resolve sprite failed: %v\n", err)
-		}
-	}
+	f.resolveSprite(pi)
 	return pi
 }
 
-func (f *Factory) buildNestedComponent(ctx context.Context, pkg *assets.Package, owner *assets.PackageItem, child *assets.ComponentChild) (*core.GComponent, *assets.PackageItem) {
-	nestedItem := f.resolvePackageItem(ctx, pkg, owner, child)
+// buildNestedComponent 构建子对象引用的组件资源 nestedItem
+func (f *Factory) buildNestedComponent(ctx context.Context, pkg *assets.Package, nestedItem *assets.PackageItem, widget interface{}) (*core.GComponent, *assets.PackageItem) {
 	if nestedItem == nil {
 		return nil, nil
 	}
-	nested, err := f.BuildComponent(ctx, pkg, nestedItem)
+	// 引用其它包的组件时，其子对象在组件自身所在的包中解析
+	if nestedItem.Owner != nil {
+		pkg = nestedItem.Owner
+	}
+	nested, err := f.buildComponent(ctx, pkg, nestedItem, widget)
 	if err != nil {
 		fmt.Println("builder: nested component error", err)
 		return nil, nil
@@ -962,7 +1120,7 @@ func (f *Factory) applyButtonTemplate(ctx context.Context, widget *widgets.GButt
 			targetPkg = owner.Owner
 		}
 		if targetPkg != nil {
-			if tmpl, err := f.BuildComponent(ctx, targetPkg, item); err == nil && tmpl != nil {
+			if tmpl, err := f.buildTemplate(ctx, targetPkg, item); err == nil && tmpl != nil {
 				widget.SetTemplateComponent(tmpl)
 			}
 		}
@@ -1053,7 +1211,7 @@ func (f *Factory) applyLabelTemplate(ctx context.Context, widget *widgets.GLabel
 			targetPkg = owner.Owner
 		}
 		if targetPkg != nil {
-			if tmpl, err := f.BuildComponent(ctx, targetPkg, item); err == nil && tmpl != nil {
+			if tmpl, err := f.buildTemplate(ctx, targetPkg, item); err == nil && tmpl != nil {
 				widget.SetTemplateComponent(tmpl)
 			}
 		}
@@ -1111,7 +1269,7 @@ func (f *Factory) applyProgressBarTemplate(ctx context.Context, widget *widgets.
 			targetPkg = owner.Owner
 		}
 		if targetPkg != nil {
-			if tmpl, err := f.BuildComponent(ctx, targetPkg, item); err == nil && tmpl != nil {
+			if tmpl, err := f.buildTemplate(ctx, targetPkg, item); err == nil && tmpl != nil {
 				widget.SetTemplateComponent(tmpl)
 			}
 		}
@@ -1150,7 +1308,7 @@ func (f *Factory) applySliderTemplate(ctx context.Context, widget *widgets.GSlid
 			targetPkg = owner.Owner
 		}
 		if targetPkg != nil {
-			if tmpl, err := f.BuildComponent(ctx, targetPkg, item); err == nil && tmpl != nil {
+			if tmpl, err := f.buildTemplate(ctx, targetPkg, item); err == nil && tmpl != nil {
 				widget.SetTemplateComponent(tmpl)
 			}
 		}
@@ -1197,7 +1355,7 @@ func (f *Factory) applyScrollBarTemplate(ctx context.Context, widget *widgets.GS
 			targetPkg = owner.Owner
 		}
 		if targetPkg != nil {
-			if tmpl, err := f.BuildComponent(ctx, targetPkg, item); err == nil && tmpl != nil {
+			if tmpl, err := f.buildTemplate(ctx, targetPkg, item); err == nil && tmpl != nil {
 				widget.SetTemplateComponent(tmpl)
 			}
 		}
@@ -1245,7 +1403,7 @@ func (f *Factory) applyComboBoxTemplate(ctx context.Context, widget *widgets.GCo
 			targetPkg = owner.Owner
 		}
 		if targetPkg != nil {
-			if tmpl, err := f.BuildComponent(ctx, targetPkg, item); err == nil && tmpl != nil {
+			if tmpl, err := f.buildTemplate(ctx, targetPkg, item); err == nil && tmpl != nil {
 				// 关键修复：如果模板是GComboBox，需要传递factory
 				// 否则ConstructExtension中factory为nil，无法创建dropdown
 				if comboBox, ok := tmpl.GObject.Data().(*widgets.GComboBox); ok {
@@ -1528,121 +1686,73 @@ func (f *Factory) finalizeComponentSize(comp *core.GComponent) {
 	}
 }
 
-func (f *Factory) setupRelations(item *assets.PackageItem, comp *core.GComponent) {
-	if item == nil || item.RawData == nil || comp == nil {
+// setupRelations 按模板为组件及其子对象添加关系
+func (f *Factory) setupRelations(tpl *componentTemplate, comp *core.GComponent) {
+	if tpl == nil || comp == nil {
 		return
 	}
-	buf := item.RawData
-	saved := buf.Pos()
-	defer buf.SetPos(saved)
-
-	if buf.Seek(0, 3) {
-		parseRelations(buf, comp.GObject, comp, true)
-	}
-
-	if !buf.Seek(0, 2) {
-		return
-	}
-	buf.Skip(2)
-	childCount := len(comp.Children())
-	for i := 0; i < childCount; i++ {
-		nextPos := int(buf.ReadInt16()) + buf.Pos()
-		current := buf.Pos()
-		if buf.Seek(current, 3) {
-			child := comp.ChildAt(i)
-			parseRelations(buf, child, comp, false)
+	for _, rel := range tpl.relations {
+		owner := comp.GObject
+		if rel.owner >= 0 {
+			owner = comp.ChildAt(rel.owner)
+		}
+		if owner == nil {
+			continue
 		}
-		buf.SetPos(nextPos)
-	}
-}
-
-func parseRelations(buf *utils.ByteBuffer, owner *core.GObject, parent *core.GComponent, parentToChild bool) {
-	if buf == nil || owner == nil {
-		return
-	}
-	cnt := int(buf.ReadByte())
-	for i := 0; i < cnt; i++ {
-		targetIndex := int(buf.ReadInt16())
 		var target *core.GObject
-		if targetIndex == -1 {
+		if rel.target == -1 {
 			if owner.Parent() != nil {
 				target = owner.Parent().GObject
 			}
 		} else {
-			var container *core.GComponent
-			if parentToChild {
-				container = parent
-			} else {
+			container := comp
+			if rel.owner >= 0 {
 				container = owner.Parent()
 			}
 			if container != nil {
-				target = container.ChildAt(targetIndex)
+				target = container.ChildAt(rel.target)
 			}
 		}
-		relCount := int(buf.ReadByte())
-		for j := 0; j < relCount; j++ {
-			relation := core.RelationType(buf.ReadByte())
-			usePercent := buf.ReadBool()
-			if target != nil {
-				owner.AddRelation(target, relation, usePercent)
-			}
+		if target == nil {
+			continue
+		}
+		for _, it := range rel.items {
+			owner.AddRelation(target, it.relation, it.usePercent)
 		}
 	}
 }
 
-func (f *Factory) setupGears(item *assets.PackageItem, comp *core.GComponent) {
-	if item == nil || item.RawData == nil || comp == nil {
+// setupGears 按模板中记录的位置读取每个子对象的 gear，然后执行子对象的 SetupAfterAdd
+func (f *Factory) setupGears(item *assets.PackageItem, tpl *componentTemplate, comp *core.GComponent) {
+	if item == nil || item.RawData == nil || tpl == nil || comp == nil {
 		return
 	}
-	buf := item.RawData
-	saved := buf.Pos()
-	defer buf.SetPos(saved)
-	if !buf.Seek(0, 2) {
-		return
-	}
-	if err := buf.Skip(2); err != nil {
-		return
-	}
-	childCount := len(comp.Children())
-	resolver := newComponentControllerResolver(comp)
-	for i := 0; i < childCount; i++ {
-		nextPos := int(buf.ReadInt16()) + buf.Pos()
-		start := buf.Pos()
+	var resolver gears.ControllerResolver
+	for i := range tpl.children {
+		tc := &tpl.children[i]
 		child := comp.ChildAt(i)
-		length := nextPos - start
-		if child != nil && length > 0 {
-			if sub, err := item.RawData.SubBuffer(start, length); err == nil {
-				setupObjectGears(child, resolver, sub)
-			}
-			child.SetupAfterAdd(comp, item.RawData, start)
+		if child == nil || tc.buf == nil {
+			continue
 		}
-		buf.SetPos(nextPos)
-	}
-}
-
-func setupObjectGears(obj *core.GObject, resolver componentControllerResolver, buf *utils.ByteBuffer) {
-	if obj == nil || buf == nil {
-		return
-	}
-	saved := buf.Pos()
-	defer buf.SetPos(saved)
-	if !buf.Seek(0, 2) {
-		return
-	}
-	gearCount := int(buf.ReadInt16())
-	for i := 0; i < gearCount; i++ {
-		nextPos := int(buf.ReadInt16()) + buf.Pos()
-		index := int(buf.ReadByte())
-		if gear := obj.GetGear(index); gear != nil {
-			gear.Setup(buf, resolver)
-			gear.Apply()
+		if tc.hasGears {
+			for _, g := range tc.gears {
+				gear := child.GetGear(g.index)
+				if gear == nil {
+					continue
+				}
+				if resolver == nil {
+					resolver = newComponentControllerResolver(comp)
+				}
+				tc.buf.SetPos(g.pos)
+				gear.Setup(tc.buf, resolver)
+				gear.Apply()
+			}
+			// 所有 gear 设置完成后，计算 GearDisplay 和 GearDisplay2 的组合可见性
+			// 参考 TypeScript 版本 GComponent.ts constructFromResource2 (1039行)
+			child.CheckGearDisplay()
 		}
-		buf.SetPos(nextPos)
+		child.SetupAfterAdd(comp, item.RawData, item.Component.Children[i].RawDataOffset)
 	}
-
-	// 所有 gear 设置完成后，计算 GearDisplay 和 GearDisplay2 的组合可见性
-	// 参考 TypeScript 版本 GComponent.ts constructFromResource2 (1039行)
-	obj.CheckGearDisplay()
 }
 
 type componentControllerResolver struct {
@@ -1666,7 +1776,7 @@ func (r componentSetupResolver) PixelData(itemID string) *assets.PixelHitTestDat
 		return nil
 	}
 	if pi := r.item.Owner.ItemByID(itemID); pi != nil {
-		return pi.PixelHitTest
+		return pi.HitTestData(core.ContentScaleLevel)
 	}
 	return nil
 }
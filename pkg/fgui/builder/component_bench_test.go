@@ -2,6 +2,7 @@ package builder
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/chslink/fairygui/pkg/fgui/assets"
@@ -93,3 +94,48 @@ func BenchmarkBuildComponent_MultiplePackages(b *testing.B) {
 		}
 	}
 }
+
+// benchmarkDemoComponent 测试演示包中真实组件的构建性能，分别测量使用和不使用组件模板缓存的情况
+func benchmarkDemoComponent(b *testing.B, pkgName, itemName string) {
+	b.Run("Template", func(b *testing.B) { benchmarkDemoBuild(b, pkgName, itemName, false) })
+	b.Run("NoTemplate", func(b *testing.B) { benchmarkDemoBuild(b, pkgName, itemName, true) })
+}
+
+func benchmarkDemoBuild(b *testing.B, pkgName, itemName string, noTemplates bool) {
+	loader := assets.NewFileLoader(filepath.Join("..", "..", "..", "demo", "assets"))
+	factory := NewFactoryWithLoader(nil, loader)
+	factory.noTemplates = noTemplates
+	ctx := context.Background()
+	data, err := loader.LoadOne(ctx, pkgName+".fui", assets.ResourceBinary)
+	if err != nil {
+		b.Skipf("demo assets unavailable: %v", err)
+	}
+	pkg, err := assets.ParsePackage(data, pkgName)
+	if err != nil {
+		b.Fatalf("parse %s: %v", pkgName, err)
+	}
+	factory.RegisterPackage(pkg)
+	item := pkg.ItemByName(itemName)
+	if item == nil {
+		b.Fatalf("%s not found in %s", itemName, pkgName)
+	}
+	if _, err := factory.BuildComponent(ctx, pkg, item); err != nil {
+		b.Fatalf("BuildComponent: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := factory.BuildComponent(ctx, pkg, item); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildComponent_BagWin(b *testing.B) {
+	benchmarkDemoComponent(b, "Bag", "BagWin")
+}
+
+func BenchmarkBuildComponent_VirtualListItem(b *testing.B) {
+	benchmarkDemoComponent(b, "VirtualList", "mailItem")
+}
@@ -0,0 +1,237 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+)
+
+// componentTemplate 是组件资源的构建模板，在资源第一次实例化时生成并缓存。
+//
+// 模板保存从组件数据解析出的、与实例无关的内容：每个子对象引用的资源（包括跨包引用）、
+// 子对象数据块及其中控制器联动（gear）的位置、关系、遮罩与点击区域、动效定义，以及多语言
+// 需要记录的字段。之后的实例直接按模板创建，不再重复查找资源和遍历组件数据；控件、控制器、
+// 关系和动效对象仍为每个实例单独创建，因此得到的对象树与逐项解析时完全一致。
+//
+// 模板按 (构建使用的包, 分支解析后的资源) 缓存；包被卸载或热重载时由 forgetPackage 清除。
+// 子对象的解析结果保存主干资源，每次实例化时再按当前分支取变体，因此切换分支后不必清除模板。
+type componentTemplate struct {
+	children    []childTemplate
+	relations   []relationTemplate
+	display     displayTemplate
+	transitions []core.TransitionTemplate
+
+	// translations 是按 translationGen 时的字符串表收集的可翻译字段，见 translateComponent
+	translations      []translatedField
+	translationGen    uint64
+	translationsReady bool
+}
+
+// childTemplate 是一个子对象的解析结果
+type childTemplate struct {
+	// resolved 是子对象引用的主干资源；解析失败时为 nil，每次实例化都会重新解析，
+	// 以便依赖包稍后加载后能够生效
+	resolved *assets.PackageItem
+	// buf 是子对象的数据块，由所有实例共享，使用者读取前都会先 Seek
+	buf *utils.ByteBuffer
+	// gears 是数据块中各个 gear 的位置；hasGears 为 false 时数据块没有 gear 分段
+	gears    []gearTemplate
+	hasGears bool
+}
+
+type gearTemplate struct {
+	index int // gear 序号，见 gears.IndexDisplay 等
+	pos   int // gear 数据在子对象数据块中的起始位置
+}
+
+// relationTemplate 是一组关系：owner 为子对象下标（-1 表示组件本身），
+// target 为目标子对象下标（-1 表示 owner 的父对象）
+type relationTemplate struct {
+	owner  int
+	target int
+	items  []relationItem
+}
+
+type relationItem struct {
+	relation   core.RelationType
+	usePercent bool
+}
+
+// displayTemplate 是组件数据第 4 段中的 opaque、遮罩和点击区域设置
+type displayTemplate struct {
+	present    bool
+	opaque     bool
+	maskIndex  int
+	reversed   bool
+	hasHitTest bool
+	hitTest    core.HitTest
+}
+
+type templateKey struct {
+	pkg  *assets.Package
+	item *assets.PackageItem
+}
+
+// componentTemplate 返回 item 的构建模板，不存在时解析组件数据并创建。
+// item 必须是分支解析后的组件资源
+func (f *Factory) componentTemplate(ctx context.Context, pkg *assets.Package, item *assets.PackageItem) *componentTemplate {
+	key := templateKey{pkg: pkg, item: item}
+	if tpl := f.templates[key]; tpl != nil {
+		return tpl
+	}
+	tpl := &componentTemplate{children: make([]childTemplate, len(item.Component.Children))}
+	for i := range item.Component.Children {
+		child := &item.Component.Children[i]
+		resolved := f.resolvePackageItem(ctx, pkg, item, child)
+		if resolved != nil && child.Type == assets.ObjectTypeImage {
+			f.resolveSprite(resolved)
+		}
+		tc := &tpl.children[i]
+		tc.resolved = resolved.BranchSource()
+		tc.buf = childBuffer(item, child)
+		tc.gears, tc.hasGears = decodeGears(tc.buf)
+	}
+	tpl.relations = decodeRelations(item)
+	tpl.display = decodeDisplay(item.RawData)
+	tpl.transitions = core.ParseTransitions(item.RawData, 0)
+	if f.noTemplates {
+		return tpl
+	}
+	if f.templates == nil {
+		f.templates = make(map[templateKey]*componentTemplate)
+	}
+	f.templates[key] = tpl
+	return tpl
+}
+
+// resolveChild 返回子对象引用的资源，优先使用模板中的解析结果并按当前分支取变体
+func (f *Factory) resolveChild(ctx context.Context, pkg *assets.Package, owner *assets.PackageItem, child *assets.ComponentChild, tc *childTemplate) *assets.PackageItem {
+	if tc != nil && tc.resolved != nil {
+		item := tc.resolved.Branch()
+		if item != tc.resolved && child.Type == assets.ObjectTypeImage {
+			f.resolveSprite(item)
+		}
+		return item
+	}
+	return f.resolvePackageItem(ctx, pkg, owner, child)
+}
+
+// resolveSprite 让图集管理器准备图片资源的精灵
+func (f *Factory) resolveSprite(pi *assets.PackageItem) {
+	if f.atlasManager != nil {
+		if _, err := f.atlasManager.ResolveSprite(pi); err != nil {
+			fmt.Printf("builder: resolve sprite failed: %v\n", err)
+		}
+	}
+}
+
+// forgetTemplates 清除由 pkg 构建、属于 pkg 或引用了 pkg 中资源的模板
+func (f *Factory) forgetTemplates(pkg *assets.Package) {
+	for key, tpl := range f.templates {
+		if key.pkg == pkg || key.item.Owner == pkg || tpl.references(pkg) {
+			delete(f.templates, key)
+		}
+	}
+}
+
+func (t *componentTemplate) references(pkg *assets.Package) bool {
+	for _, tc := range t.children {
+		if tc.resolved != nil && tc.resolved.Owner == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeGears 记录子对象数据块第 2 段中每个 gear 的序号和数据位置
+func decodeGears(buf *utils.ByteBuffer) ([]gearTemplate, bool) {
+	if buf == nil || !buf.Seek(0, 2) {
+		return nil, false
+	}
+	count := int(buf.ReadInt16())
+	gears := make([]gearTemplate, 0, count)
+	for i := 0; i < count; i++ {
+		nextPos := int(buf.ReadInt16()) + buf.Pos()
+		gears = append(gears, gearTemplate{index: int(buf.ReadByte()), pos: buf.Pos()})
+		buf.SetPos(nextPos)
+	}
+	return gears, true
+}
+
+// decodeRelations 读取组件本身（第 3 段）和各子对象数据块中的关系
+func decodeRelations(item *assets.PackageItem) []relationTemplate {
+	buf := item.RawData
+	if buf == nil {
+		return nil
+	}
+	saved := buf.Pos()
+	defer buf.SetPos(saved)
+
+	var relations []relationTemplate
+	if buf.Seek(0, 3) {
+		relations = readRelations(buf, -1, relations)
+	}
+	if !buf.Seek(0, 2) {
+		return relations
+	}
+	buf.Skip(2)
+	for i := range item.Component.Children {
+		nextPos := int(buf.ReadInt16()) + buf.Pos()
+		if buf.Seek(buf.Pos(), 3) {
+			relations = readRelations(buf, i, relations)
+		}
+		buf.SetPos(nextPos)
+	}
+	return relations
+}
+
+func readRelations(buf *utils.ByteBuffer, owner int, relations []relationTemplate) []relationTemplate {
+	cnt := int(buf.ReadByte())
+	for i := 0; i < cnt; i++ {
+		rel := relationTemplate{owner: owner, target: int(buf.ReadInt16())}
+		rel.items = make([]relationItem, int(buf.ReadByte()))
+		for j := range rel.items {
+			rel.items[j] = relationItem{relation: core.RelationType(buf.ReadByte()), usePercent: buf.ReadBool()}
+		}
+		relations = append(relations, rel)
+	}
+	return relations
+}
+
+// decodeDisplay 读取组件数据第 4 段的 opaque、遮罩和点击区域
+func decodeDisplay(buf *utils.ByteBuffer) displayTemplate {
+	var d displayTemplate
+	if buf == nil {
+		return d
+	}
+	saved := buf.Pos()
+	defer buf.SetPos(saved)
+	if !buf.Seek(0, 4) {
+		return d
+	}
+	if err := buf.Skip(2); err != nil || buf.Remaining() < 1+2 {
+		return d
+	}
+	d.present = true
+	d.opaque = buf.ReadBool()
+	d.maskIndex = int(buf.ReadInt16())
+	if d.maskIndex >= 0 && buf.Remaining() > 0 {
+		d.reversed = buf.ReadBool()
+	}
+	if buf.Remaining() >= 4+4 {
+		hitID := buf.ReadS()
+		offsetX := int(buf.ReadInt32())
+		offsetY := int(buf.ReadInt32())
+		d.hasHitTest = true
+		d.hitTest = core.HitTest{Mode: core.HitTestModeNone}
+		if hitID != nil && *hitID != "" {
+			d.hitTest = core.HitTest{Mode: core.HitTestModePixel, ItemID: *hitID, OffsetX: offsetX, OffsetY: offsetY}
+		} else if offsetX != 0 && offsetY != -1 {
+			d.hitTest = core.HitTest{Mode: core.HitTestModeChild, OffsetX: offsetX, ChildIndex: offsetY}
+		}
+	}
+	return d
+}
@@ -0,0 +1,149 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+func TestComponentTemplateMatchesUncachedBuild(t *testing.T) {
+	cases := []struct{ pkg, item string }{
+		{"Bag", "Main"},
+		{"Bag", "BagWin"},
+		{"VirtualList", "mailItem"},
+		{"Basics", "Main"},
+		{"Basics", "Demo_Clip&Scroll"},
+		{"Transition", "BOSS_SKILL"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.pkg+"/"+tc.item, func(t *testing.T) {
+			factory, pkg := addDemoPackage(t, tc.pkg)
+			item := pkg.ItemByName(tc.item)
+			if item == nil {
+				t.Skipf("%s package missing %s", tc.pkg, tc.item)
+			}
+			ctx := context.Background()
+
+			factory.noTemplates = true
+			want, err := factory.BuildComponent(ctx, pkg, item)
+			if err != nil {
+				t.Fatalf("BuildComponent without templates: %v", err)
+			}
+			if len(factory.templates) != 0 {
+				t.Fatalf("expected no templates to be cached")
+			}
+
+			factory.noTemplates = false
+			// 第一次构建生成模板，第二次从模板克隆
+			for i := 0; i < 2; i++ {
+				got, err := factory.BuildComponent(ctx, pkg, item)
+				if err != nil {
+					t.Fatalf("BuildComponent #%d: %v", i+1, err)
+				}
+				if diff := describeTree(got.GObject); diff != describeTree(want.GObject) {
+					t.Fatalf("build #%d differs from uncached build:\n%s\nwant:\n%s", i+1, diff, describeTree(want.GObject))
+				}
+				if diff := describeSetup(got.GObject); diff != describeSetup(want.GObject) {
+					t.Fatalf("build #%d setup differs from uncached build:\n%s\nwant:\n%s", i+1, diff, describeSetup(want.GObject))
+				}
+			}
+			if factory.templates[templateKey{pkg: pkg, item: item.Branch()}] == nil {
+				t.Fatalf("expected a template for %s", tc.item)
+			}
+		})
+	}
+}
+
+// describeSetup 描述模板中解析的关系、遮罩、点击区域和动效
+func describeSetup(obj *core.GObject) string {
+	var sb strings.Builder
+	var walk func(obj *core.GObject, depth int)
+	walk = func(obj *core.GObject, depth int) {
+		indent := strings.Repeat("  ", depth)
+		fmt.Fprintf(&sb, "%s%s\n", indent, obj.Name())
+		for _, rel := range obj.Relations().Items() {
+			fmt.Fprintf(&sb, "%s  relation -> %s\n", indent, rel.Target().Name())
+		}
+		comp := core.ComponentFrom(obj)
+		if comp == nil {
+			return
+		}
+		mask, reversed := comp.Mask()
+		fmt.Fprintf(&sb, "%s  opaque=%v mask=%v/%v hit=%+v\n", indent, comp.Opaque(), mask != nil, reversed, comp.HitTest())
+		for _, tr := range comp.Transitions() {
+			fmt.Fprintf(&sb, "%s  transition %s autoPlay=%v %.2fs:", indent, tr.Name, tr.AutoPlay, tr.TotalDuration)
+			for _, it := range tr.Items {
+				fmt.Fprintf(&sb, " %s@%.2f", it.TargetID, it.Time)
+			}
+			sb.WriteString("\n")
+		}
+		for _, child := range comp.Children() {
+			walk(child, depth+1)
+		}
+	}
+	walk(obj, 0)
+	return sb.String()
+}
+
+func TestComponentTemplateForgottenWithPackage(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "Bag")
+	item := pkg.ItemByName("Main")
+	if item == nil {
+		t.Skip("Bag package missing Main")
+	}
+	if _, err := factory.BuildComponent(context.Background(), pkg, item); err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	if len(factory.templates) == 0 {
+		t.Fatalf("expected templates to be cached")
+	}
+	factory.forgetPackage(pkg)
+	if len(factory.templates) != 0 {
+		t.Fatalf("expected templates of a forgotten package to be dropped, %d left", len(factory.templates))
+	}
+}
+
+func TestComponentTemplateFollowsBranch(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "Basics")
+	item := pkg.ItemByName("Button52")
+	bg := pkg.ItemByID("rpmb1")
+	if item == nil || bg == nil || pkg.ItemByID("a7vt7m") == nil {
+		t.Skip("Basics package missing Button52 or its images")
+	}
+	// 演示包没有分支，这里给背景图添加 en 分支的变体
+	pkg.Branches = []string{"en"}
+	bg.Branches = []string{"a7vt7m"}
+	t.Cleanup(func() {
+		assets.SetBranch("")
+		pkg.Branches, bg.Branches = nil, nil
+	})
+	bgOf := func() string {
+		t.Helper()
+		comp, err := factory.BuildComponent(context.Background(), pkg, item)
+		if err != nil {
+			t.Fatalf("BuildComponent: %v", err)
+		}
+		img, err := core.GetChildAs[*widgets.GImage](comp, "bg")
+		if err != nil {
+			t.Fatalf("bg: %v", err)
+		}
+		return img.PackageItem().ID
+	}
+
+	if got := bgOf(); got != "rpmb1" {
+		t.Fatalf("expected trunk bg rpmb1, got %s", got)
+	}
+	assets.SetBranch("en")
+	if got := bgOf(); got != "a7vt7m" {
+		t.Fatalf("expected en bg a7vt7m after switching branch, got %s", got)
+	}
+	assets.SetBranch("")
+	if got := bgOf(); got != "rpmb1" {
+		t.Fatalf("expected trunk bg rpmb1 after switching back, got %s", got)
+	}
+}
@@ -2,11 +2,13 @@ package builder
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/chslink/fairygui/pkg/fgui/assets"
 	"github.com/chslink/fairygui/pkg/fgui/core"
@@ -872,3 +874,51 @@ func computePivotOffset(width, height float64, pivotX, pivotY float64, rotation,
 	transformedY := b*px + d*py
 	return px - transformedX, py - transformedY
 }
+
+// 引用其它包的组件时，其子对象在组件自身所在的包中解析，同步和异步构建结果相同
+func TestBuildNestedComponentFromAnotherPackage(t *testing.T) {
+	factory, bag := addDemoPackage(t, "Bag")
+	win := bag.ItemByName("BagWin")
+	if win == nil {
+		t.Skip("Bag package missing BagWin")
+	}
+	ctx := context.Background()
+	want, err := factory.BuildComponent(ctx, bag, win)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	want.SetName("win")
+
+	// 宿主包的条目 ID 与 Bag 中的子对象引用相同，按宿主包解析会找到错误的资源
+	xml := fmt.Sprintf(`<component size="800,600"><displayList><component id="n0" name="win" src="%s" pkg="%s" xy="0,0" size="%d,%d"/></displayList></component>`,
+		win.ID, bag.ID, win.Width, win.Height)
+	sources := []assets.ComponentSource{{ID: "host", Name: "Host", Exported: true, XML: []byte(xml)}}
+	for _, child := range win.Component.Children {
+		if child.Src != "" && bag.ItemByID(child.Src) != nil {
+			sources = append(sources, assets.ComponentSource{ID: child.Src, Name: "Decoy" + child.Src, XML: []byte(`<component size="1,1"/>`)})
+		}
+	}
+	host, err := assets.CompileComponents("nested01", "NestedHost", sources)
+	if err != nil {
+		t.Fatalf("CompileComponents: %v", err)
+	}
+	item := host.ItemByID("host")
+
+	comp, err := factory.BuildComponent(ctx, host, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	if got := describeTree(comp.GetChild("win")); got != describeTree(want.GObject) {
+		t.Fatalf("nested component differs:\n%s\nwant:\n%s", got, describeTree(want.GObject))
+	}
+
+	op := factory.BuildComponentAsync(ctx, host, item, nil)
+	runAsync(t, op, time.Millisecond)
+	comp, err = op.Result()
+	if err != nil {
+		t.Fatalf("async build: %v", err)
+	}
+	if got := describeTree(comp.GetChild("win")); got != describeTree(want.GObject) {
+		t.Fatalf("async nested component differs:\n%s\nwant:\n%s", got, describeTree(want.GObject))
+	}
+}
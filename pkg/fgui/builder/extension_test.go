@@ -0,0 +1,167 @@
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/utils"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+// bagButton 是内嵌 GButton 的自定义扩展，记录构建钩子的调用
+type bagButton struct {
+	*widgets.GButton
+	constructed int
+	added       int
+}
+
+func (b *bagButton) ConstructExtension(buf *utils.ByteBuffer) error {
+	b.constructed++
+	return b.GButton.ConstructExtension(buf)
+}
+
+func (b *bagButton) SetupAfterAdd(ctx *widgets.SetupContext, buf *utils.ByteBuffer) {
+	b.added++
+	b.GButton.SetupAfterAdd(ctx, buf)
+}
+
+// plainPanel 是内嵌普通组件的自定义扩展
+type plainPanel struct {
+	*core.GComponent
+	constructed int
+	added       int
+}
+
+func (p *plainPanel) ConstructExtension(buf *utils.ByteBuffer) error {
+	p.constructed++
+	return nil
+}
+
+func (p *plainPanel) SetupAfterAdd(ctx *widgets.SetupContext, buf *utils.ByteBuffer) {
+	p.added++
+}
+
+// registerExtension 注册扩展并在测试结束时移除，返回构造次数计数器
+func registerExtension(t *testing.T, url string, fn func() any) *int {
+	t.Helper()
+	count := new(int)
+	if err := widgets.SetExtension(url, func() any {
+		*count++
+		return fn()
+	}); err != nil {
+		t.Fatalf("SetExtension(%q): %v", url, err)
+	}
+	t.Cleanup(func() { widgets.RemoveExtension(url) })
+	return count
+}
+
+func addDemoPackage(t *testing.T, name string) (*Factory, *assets.Package) {
+	t.Helper()
+	loader := demoPackageLoader(t)
+	factory := NewFactoryWithLoader(nil, loader)
+	pkg, err := NewPackageManager(factory, loader).AddPackage(context.Background(), name)
+	if err != nil {
+		t.Fatalf("AddPackage %s: %v", name, err)
+	}
+	return factory, pkg
+}
+
+func TestExtensionRootAndChild(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "Bag")
+	main := pkg.ItemByName("Main")
+	button := pkg.ItemByName("BagButton")
+	if main == nil || button == nil {
+		t.Skip("Bag package missing Main or BagButton")
+	}
+	mains := registerExtension(t, "Bag/Main", func() any { return &plainPanel{GComponent: core.NewGComponent()} })
+	buttons := registerExtension(t, "ui://"+pkg.ID+button.ID, func() any { return &bagButton{GButton: widgets.NewButton()} })
+
+	comp, err := factory.BuildComponent(context.Background(), pkg, main)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	panel, ok := widgets.ExtensionOf(comp.GObject).(*plainPanel)
+	if !ok || panel.GComponent != comp {
+		t.Fatalf("expected root to be created by the extension, got %T", widgets.ExtensionOf(comp.GObject))
+	}
+	if *mains != 1 || panel.constructed != 1 || panel.added != 0 {
+		t.Fatalf("unexpected root hooks: created=%d constructed=%d added=%d", *mains, panel.constructed, panel.added)
+	}
+
+	child := comp.ChildByName("bagBtn")
+	cell, ok := widgets.ExtensionOf(child).(*bagButton)
+	if !ok {
+		t.Fatalf("expected bagBtn to be created by the extension, got %T", widgets.ExtensionOf(child))
+	}
+	if *buttons != 1 || cell.constructed != 1 || cell.added != 1 {
+		t.Fatalf("unexpected child hooks: created=%d constructed=%d added=%d", *buttons, cell.constructed, cell.added)
+	}
+	if child.Data() != cell.GButton || cell.GComponent.GObject != child {
+		t.Fatalf("expected the embedded GButton to back the child object")
+	}
+	if cell.TemplateComponent() == nil && cell.GButton.NumChildren() == 0 {
+		t.Fatalf("expected button template to be applied to the extension")
+	}
+}
+
+func TestExtensionNestedComponent(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "ScrollPane")
+	main := pkg.ItemByName("Main")
+	if main == nil || pkg.ItemByName("Box") == nil {
+		t.Skip("ScrollPane package missing Main or Box")
+	}
+	boxes := registerExtension(t, "ui://ScrollPane/Box", func() any { return &plainPanel{GComponent: core.NewGComponent()} })
+
+	comp, err := factory.BuildComponent(context.Background(), pkg, main)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	box := comp.ChildByName("box")
+	panel, ok := widgets.ExtensionOf(box).(*plainPanel)
+	if !ok || panel.GObject != box {
+		t.Fatalf("expected nested box to be created by the extension, got %T", widgets.ExtensionOf(box))
+	}
+	if *boxes != 1 || panel.constructed != 1 || panel.added != 1 {
+		t.Fatalf("unexpected nested hooks: created=%d constructed=%d added=%d", *boxes, panel.constructed, panel.added)
+	}
+	if panel.NumChildren() == 0 {
+		t.Fatalf("expected nested component children to be built")
+	}
+}
+
+func TestExtensionObjectCreator(t *testing.T) {
+	factory, pkg := addDemoPackage(t, "VirtualList")
+	item := pkg.ItemByName("mailItem")
+	if item == nil {
+		t.Skip("VirtualList package missing mailItem")
+	}
+	registerExtension(t, "ui://VirtualList/mailItem", func() any { return &bagButton{GButton: widgets.NewButton()} })
+
+	creator := &FactoryObjectCreator{factory: factory, pkg: pkg, ctx: context.Background()}
+	obj := creator.CreateObject("ui://" + pkg.ID + item.ID)
+	if obj == nil {
+		t.Fatalf("CreateObject returned nil")
+	}
+	cell, ok := widgets.ExtensionOf(obj).(*bagButton)
+	if !ok || cell.constructed != 1 {
+		t.Fatalf("expected list item to be created by the extension, got %T", widgets.ExtensionOf(obj))
+	}
+	if _, ok := obj.Data().(*widgets.GButton); !ok {
+		t.Fatalf("expected object data to stay the built-in GButton, got %T", obj.Data())
+	}
+
+	widgets.RemoveExtension("ui://VirtualList/mailItem")
+	if obj := creator.CreateObject("ui://" + pkg.ID + item.ID); obj == nil || widgets.ExtensionOf(obj) != nil {
+		t.Fatalf("expected removed extension to no longer apply")
+	}
+}
+
+func TestSetExtensionRejectsInvalidURL(t *testing.T) {
+	for _, url := range []string{"", "ui://", "ui://short", "Bag/"} {
+		if err := widgets.SetExtension(url, func() any { return nil }); err == nil {
+			t.Errorf("expected error for %q", url)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"weak"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// DefaultHotReloadInterval 是 HotReloader 默认的轮询间隔
+const DefaultHotReloadInterval = 500 * time.Millisecond
+
+// HotReloadOptions 配置 HotReloader
+type HotReloadOptions struct {
+	// FS 用于查询文件修改时间，键与 loader 的资源键一致。
+	// 为空时根据 loader 推导：FileLoader 使用 os.DirFS(Root)，FSLoader 使用其 FS 的 Root 子目录。
+	FS fs.FS
+	// Interval 是 Update 两次轮询之间的最小间隔，默认 DefaultHotReloadInterval
+	Interval time.Duration
+	// OnReload 在每个包重新加载并重建组件后调用，未通过 Track 登记的组件可在这里自行重建
+	OnReload func(*ReloadEvent)
+}
+
+// ReloadEvent 描述一次包的热重载
+type ReloadEvent struct {
+	Old   *assets.Package
+	New   *assets.Package // 重新解析或加载失败时为 nil，旧包保持可用
+	Files []string        // 变化的文件（.fui 及图集）
+	// Rebuilt 列出已替换的顶层组件
+	Rebuilt []ReloadedComponent
+	// Err 记录解析、加载或重建时遇到的错误
+	Err error
+}
+
+// ReloadedComponent 记录一个被重建的顶层组件
+type ReloadedComponent struct {
+	Old *core.GComponent // 已从父对象移除并释放（Dispose）
+	New *core.GComponent
+}
+
+// HotReloader 以轮询方式监视包文件，发布后自动重新解析包、替换全局注册表中的包、
+// 清除图集缓存，并重建通过 Track 登记的顶层组件（保留控制器所选页面）。
+// 只用于开发环境，所有操作在调用 Update/Check 的线程（通常是游戏循环）中完成。
+type HotReloader struct {
+	factory  *Factory
+	loader   assets.Loader
+	fsys     fs.FS
+	root     string
+	interval time.Duration
+	onReload func(*ReloadEvent)
+
+	packages   []*watchedPackage
+	components []*trackedComponent
+	lastPoll   time.Time
+	now        func() time.Time
+}
+
+type watchedPackage struct {
+	pkg   *assets.Package
+	files map[string]fileStamp
+	// changed 记录上次轮询发现变化的文件；文件在下一次轮询时不再变化才重新加载，
+	// 避免读到编辑器正在写入的文件
+	changed map[string]fileStamp
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+type trackedComponent struct {
+	comp weak.Pointer[core.GComponent]
+	item *assets.PackageItem
+}
+
+// NewHotReloader 创建监视 factory 所用包的热重载器。loader 为空时使用 factory 的 loader。
+func NewHotReloader(factory *Factory, loader assets.Loader, opts HotReloadOptions) (*HotReloader, error) {
+	if factory == nil {
+		return nil, errors.New("builder: hot reload requires a factory")
+	}
+	if loader == nil {
+		loader = factory.loader
+	}
+	if loader == nil {
+		return nil, errors.New("builder: hot reload requires a loader")
+	}
+	h := &HotReloader{
+		factory:  factory,
+		loader:   loader,
+		fsys:     opts.FS,
+		interval: opts.Interval,
+		onReload: opts.OnReload,
+		now:      time.Now,
+	}
+	if h.interval <= 0 {
+		h.interval = DefaultHotReloadInterval
+	}
+	if h.fsys == nil {
+		switch l := loader.(type) {
+		case *assets.FileLoader:
+			h.root = filepath.ToSlash(filepath.Clean(l.Root))
+			h.fsys = os.DirFS(l.Root)
+		case *assets.FSLoader:
+			root := path.Clean("/" + filepath.ToSlash(l.Root))[1:]
+			if root == "" {
+				h.fsys = l.FS
+			} else {
+				sub, err := fs.Sub(l.FS, root)
+				if err != nil {
+					return nil, err
+				}
+				h.fsys = sub
+			}
+		default:
+			return nil, errors.New("builder: hot reload needs HotReloadOptions.FS for this loader")
+		}
+	}
+	return h, nil
+}
+
+// Watch 开始监视包的 .fui 文件及图集文件。包通常来自 PackageManager.AddPackage
+// 或 ParsePackage，ResKey 为相对于 loader 根目录的路径。
+func (h *HotReloader) Watch(pkgs ...*assets.Package) {
+	for _, pkg := range pkgs {
+		if pkg == nil || h.watched(pkg) != nil {
+			continue
+		}
+		w := &watchedPackage{pkg: pkg}
+		w.files = h.stampFiles(pkg)
+		h.packages = append(h.packages, w)
+	}
+}
+
+// Unwatch 停止监视包
+func (h *HotReloader) Unwatch(pkg *assets.Package) {
+	h.packages = slices.DeleteFunc(h.packages, func(w *watchedPackage) bool { return w.pkg == pkg })
+}
+
+// Track 登记一个由 item 构建的顶层组件。包重新加载后组件会被重建，
+// 并在原父对象的相同位置替换旧组件。组件被回收后自动停止跟踪。
+func (h *HotReloader) Track(comp *core.GComponent, item *assets.PackageItem) {
+	if comp == nil || item == nil {
+		return
+	}
+	h.components = append(h.components, &trackedComponent{comp: weak.Make(comp), item: item.BranchSource()})
+}
+
+// Update 在距上次轮询超过间隔时检查文件变化，适合在游戏循环的每帧调用
+func (h *HotReloader) Update(ctx context.Context) []*ReloadEvent {
+	now := h.now()
+	if now.Sub(h.lastPoll) < h.interval {
+		return nil
+	}
+	return h.Check(ctx)
+}
+
+// Check 立即检查所有被监视的包，重新加载文件已稳定的变化并返回重载事件
+func (h *HotReloader) Check(ctx context.Context) []*ReloadEvent {
+	h.lastPoll = h.now()
+	var events []*ReloadEvent
+	// 重载可能替换 h.packages 中的条目，遍历副本
+	for _, w := range slices.Clone(h.packages) {
+		changed := make(map[string]fileStamp)
+		stable := true
+		for name, stamp := range h.stampFiles(w.pkg) {
+			if stamp == w.files[name] {
+				continue
+			}
+			changed[name] = stamp
+			if prev, ok := w.changed[name]; !ok || prev != stamp {
+				stable = false
+			}
+		}
+		w.changed = changed
+		if len(changed) == 0 || !stable {
+			continue
+		}
+		files := slices.Sorted(maps.Keys(changed))
+		w.changed = nil
+		event := h.reload(ctx, w, files)
+		events = append(events, event)
+		if h.onReload != nil {
+			h.onReload(event)
+		}
+	}
+	return events
+}
+
+func (h *HotReloader) reload(ctx context.Context, w *watchedPackage, files []string) *ReloadEvent {
+	old := w.pkg
+	// 成功后才记录新的时间戳，失败时文件仍视为已变化，下一次轮询到文件稳定后重试
+	event := &ReloadEvent{Old: old, Files: files}
+
+	data, err := h.loader.LoadOne(ctx, old.ResKey+".fui", assets.ResourceBinary)
+	if err != nil {
+		event.Err = err
+		return event
+	}
+	pkg, err := assets.ParsePackage(data, old.ResKey)
+	if err != nil {
+		event.Err = err
+		return event
+	}
+
+	f := h.factory
+	// 先加载新包的图集，成功后才移除旧包，加载失败时旧包保持可用
+	reloader, staged := f.atlasManager.(PackageReloader)
+	if staged {
+		if err := reloader.ReloadPackage(ctx, old, pkg); err != nil {
+			event.Err = err
+			return event
+		}
+	}
+	f.forgetPackage(old)
+	if unloader, ok := f.atlasManager.(PackageUnloader); ok && !staged {
+		unloader.UnloadPackage(old)
+	}
+	assets.UnloadPackage(old)
+	if f.packages != nil {
+		f.packages.replacePackage(old, pkg)
+	}
+	if err := f.ensurePackageReady(ctx, pkg); err != nil {
+		event.Err = err
+		return event
+	}
+	event.New = pkg
+	w.pkg = pkg
+	w.files = h.stampFiles(pkg)
+
+	var errs []error
+	for _, tc := range h.components {
+		oldComp := tc.comp.Value()
+		if oldComp == nil || !usesPackage(tc.item.Owner, old) {
+			continue
+		}
+		item := tc.item
+		if item.Owner == old {
+			item = pkg.ItemByID(item.ID)
+			if item == nil {
+				errs = append(errs, errors.New("builder: "+tc.item.Name+" was removed from package "+pkg.Name))
+				continue
+			}
+			item = item.BranchSource()
+		}
+		newComp, err := f.BuildComponent(ctx, item.Owner, item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		replaceComponent(oldComp, newComp, tc.item)
+		if f.packages != nil {
+			f.packages.ReleaseComponent(oldComp)
+		}
+		oldComp.Dispose()
+		tc.comp = weak.Make(newComp)
+		tc.item = item
+		event.Rebuilt = append(event.Rebuilt, ReloadedComponent{Old: oldComp, New: newComp})
+	}
+	h.components = slices.DeleteFunc(h.components, func(tc *trackedComponent) bool { return tc.comp.Value() == nil })
+	event.Err = errors.Join(errs...)
+	return event
+}
+
+// usesPackage 判断 owner 是否为 pkg 本身或声明了对 pkg 的依赖
+func usesPackage(owner, pkg *assets.Package) bool {
+	if owner == pkg {
+		return true
+	}
+	if owner == nil {
+		return false
+	}
+	for _, dep := range owner.Dependencies {
+		if dep.ID == pkg.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceComponent 在旧组件的父对象中以相同位置替换为新组件，并保留控制器所选页面。
+// 旧组件尺寸与其资源初始尺寸不同（例如被设为全屏）时保留该尺寸。
+func replaceComponent(oldComp, newComp *core.GComponent, oldItem *assets.PackageItem) {
+	newComp.SetPosition(oldComp.X(), oldComp.Y())
+	if cd := oldItem.Component; cd != nil && (oldComp.Width() != float64(cd.InitWidth) || oldComp.Height() != float64(cd.InitHeight)) {
+		newComp.SetSize(oldComp.Width(), oldComp.Height())
+	}
+	copyControllerPages(oldComp, newComp)
+	if parent := oldComp.Parent(); parent != nil {
+		index := slices.Index(parent.Children(), oldComp.GObject)
+		parent.RemoveChild(oldComp.GObject)
+		parent.AddChildAt(newComp.GObject, index)
+	}
+}
+
+// copyControllerPages 按名称匹配控制器和子组件，页面 ID 仍存在时恢复所选页面
+func copyControllerPages(oldComp, newComp *core.GComponent) {
+	if oldComp == nil || newComp == nil {
+		return
+	}
+	for _, ctrl := range newComp.Controllers() {
+		prev := oldComp.ControllerByName(ctrl.Name)
+		if prev == nil {
+			continue
+		}
+		if id := prev.SelectedPageID(); id != "" && slices.Contains(ctrl.PageIDs, id) && id != ctrl.SelectedPageID() {
+			ctrl.SetSelectedPageID(id)
+		}
+	}
+	for _, child := range newComp.Children() {
+		comp := core.ComponentFrom(child)
+		if comp == nil || child.Name() == "" {
+			continue
+		}
+		if prev := oldComp.ChildByName(child.Name()); prev != nil {
+			copyControllerPages(core.ComponentFrom(prev), comp)
+		}
+	}
+}
+
+func (h *HotReloader) watched(pkg *assets.Package) *watchedPackage {
+	for _, w := range h.packages {
+		if w.pkg == pkg {
+			return w
+		}
+	}
+	return nil
+}
+
+// stampFiles 返回包的 .fui 及图集文件的修改时间和大小
+func (h *HotReloader) stampFiles(pkg *assets.Package) map[string]fileStamp {
+	files := make(map[string]fileStamp)
+	add := func(key string) {
+		name := h.fsName(key)
+		stamp := fileStamp{}
+		if info, err := fs.Stat(h.fsys, name); err == nil {
+			stamp = fileStamp{modTime: info.ModTime(), size: info.Size(), exists: true}
+		}
+		files[name] = stamp
+	}
+	add(pkg.ResKey + ".fui")
+	for _, item := range pkg.Items {
+		if item.Type == assets.PackageItemTypeAtlas && item.File != "" && item.RawData == nil {
+			add(item.File)
+		}
+	}
+	return files
+}
+
+// fsName 将 loader 的资源键转换为 fs.FS 中的路径：与 FileLoader.LoadOne 一样，已包含根目录的键
+// 去掉根目录，根目录按完整的路径段匹配（根目录为 assets 时 assets2/x.fui 保持不变）
+func (h *HotReloader) fsName(key string) string {
+	name := filepath.ToSlash(filepath.Clean(key))
+	if h.root != "" && h.root != "." && (name == h.root || strings.HasPrefix(name, h.root+"/")) {
+		name = strings.TrimPrefix(name, h.root)
+	}
+	return strings.TrimPrefix(name, "/")
+}
@@ -0,0 +1,300 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/testutil"
+)
+
+// copyDemoPackage 将演示包及其图集复制到临时目录，供热重载测试修改
+func copyDemoPackage(t *testing.T, name string) string {
+	t.Helper()
+	src := filepath.Join("..", "..", "..", "demo", "assets")
+	files, _ := filepath.Glob(filepath.Join(src, name+"*"))
+	if len(files) == 0 {
+		t.Skipf("demo assets unavailable: %s", name)
+	}
+	dir := t.TempDir()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(file)), data, 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	return dir
+}
+
+// publish 写入新的包数据，并把修改时间推后以确保轮询能发现变化
+func publish(t *testing.T, path string, data []byte, stamp time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, stamp, stamp); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+}
+
+func TestHotReloaderRebuildsTrackedComponents(t *testing.T) {
+	dir := copyDemoPackage(t, "Basics")
+	loader := assets.NewFileLoader(dir)
+	recorder := &unloadRecorder{}
+	factory := NewFactoryWithLoader(recorder, loader)
+	mgr := NewPackageManager(factory, loader)
+	ctx := context.Background()
+
+	pkg, err := mgr.AddPackage(ctx, "Basics")
+	if err != nil {
+		t.Fatalf("AddPackage: %v", err)
+	}
+	var item *assets.PackageItem
+	for _, it := range pkg.Items {
+		if it.Type == assets.PackageItemTypeComponent && it.Component != nil && len(it.Component.Controllers) > 0 &&
+			len(it.Component.Controllers[0].PageIDs) > 1 {
+			item = it
+			break
+		}
+	}
+	if item == nil {
+		t.Skip("no component with a multi-page controller")
+	}
+	comp, err := factory.BuildComponent(ctx, pkg, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	ctrl := comp.Controllers()[0]
+	ctrl.SetSelectedIndex(1)
+	parent := core.NewGComponent()
+	parent.AddChild(core.NewGObject())
+	parent.AddChild(comp.GObject)
+	comp.SetPosition(12, 34)
+
+	var notified []*ReloadEvent
+	reloader, err := NewHotReloader(factory, nil, HotReloadOptions{OnReload: func(e *ReloadEvent) { notified = append(notified, e) }})
+	if err != nil {
+		t.Fatalf("NewHotReloader: %v", err)
+	}
+	reloader.Watch(pkg)
+	reloader.Track(comp, item)
+
+	if events := reloader.Check(ctx); len(events) != 0 {
+		t.Fatalf("expected no reload without changes, got %d", len(events))
+	}
+
+	pkg.ReplaceString(pkg.StringTable[0], "hot reloaded")
+	data, err := assets.WritePackage(pkg, assets.WriteOptions{})
+	if err != nil {
+		t.Fatalf("WritePackage: %v", err)
+	}
+	publish(t, filepath.Join(dir, "Basics.fui"), data, time.Now().Add(time.Hour))
+
+	if events := reloader.Check(ctx); len(events) != 0 {
+		t.Fatalf("expected reload to wait until the file is stable, got %d events", len(events))
+	}
+	events := reloader.Check(ctx)
+	if len(events) != 1 || len(notified) != 1 {
+		t.Fatalf("expected one reload event, got %d (notified %d)", len(events), len(notified))
+	}
+	event := events[0]
+	if event.Err != nil {
+		t.Fatalf("reload error: %v", event.Err)
+	}
+	if event.New == nil || event.New == pkg || event.New.StringTable[0] != "hot reloaded" {
+		t.Fatalf("expected the republished package to be parsed")
+	}
+	if assets.GetPackageByID(pkg.ID) != event.New || mgr.Package("Basics") != event.New {
+		t.Fatalf("expected registry and manager to hold the reloaded package")
+	}
+	if !slices.Contains(recorder.unloaded, "Basics") {
+		t.Fatalf("expected atlas caches of the old package to be evicted, got %v", recorder.unloaded)
+	}
+	if len(event.Rebuilt) != 1 || event.Rebuilt[0].Old != comp {
+		t.Fatalf("expected tracked component to be rebuilt, got %+v", event.Rebuilt)
+	}
+	rebuilt := event.Rebuilt[0].New
+	if parent.ChildAt(1) != rebuilt.GObject || comp.Parent() != nil {
+		t.Fatalf("expected rebuilt component to replace the old one in its parent")
+	}
+	if rebuilt.X() != 12 || rebuilt.Y() != 34 {
+		t.Fatalf("expected position to be kept, got %.0f,%.0f", rebuilt.X(), rebuilt.Y())
+	}
+	if got := rebuilt.Controllers()[0].SelectedIndex(); got != 1 {
+		t.Fatalf("expected controller page to be kept, got %d", got)
+	}
+}
+
+func TestHotReloaderKeepsPackageOnParseError(t *testing.T) {
+	dir := copyDemoPackage(t, "Bag")
+	loader := assets.NewFileLoader(dir)
+	factory := NewFactoryWithLoader(&unloadRecorder{}, loader)
+	ctx := context.Background()
+
+	data, err := loader.LoadOne(ctx, "Bag.fui", assets.ResourceBinary)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	pkg, err := assets.ParsePackage(data, "Bag")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	factory.RegisterPackage(pkg)
+
+	reloader, err := NewHotReloader(factory, loader, HotReloadOptions{Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("NewHotReloader: %v", err)
+	}
+	reloader.Watch(pkg)
+	publish(t, filepath.Join(dir, "Bag.fui"), []byte("partial"), time.Now().Add(time.Hour))
+
+	reloader.Check(ctx)
+	events := reloader.Check(ctx)
+	if len(events) != 1 || events[0].Err == nil || events[0].New != nil {
+		t.Fatalf("expected a failed reload event, got %+v", events)
+	}
+	if assets.GetPackageByID(pkg.ID) != pkg {
+		t.Fatalf("expected the old package to stay registered")
+	}
+	if events := reloader.Update(ctx); events != nil {
+		t.Fatalf("expected Update to respect the polling interval")
+	}
+	if events := reloader.Check(ctx); len(events) != 0 {
+		t.Fatalf("expected the retry to wait until the file is stable, got %d", len(events))
+	}
+	if events := reloader.Check(ctx); len(events) != 1 || events[0].Err == nil {
+		t.Fatalf("expected the failed reload to be retried, got %+v", events)
+	}
+}
+
+// stagedReloader 是实现 PackageReloader 的 AtlasResolver，err 不为空时加载新包失败
+type stagedReloader struct {
+	unloadRecorder
+	err      error
+	reloaded []string
+}
+
+func (r *stagedReloader) ReloadPackage(ctx context.Context, old, pkg *assets.Package) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.reloaded = append(r.reloaded, pkg.Name)
+	return nil
+}
+
+func TestHotReloaderKeepsPackageWhenPrepareFails(t *testing.T) {
+	dir := copyDemoPackage(t, "Bag")
+	loader := assets.NewFileLoader(dir)
+	atlases := &stagedReloader{err: errors.New("atlas not published yet")}
+	factory := NewFactoryWithLoader(atlases, loader)
+	mgr := NewPackageManager(factory, loader)
+	ctx := context.Background()
+
+	pkg, err := mgr.AddPackage(ctx, "Bag")
+	if err != nil {
+		t.Fatalf("AddPackage: %v", err)
+	}
+	reloader, err := NewHotReloader(factory, nil, HotReloadOptions{})
+	if err != nil {
+		t.Fatalf("NewHotReloader: %v", err)
+	}
+	reloader.Watch(pkg)
+
+	data, err := assets.WritePackage(pkg, assets.WriteOptions{})
+	if err != nil {
+		t.Fatalf("WritePackage: %v", err)
+	}
+	publish(t, filepath.Join(dir, "Bag.fui"), data, time.Now().Add(time.Hour))
+
+	reloader.Check(ctx)
+	events := reloader.Check(ctx)
+	if len(events) != 1 || !errors.Is(events[0].Err, atlases.err) || events[0].New != nil {
+		t.Fatalf("expected a failed reload event, got %+v", events)
+	}
+	if assets.GetPackageByID(pkg.ID) != pkg || mgr.Package("Bag") != pkg || factory.packagesByID[pkg.ID] != pkg {
+		t.Fatalf("expected the old package to stay registered")
+	}
+	if len(atlases.unloaded) != 0 {
+		t.Fatalf("expected the atlases of the old package to be kept, got %v", atlases.unloaded)
+	}
+
+	// 文件未再变化，加载恢复后仍会重试
+	atlases.err = nil
+	reloader.Check(ctx)
+	events = reloader.Check(ctx)
+	if len(events) != 1 || events[0].Err != nil || events[0].New == nil {
+		t.Fatalf("expected the reload to be retried, got %+v", events)
+	}
+	if assets.GetPackageByID(pkg.ID) != events[0].New || !slices.Contains(atlases.reloaded, "Bag") {
+		t.Fatalf("expected the retried reload to replace the package")
+	}
+	if events := reloader.Check(ctx); len(events) != 0 {
+		t.Fatalf("expected no reload after a successful one, got %d", len(events))
+	}
+}
+
+func TestHotReloaderDisposesReplacedComponents(t *testing.T) {
+	dir := copyDemoPackage(t, "Basics")
+	loader := assets.NewFileLoader(dir)
+	factory := NewFactoryWithLoader(&unloadRecorder{}, loader)
+	ctx := context.Background()
+	pkg, err := NewPackageManager(factory, loader).AddPackage(ctx, "Basics")
+	if err != nil {
+		t.Fatalf("AddPackage: %v", err)
+	}
+	item := pkg.ItemByName("Demo_MovieClip")
+	if item == nil {
+		t.Skip("Basics package missing Demo_MovieClip")
+	}
+	comp, err := factory.BuildComponent(ctx, pkg, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	// 重建后的组件与旧组件注册同样多的 ticker，旧组件未释放时计数会增加
+	check := testutil.LeakCheck(t)
+	reloader, err := NewHotReloader(factory, nil, HotReloadOptions{})
+	if err != nil {
+		t.Fatalf("NewHotReloader: %v", err)
+	}
+	reloader.Watch(pkg)
+	reloader.Track(comp, item)
+
+	data, err := assets.WritePackage(pkg, assets.WriteOptions{})
+	if err != nil {
+		t.Fatalf("WritePackage: %v", err)
+	}
+	publish(t, filepath.Join(dir, "Basics.fui"), data, time.Now().Add(time.Hour))
+	reloader.Check(ctx)
+	events := reloader.Check(ctx)
+	if len(events) != 1 || len(events[0].Rebuilt) != 1 {
+		t.Fatalf("expected the tracked component to be rebuilt, got %+v", events)
+	}
+	if !comp.IsDisposed() {
+		t.Fatalf("expected the replaced component to be disposed")
+	}
+	check()
+}
+
+func TestHotReloaderFSNameMatchesWholeRoot(t *testing.T) {
+	h := &HotReloader{root: "assets"}
+	for key, want := range map[string]string{
+		"assets/Bag.fui":    "Bag.fui",
+		"assets2/Bag.fui":   "assets2/Bag.fui",
+		"Bag_atlas0.png":    "Bag_atlas0.png",
+		"/ui/assets/x.fui":  "ui/assets/x.fui",
+		"assets/ui/Bag.fui": "ui/Bag.fui",
+	} {
+		if got := h.fsName(key); got != want {
+			t.Errorf("fsName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
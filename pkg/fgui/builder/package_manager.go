@@ -0,0 +1,400 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"weak"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+var (
+	// ErrPackageNotFound is returned when a package id or name is not managed.
+	ErrPackageNotFound = errors.New("builder: package not found")
+	// ErrPackageInUse is returned when another loaded package still depends on the package.
+	ErrPackageInUse = errors.New("builder: package is required by other packages")
+	// ErrRemovalDeferred is returned when components built from the package are still
+	// alive. The package is unloaded by Collect once they have been released.
+	ErrRemovalDeferred = errors.New("builder: package removal deferred until its components are released")
+)
+
+// PackageUnloader is implemented by atlas resolvers that cache per-package textures
+// (render.AtlasManager). PackageManager calls it when a package is removed.
+type PackageUnloader interface {
+	UnloadPackage(pkg *assets.Package)
+}
+
+// PackageReloader is implemented by atlas resolvers that can load the textures of a
+// reparsed package before evicting those of the package it replaces
+// (render.AtlasManager). HotReloader uses it so a failed load keeps the old package.
+type PackageReloader interface {
+	ReloadPackage(ctx context.Context, old, pkg *assets.Package) error
+}
+
+// PackageManager implements the UIPackage.addPackage/removePackage lifecycle on top
+// of a Factory. Dependencies listed in Package.Dependencies are reference counted,
+// and top-level components built by the factory keep their package alive until they
+// are released (or garbage collected).
+type PackageManager struct {
+	factory *Factory
+	loader  assets.Loader
+	entries map[string]*packageEntry // key: package ID
+}
+
+type packageEntry struct {
+	pkg *assets.Package
+	// explicit is true when the package was added through AddPackage rather than
+	// pulled in as a dependency.
+	explicit bool
+	// dependents counts loaded packages that list this one as a dependency.
+	dependents int
+	deps       []*packageEntry
+	components []weak.Pointer[core.GComponent]
+	pending    bool
+}
+
+// NewPackageManager attaches a lifecycle manager to factory. Packages are read through
+// loader; when loader is nil the factory's own loader is used.
+func NewPackageManager(factory *Factory, loader assets.Loader) *PackageManager {
+	if factory == nil {
+		factory = NewFactory(nil, nil)
+	}
+	if loader == nil {
+		loader = factory.loader
+	}
+	m := &PackageManager{
+		factory: factory,
+		loader:  loader,
+		entries: make(map[string]*packageEntry),
+	}
+	factory.packages = m
+	return m
+}
+
+// Factory returns the factory whose built components are tracked.
+func (m *PackageManager) Factory() *Factory {
+	return m.factory
+}
+
+// PackageManager returns the lifecycle manager attached by NewPackageManager, or nil.
+func (f *Factory) PackageManager() *PackageManager {
+	return f.packages
+}
+
+// AddTransientPackage adds a package that is owned by the components built from it:
+// it is unloaded by Collect (or ReleaseComponent) as soon as none of them is alive.
+// Its dependencies are not reference counted and must stay loaded meanwhile.
+func (m *PackageManager) AddTransientPackage(ctx context.Context, pkg *assets.Package) error {
+	if pkg == nil {
+		return errors.New("builder: nil package")
+	}
+	if m.entries[pkg.ID] != nil {
+		return nil
+	}
+	if err := m.factory.ensurePackageReady(ctx, pkg); err != nil {
+		return err
+	}
+	m.entries[pkg.ID] = &packageEntry{pkg: pkg, pending: true}
+	return nil
+}
+
+// AddPackage loads "<resKey>.fui" through the loader, resolves its dependencies and
+// prepares atlases and fonts. Adding an already loaded package returns it unchanged.
+func (m *PackageManager) AddPackage(ctx context.Context, resKey string) (*assets.Package, error) {
+	resKey = strings.TrimSuffix(resKey, ".fui")
+	for _, entry := range m.entries {
+		if entry.pkg.ResKey == resKey {
+			entry.explicit = true
+			entry.pending = false
+			return entry.pkg, nil
+		}
+	}
+	if m.loader == nil {
+		return nil, fmt.Errorf("builder: no loader configured for package %s", resKey)
+	}
+	data, err := m.loader.LoadOne(ctx, resKey+".fui", assets.ResourceBinary)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := assets.ParsePackage(data, resKey)
+	if err != nil {
+		return nil, err
+	}
+	return m.AddLoadedPackage(ctx, pkg)
+}
+
+// AddLoadedPackage adds an already parsed package. If a package with the same ID is
+// managed, the existing one is returned.
+func (m *PackageManager) AddLoadedPackage(ctx context.Context, pkg *assets.Package) (*assets.Package, error) {
+	if pkg == nil {
+		return nil, errors.New("builder: nil package")
+	}
+	entry, err := m.add(ctx, pkg, nil)
+	if err != nil {
+		m.Collect()
+		return nil, err
+	}
+	entry.explicit = true
+	entry.pending = false
+	return entry.pkg, nil
+}
+
+func (m *PackageManager) add(ctx context.Context, pkg *assets.Package, chain []string) (*packageEntry, error) {
+	if entry := m.entries[pkg.ID]; entry != nil {
+		return entry, nil
+	}
+	for _, id := range chain {
+		if id == pkg.ID {
+			return nil, fmt.Errorf("builder: cyclic package dependency %s -> %s", strings.Join(chain, " -> "), pkg.ID)
+		}
+	}
+	chain = append(chain, pkg.ID)
+
+	entry := &packageEntry{pkg: pkg}
+	for _, dep := range pkg.Dependencies {
+		depEntry, err := m.resolveDependency(ctx, pkg, dep, chain)
+		if err != nil {
+			m.releaseDeps(entry)
+			return nil, err
+		}
+		depEntry.dependents++
+		entry.deps = append(entry.deps, depEntry)
+	}
+
+	if err := m.factory.ensurePackageReady(ctx, pkg); err != nil {
+		m.releaseDeps(entry)
+		return nil, err
+	}
+	m.entries[pkg.ID] = entry
+	return entry, nil
+}
+
+func (m *PackageManager) resolveDependency(ctx context.Context, owner *assets.Package, dep assets.Dependency, chain []string) (*packageEntry, error) {
+	if entry := m.lookup(dep.ID); entry != nil {
+		return entry, nil
+	}
+	if entry := m.lookup(dep.Name); entry != nil {
+		return entry, nil
+	}
+	depPkg := m.factory.lookupRegisteredPackage(dep.ID)
+	if depPkg == nil {
+		depPkg = m.factory.lookupRegisteredPackage(dep.Name)
+	}
+	if depPkg == nil && m.factory.packageResolver != nil {
+		for _, key := range []string{dep.ID, dep.Name} {
+			if key == "" {
+				continue
+			}
+			if resolved, err := m.factory.packageResolver(ctx, owner, key); err == nil && resolved != nil {
+				depPkg = resolved
+				break
+			}
+		}
+	}
+	if depPkg == nil && m.loader != nil && dep.Name != "" {
+		key := dep.Name
+		if dir := filepath.Dir(owner.ResKey); dir != "." && dir != "" {
+			key = filepath.Join(dir, dep.Name)
+		}
+		if data, err := m.loader.LoadOne(ctx, key+".fui", assets.ResourceBinary); err == nil {
+			parsed, err := assets.ParsePackage(data, key)
+			if err != nil {
+				return nil, err
+			}
+			depPkg = parsed
+		}
+	}
+	if depPkg == nil {
+		return nil, fmt.Errorf("builder: dependency %s (%s) of package %s not found", dep.Name, dep.ID, owner.Name)
+	}
+	return m.add(ctx, depPkg, chain)
+}
+
+// RemovePackage unloads a package added with AddPackage. It fails with ErrPackageInUse
+// while other loaded packages depend on it, and returns ErrRemovalDeferred while
+// components built from it are alive; the deferred removal is completed by Collect
+// (or ReleaseComponent) once they are gone.
+func (m *PackageManager) RemovePackage(idOrName string) error {
+	entry := m.lookup(idOrName)
+	if entry == nil {
+		return fmt.Errorf("%w: %s", ErrPackageNotFound, idOrName)
+	}
+	if entry.dependents > 0 {
+		return fmt.Errorf("%w: %s needed by %s", ErrPackageInUse, entry.pkg.Name, strings.Join(m.dependentsOf(entry), ", "))
+	}
+	entry.explicit = false
+	if entry.liveComponents() > 0 {
+		entry.pending = true
+		return ErrRemovalDeferred
+	}
+	m.unload(entry)
+	// Dependencies pulled in only for this package go away with it.
+	m.Collect()
+	return nil
+}
+
+// ReleaseComponent stops tracking a component returned by Factory.BuildComponent and
+// completes any removal that was waiting for it.
+func (m *PackageManager) ReleaseComponent(comp *core.GComponent) {
+	if comp == nil {
+		return
+	}
+	for _, entry := range m.entries {
+		kept := entry.components[:0]
+		for _, ptr := range entry.components {
+			if ptr.Value() != comp {
+				kept = append(kept, ptr)
+			}
+		}
+		clear(entry.components[len(kept):])
+		entry.components = kept
+	}
+	m.Collect()
+}
+
+// Collect drops components that have been garbage collected and unloads packages
+// whose removal was deferred. It returns the number of packages unloaded.
+func (m *PackageManager) Collect() int {
+	unloaded := 0
+	for {
+		progressed := false
+		for _, entry := range m.sortedEntries() {
+			if m.entries[entry.pkg.ID] != entry {
+				continue
+			}
+			if entry.pending && entry.dependents == 0 && entry.liveComponents() == 0 {
+				m.unload(entry)
+				unloaded++
+				progressed = true
+			}
+		}
+		if !progressed {
+			return unloaded
+		}
+	}
+}
+
+// Package returns the managed package with the given id or name.
+func (m *PackageManager) Package(idOrName string) *assets.Package {
+	if entry := m.lookup(idOrName); entry != nil {
+		return entry.pkg
+	}
+	return nil
+}
+
+// Packages returns every managed package, dependencies included, sorted by name.
+func (m *PackageManager) Packages() []*assets.Package {
+	entries := m.sortedEntries()
+	out := make([]*assets.Package, len(entries))
+	for i, entry := range entries {
+		out[i] = entry.pkg
+	}
+	return out
+}
+
+// LiveComponents reports how many top-level components built from the package are
+// still alive.
+func (m *PackageManager) LiveComponents(idOrName string) int {
+	if entry := m.lookup(idOrName); entry != nil {
+		return entry.liveComponents()
+	}
+	return 0
+}
+
+// replacePackage swaps a reloaded package into the entry of the package it replaces,
+// keeping its dependency counts and tracked components.
+func (m *PackageManager) replacePackage(old, pkg *assets.Package) {
+	entry := m.entries[old.ID]
+	if entry == nil || entry.pkg != old {
+		return
+	}
+	delete(m.entries, old.ID)
+	entry.pkg = pkg
+	m.entries[pkg.ID] = entry
+}
+
+// trackComponent is called by Factory.BuildComponent for every top-level build.
+func (m *PackageManager) trackComponent(pkg *assets.Package, comp *core.GComponent) {
+	if pkg == nil || comp == nil {
+		return
+	}
+	entry := m.entries[pkg.ID]
+	if entry == nil {
+		return
+	}
+	entry.components = append(entry.components, weak.Make(comp))
+}
+
+func (m *PackageManager) lookup(idOrName string) *packageEntry {
+	if idOrName == "" {
+		return nil
+	}
+	if entry := m.entries[idOrName]; entry != nil {
+		return entry
+	}
+	for _, entry := range m.entries {
+		if strings.EqualFold(entry.pkg.Name, idOrName) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (m *PackageManager) sortedEntries() []*packageEntry {
+	entries := make([]*packageEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].pkg.Name < entries[j].pkg.Name })
+	return entries
+}
+
+func (m *PackageManager) dependentsOf(target *packageEntry) []string {
+	var names []string
+	for _, entry := range m.sortedEntries() {
+		for _, dep := range entry.deps {
+			if dep == target {
+				names = append(names, entry.pkg.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func (m *PackageManager) releaseDeps(entry *packageEntry) {
+	for _, dep := range entry.deps {
+		dep.dependents--
+		if !dep.explicit && dep.dependents == 0 {
+			dep.pending = true
+		}
+	}
+	entry.deps = nil
+}
+
+func (m *PackageManager) unload(entry *packageEntry) {
+	delete(m.entries, entry.pkg.ID)
+	m.releaseDeps(entry)
+	m.factory.forgetPackage(entry.pkg)
+	if unloader, ok := m.factory.atlasManager.(PackageUnloader); ok {
+		unloader.UnloadPackage(entry.pkg)
+	}
+	assets.UnloadPackage(entry.pkg)
+}
+
+func (e *packageEntry) liveComponents() int {
+	kept := e.components[:0]
+	for _, ptr := range e.components {
+		if ptr.Value() != nil {
+			kept = append(kept, ptr)
+		}
+	}
+	clear(e.components[len(kept):])
+	e.components = kept
+	return len(kept)
+}
@@ -0,0 +1,173 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/render"
+)
+
+// unloadRecorder 是记录 UnloadPackage 调用的 AtlasResolver
+type unloadRecorder struct {
+	loaded   []string
+	unloaded []string
+}
+
+func (r *unloadRecorder) LoadPackage(ctx context.Context, pkg *assets.Package) error {
+	r.loaded = append(r.loaded, pkg.Name)
+	return nil
+}
+
+func (r *unloadRecorder) ResolveSprite(item *assets.PackageItem) (any, error) {
+	return nil, nil
+}
+
+func (r *unloadRecorder) UnloadPackage(pkg *assets.Package) {
+	r.unloaded = append(r.unloaded, pkg.Name)
+}
+
+func demoPackageLoader(t *testing.T) assets.Loader {
+	t.Helper()
+	root := filepath.Join("..", "..", "..", "demo", "assets")
+	if _, err := os.Stat(filepath.Join(root, "Bag.fui")); err != nil {
+		t.Skipf("demo assets unavailable: %v", err)
+	}
+	return assets.NewFileLoader(root)
+}
+
+func firstComponentItem(pkg *assets.Package) *assets.PackageItem {
+	for _, item := range pkg.Items {
+		if item.Type == assets.PackageItemTypeComponent && item.Component != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestPackageManagerDependencyRefCount(t *testing.T) {
+	loader := demoPackageLoader(t)
+	recorder := &unloadRecorder{}
+	mgr := NewPackageManager(NewFactory(recorder, nil), loader)
+	ctx := context.Background()
+
+	data, err := loader.LoadOne(ctx, "Bag.fui", assets.ResourceBinary)
+	if err != nil {
+		t.Fatalf("load Bag: %v", err)
+	}
+	bag, err := assets.ParsePackage(data, "Bag")
+	if err != nil {
+		t.Fatalf("parse Bag: %v", err)
+	}
+	// 演示包之间没有依赖，这里人为声明 Bag 依赖 Basics
+	bag.Dependencies = []assets.Dependency{{ID: "9leh0eyf", Name: "Basics"}}
+
+	if _, err := mgr.AddLoadedPackage(ctx, bag); err != nil {
+		t.Fatalf("AddLoadedPackage failed: %v", err)
+	}
+	if got := len(mgr.Packages()); got != 2 {
+		t.Fatalf("expected Bag and its dependency to be managed, got %d packages", got)
+	}
+	if assets.GetPackageByName("Basics") == nil {
+		t.Fatalf("expected dependency to be registered globally")
+	}
+
+	if err := mgr.RemovePackage("Basics"); !errors.Is(err, ErrPackageInUse) {
+		t.Fatalf("expected ErrPackageInUse, got %v", err)
+	}
+
+	comp, err := mgr.Factory().BuildComponent(ctx, bag, firstComponentItem(bag))
+	if err != nil {
+		t.Fatalf("BuildComponent failed: %v", err)
+	}
+	if got := mgr.LiveComponents("Bag"); got != 1 {
+		t.Fatalf("expected 1 live component, got %d", got)
+	}
+
+	if err := mgr.RemovePackage("Bag"); !errors.Is(err, ErrRemovalDeferred) {
+		t.Fatalf("expected ErrRemovalDeferred, got %v", err)
+	}
+	if mgr.Package("Bag") == nil {
+		t.Fatalf("package should stay loaded while components are alive")
+	}
+
+	mgr.ReleaseComponent(comp)
+	if len(mgr.Packages()) != 0 {
+		t.Fatalf("expected Bag and implicit dependency to be unloaded, got %d packages", len(mgr.Packages()))
+	}
+	if assets.GetPackageByID(bag.ID) != nil || assets.GetPackageByName("Basics") != nil {
+		t.Fatalf("expected packages to be removed from the global registry")
+	}
+	if len(recorder.unloaded) != 2 {
+		t.Fatalf("expected atlas unload for both packages, got %v", recorder.unloaded)
+	}
+}
+
+func TestPackageManagerEvictsFonts(t *testing.T) {
+	loader := demoPackageLoader(t)
+	mgr := NewPackageManager(NewFactory(nil, nil), loader)
+
+	pkg, err := mgr.AddPackage(context.Background(), "Basics")
+	if err != nil {
+		t.Fatalf("AddPackage failed: %v", err)
+	}
+	var font *assets.PackageItem
+	for _, item := range pkg.Items {
+		if item.Type == assets.PackageItemTypeFont {
+			font = item
+			break
+		}
+	}
+	if font == nil {
+		t.Skip("Basics has no bitmap font")
+	}
+	url := "ui://" + pkg.ID + font.ID
+	if render.TestLookupFont(url) == nil {
+		t.Fatalf("expected font %s to be registered", url)
+	}
+
+	if err := mgr.RemovePackage(pkg.Name); err != nil {
+		t.Fatalf("RemovePackage failed: %v", err)
+	}
+	if render.TestLookupFont(url) != nil {
+		t.Fatalf("expected font %s to be unregistered", url)
+	}
+	if err := mgr.RemovePackage(pkg.Name); !errors.Is(err, ErrPackageNotFound) {
+		t.Fatalf("expected ErrPackageNotFound, got %v", err)
+	}
+}
+
+func TestPackageManagerCollectsUnreachableComponents(t *testing.T) {
+	loader := demoPackageLoader(t)
+	mgr := NewPackageManager(NewFactory(nil, nil), loader)
+	ctx := context.Background()
+
+	pkg, err := mgr.AddPackage(ctx, "Bag")
+	if err != nil {
+		t.Fatalf("AddPackage failed: %v", err)
+	}
+	func() {
+		comp, err := mgr.Factory().BuildComponent(ctx, pkg, firstComponentItem(pkg))
+		if err != nil {
+			t.Fatalf("BuildComponent failed: %v", err)
+		}
+		if comp == nil {
+			t.Fatalf("BuildComponent returned nil")
+		}
+	}()
+
+	if err := mgr.RemovePackage("Bag"); !errors.Is(err, ErrRemovalDeferred) {
+		t.Fatalf("expected ErrRemovalDeferred, got %v", err)
+	}
+	runtime.GC()
+	if n := mgr.Collect(); n != 1 {
+		t.Fatalf("expected Collect to unload 1 package, got %d", n)
+	}
+	if mgr.Package("Bag") != nil {
+		t.Fatalf("expected Bag to be unloaded")
+	}
+}
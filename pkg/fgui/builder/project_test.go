@@ -0,0 +1,118 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+// 从编辑器工程构建的组件树应与从发布的 .fui 构建的一致
+func TestBuildComponentFromProjectMatchesPublished(t *testing.T) {
+	demo := filepath.Join("..", "..", "..", "demo")
+	for _, name := range []string{
+		"Bag", "Basics", "Chat", "Cooldown", "Guide", "HitTest", "Joystick", "ListEffect", "LoopList",
+		"MainMenu", "ModalWaiting", "PullToRefresh", "ScrollPane", "Transition", "TreeView", "VirtualList",
+	} {
+		t.Run(name, func(t *testing.T) {
+			fuiData, err := os.ReadFile(filepath.Join(demo, "assets", name+".fui"))
+			if err != nil {
+				t.Skipf("跳过测试：无法读取 .fui 文件: %v", err)
+			}
+			published, err := assets.ParsePackage(fuiData, "demo/assets/"+name)
+			if err != nil {
+				t.Fatalf("解析 .fui 文件失败: %v", err)
+			}
+			loader := assets.NewFileLoader(filepath.Join(demo, "UIProject", "assets"))
+			project, err := assets.LoadProjectPackage(context.Background(), loader, name)
+			if err != nil {
+				t.Fatalf("加载工程包失败: %v", err)
+			}
+
+			publishedFactory := NewFactory(nil, nil)
+			publishedFactory.RegisterPackage(published)
+			projectFactory := NewFactory(nil, nil)
+			projectFactory.RegisterPackage(project)
+
+			ctx := context.Background()
+			for _, want := range published.Items {
+				if want.Type != assets.PackageItemTypeComponent || name+"/"+want.Name == "Basics/Demo_List" {
+					continue
+				}
+				got := project.ItemByID(want.ID)
+				if got == nil {
+					t.Errorf("组件 %s 不在工程包中", want.Name)
+					continue
+				}
+				wantRoot, err := publishedFactory.BuildComponent(ctx, published, want)
+				if err != nil {
+					t.Fatalf("构建发布组件 %s 失败: %v", want.Name, err)
+				}
+				gotRoot, err := projectFactory.BuildComponent(ctx, project, got)
+				if err != nil {
+					t.Fatalf("构建工程组件 %s 失败: %v", want.Name, err)
+				}
+				if diff := diffComponentTrees(gotRoot, wantRoot, want.Name); diff != "" {
+					t.Errorf("组件树不一致：%s", diff)
+				}
+			}
+		})
+	}
+}
+
+// diffComponentTrees 返回第一处差异的描述，一致时返回空字符串
+func diffComponentTrees(got, want *core.GComponent, path string) string {
+	if got.Width() != want.Width() || got.Height() != want.Height() {
+		return fmt.Sprintf("%s: size %vx%v, want %vx%v", path, got.Width(), got.Height(), want.Width(), want.Height())
+	}
+	gotChildren, wantChildren := got.Children(), want.Children()
+	if len(gotChildren) != len(wantChildren) {
+		return fmt.Sprintf("%s: %d children, want %d", path, len(gotChildren), len(wantChildren))
+	}
+	for i, w := range wantChildren {
+		g := gotChildren[i]
+		childPath := path + "/" + w.Name()
+		if g.Name() != w.Name() || fmt.Sprintf("%T", g.Data()) != fmt.Sprintf("%T", w.Data()) {
+			return fmt.Sprintf("%s: got %s (%T), want %s (%T)", childPath, g.Name(), g.Data(), w.Name(), w.Data())
+		}
+		if g.X() != w.X() || g.Y() != w.Y() || g.Width() != w.Width() || g.Height() != w.Height() {
+			return fmt.Sprintf("%s: rect %v,%v %vx%v, want %v,%v %vx%v", childPath,
+				g.X(), g.Y(), g.Width(), g.Height(), w.X(), w.Y(), w.Width(), w.Height())
+		}
+		if g.Visible() != w.Visible() || g.Alpha() != w.Alpha() || g.Touchable() != w.Touchable() {
+			return fmt.Sprintf("%s: visible/alpha/touchable mismatch", childPath)
+		}
+		if gt, wt := childText(g), childText(w); gt != wt {
+			return fmt.Sprintf("%s: text %q, want %q", childPath, gt, wt)
+		}
+		if gc, ok := g.Data().(*core.GComponent); ok {
+			if diff := diffComponentTrees(gc, w.Data().(*core.GComponent), childPath); diff != "" {
+				return diff
+			}
+		}
+	}
+	return ""
+}
+
+func childText(obj *core.GObject) string {
+	switch w := obj.Data().(type) {
+	case *widgets.GTextField:
+		return w.Text()
+	case *widgets.GTextInput:
+		return w.Text()
+	case *widgets.GRichTextField:
+		return w.Text()
+	case *widgets.GLabel:
+		return w.Title()
+	case *widgets.GButton:
+		return w.Title()
+	case *widgets.GComboBox:
+		return w.Text()
+	}
+	return ""
+}
@@ -0,0 +1,303 @@
+package builder
+
+import (
+	"slices"
+	"strconv"
+	"weak"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+// translatedComponent 记录一个已构建组件中可翻译的字段及其原文。
+// 字段通过子对象下标定位，不直接持有子对象，组件被释放或回收后记录随之失效，
+// 失效的记录在 Retranslate 或记录列表扩容时移除。
+type translatedComponent struct {
+	root   weak.Pointer[core.GComponent]
+	key    string // 包ID + 组件ID
+	fields []translatedField
+}
+
+type translatedField struct {
+	key      string // 元素键，与字符串表第二层键一致
+	original string
+	apply    func(root *core.GComponent, value string)
+}
+
+// translateComponent 为 item 构建出的 root 收集可翻译字段并应用当前字符串表。
+// 键规则与 TypeScript TranslationHelper.translateComponent 一致：
+//   - <elementId>：文本内容，Label/Button 的标题，ComboBox 的标题
+//   - <elementId>-tips：tooltips
+//   - <elementId>-prompt：输入框提示文字
+//   - <elementId>-0：Button 选中标题
+//   - <elementId>-<j>：List 第 j 项的标题、ComboBox 第 j 个条目
+//   - <elementId>-<j>-0：List 第 j 项的选中标题
+//
+// 控制器页面名称使用 <controllerName>-pages_<index>。
+//
+// 未加载字符串表时同样记录原文，之后设置的字符串表也会应用到该组件。原文为空的字段只在当前
+// 字符串表提供了译文时记录（编辑器导出的字符串表只包含非空原文），没有可翻译字段的组件
+// （例如只含图形的按钮模板）不产生记录。
+//
+// 字段列表只与组件资源和字符串表有关，缓存在组件模板中，字符串表切换后重新收集。
+func (f *Factory) translateComponent(root *core.GComponent, item *assets.PackageItem, tpl *componentTemplate) {
+	if root == nil || item == nil || item.Owner == nil || item.Component == nil {
+		return
+	}
+	f.hookTranslations()
+	table := assets.CurrentStringTable()
+	key := item.Owner.ID + item.ID
+	if !tpl.translationsReady || tpl.translationGen != f.translationGen {
+		tpl.translations = collectTranslations(root, item, table[key])
+		tpl.translationGen, tpl.translationsReady = f.translationGen, true
+	}
+	if len(tpl.translations) == 0 {
+		return
+	}
+	tc := &translatedComponent{root: weak.Make(root), key: key, fields: tpl.translations}
+	if table != nil {
+		tc.apply(table)
+	}
+	// 列表扩容前先移除失效的记录，未切换语言时记录数也不会无限增长
+	if len(f.translations) == cap(f.translations) {
+		f.translations = slices.DeleteFunc(f.translations, func(tc *translatedComponent) bool { return tc.root.Value() == nil })
+	}
+	f.translations = append(f.translations, tc)
+	root.OnDispose(tc.release)
+}
+
+// hookTranslations 注册语言切换回调。即使还没有记录也要注册，字符串表切换时
+// translationGen 递增，模板中缓存的字段随之失效
+func (f *Factory) hookTranslations() {
+	if f.translationHooked {
+		return
+	}
+	f.translationHooked = true
+	// 工厂只被弱引用，不会因为语言切换回调而无法回收
+	ref := weak.Make(f)
+	assets.OnTranslationChanged(func() {
+		if factory := ref.Value(); factory != nil {
+			factory.Retranslate()
+		}
+	})
+}
+
+// collectTranslations 收集 root 中可翻译的字段及其原文，strings 是当前字符串表中该组件的译文
+func collectTranslations(root *core.GComponent, item *assets.PackageItem, strings map[string]string) []translatedField {
+	var fields []translatedField
+	add := func(key, original string, apply func(*core.GComponent, string)) {
+		if original == "" {
+			if _, ok := strings[key]; !ok {
+				return
+			}
+		}
+		fields = append(fields, translatedField{key: key, original: original, apply: apply})
+	}
+
+	for ci, ctrl := range root.Controllers() {
+		for pi, name := range ctrl.PageNames {
+			ci, pi := ci, pi
+			add(ctrl.Name+"-pages_"+strconv.Itoa(pi), name, func(root *core.GComponent, value string) {
+				if ctrls := root.Controllers(); ci < len(ctrls) && pi < len(ctrls[ci].PageNames) {
+					ctrls[ci].PageNames[pi] = value
+				}
+			})
+		}
+	}
+
+	for idx := range item.Component.Children {
+		meta := &item.Component.Children[idx]
+		obj := translationChild(root, idx, meta.Name)
+		if obj == nil || meta.ID == "" {
+			continue
+		}
+		idx, name, id := idx, meta.Name, meta.ID
+		child := func(root *core.GComponent) *core.GObject { return translationChild(root, idx, name) }
+		widget := func(root *core.GComponent) any {
+			if obj := child(root); obj != nil {
+				return obj.Data()
+			}
+			return nil
+		}
+
+		add(id+"-tips", obj.Tooltips(), func(root *core.GComponent, value string) {
+			if obj := child(root); obj != nil {
+				obj.SetTooltips(value)
+			}
+		})
+
+		switch w := obj.Data().(type) {
+		case *widgets.GTextInput:
+			add(id, w.Text(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GTextInput); ok {
+					w.SetText(value)
+				}
+			})
+			add(id+"-prompt", w.PromptText(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GTextInput); ok {
+					w.SetPromptText(value)
+				}
+			})
+		case *widgets.GTextField:
+			add(id, w.Text(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GTextField); ok {
+					w.SetText(value)
+				}
+			})
+		case *widgets.GRichTextField:
+			add(id, w.Text(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GRichTextField); ok {
+					w.SetText(value)
+				}
+			})
+		case *widgets.GLabel:
+			add(id, w.Title(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GLabel); ok {
+					w.SetTitle(value)
+				}
+			})
+		case *widgets.GButton:
+			add(id, w.Title(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GButton); ok {
+					w.SetTitle(value)
+				}
+			})
+			add(id+"-0", w.SelectedTitle(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GButton); ok {
+					w.SetSelectedTitle(value)
+				}
+			})
+		case *widgets.GComboBox:
+			add(id, w.Text(), func(root *core.GComponent, value string) {
+				if w, ok := widget(root).(*widgets.GComboBox); ok {
+					w.SetText(value)
+				}
+			})
+			for j, text := range w.Items() {
+				j := j
+				add(id+"-"+strconv.Itoa(j), text, func(root *core.GComponent, value string) {
+					if w, ok := widget(root).(*widgets.GComboBox); ok {
+						items := append([]string(nil), w.Items()...)
+						if j < len(items) {
+							items[j] = value
+							w.SetItems(items, w.Values(), w.Icons())
+						}
+					}
+				})
+			}
+		case *widgets.GList:
+			for j, entry := range w.Items() {
+				j := j
+				title, selectedTitle := listItemTitles(entry)
+				add(id+"-"+strconv.Itoa(j), title, func(root *core.GComponent, value string) {
+					if entry := listItemAt(child(root), j); entry != nil {
+						setListItemTitle(entry, value, false)
+					}
+				})
+				add(id+"-"+strconv.Itoa(j)+"-0", selectedTitle, func(root *core.GComponent, value string) {
+					if entry := listItemAt(child(root), j); entry != nil {
+						setListItemTitle(entry, value, true)
+					}
+				})
+			}
+		}
+	}
+
+	return fields
+}
+
+// release 在组件释放时使记录失效
+func (tc *translatedComponent) release() {
+	tc.root = weak.Pointer[core.GComponent]{}
+	tc.fields = nil
+}
+
+// Retranslate 按当前字符串表重新翻译该工厂构建的所有存活组件，
+// 字符串表中缺少的键恢复为包内原文。切换语言（assets.SetStringTable）时会自动调用。
+func (f *Factory) Retranslate() {
+	f.translationGen++
+	table := assets.CurrentStringTable()
+	kept := f.translations[:0]
+	for _, tc := range f.translations {
+		if tc.apply(table) {
+			kept = append(kept, tc)
+		}
+	}
+	clear(f.translations[len(kept):])
+	f.translations = kept
+}
+
+// apply 写入翻译，组件已被回收时返回 false
+func (tc *translatedComponent) apply(table assets.StringTable) bool {
+	root := tc.root.Value()
+	if root == nil {
+		return false
+	}
+	strings := table[tc.key]
+	for _, field := range tc.fields {
+		value, ok := strings[field.key]
+		if !ok {
+			value = field.original
+		}
+		field.apply(root, value)
+	}
+	return true
+}
+
+// translationChild 按构建顺序定位子对象，名称不一致时按名称查找
+func translationChild(root *core.GComponent, index int, name string) *core.GObject {
+	if root == nil {
+		return nil
+	}
+	if obj := root.ChildAt(index); obj != nil && obj.Name() == name {
+		return obj
+	}
+	if name == "" {
+		return nil
+	}
+	return root.ChildByName(name)
+}
+
+func listItemAt(obj *core.GObject, index int) *core.GObject {
+	if obj == nil {
+		return nil
+	}
+	list, ok := obj.Data().(*widgets.GList)
+	if !ok {
+		return nil
+	}
+	items := list.Items()
+	if index < 0 || index >= len(items) {
+		return nil
+	}
+	return items[index]
+}
+
+func listItemTitles(entry *core.GObject) (string, string) {
+	if entry == nil {
+		return "", ""
+	}
+	switch w := entry.Data().(type) {
+	case *widgets.GButton:
+		return w.Title(), w.SelectedTitle()
+	case *widgets.GLabel:
+		return w.Title(), ""
+	}
+	return "", ""
+}
+
+func setListItemTitle(entry *core.GObject, value string, selected bool) {
+	switch w := entry.Data().(type) {
+	case *widgets.GButton:
+		if selected {
+			w.SetSelectedTitle(value)
+		} else {
+			w.SetTitle(value)
+		}
+	case *widgets.GLabel:
+		if !selected {
+			w.SetTitle(value)
+		}
+	}
+}
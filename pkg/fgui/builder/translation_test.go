@@ -0,0 +1,234 @@
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+func TestBuildComponentAppliesStringTable(t *testing.T) {
+	fuiData, err := os.ReadFile(filepath.Join("..", "..", "..", "demo", "assets", "Basics.fui"))
+	if err != nil {
+		t.Skipf("跳过测试：无法读取 .fui 文件: %v", err)
+	}
+	pkg, err := assets.ParsePackage(fuiData, "demo/assets/Basics")
+	if err != nil {
+		t.Fatalf("解析 .fui 文件失败: %v", err)
+	}
+	textItem := pkg.ItemByName("Demo_Text")
+	windowItem := pkg.ItemByName("WindowA")
+	if textItem == nil || windowItem == nil {
+		t.Fatalf("未找到 Demo_Text 或 WindowA 组件")
+	}
+	t.Cleanup(func() { assets.SetStringTable(nil) })
+
+	assets.SetStringTable(assets.StringTable{
+		pkg.ID + textItem.ID: {
+			"n2":      "文本对齐",
+			"n3-tips": "样式提示",
+		},
+		pkg.ID + windowItem.ID: {
+			"n2-1": "第二项",
+		},
+	})
+
+	factory := NewFactory(nil, nil)
+	factory.RegisterPackage(pkg)
+	ctx := context.Background()
+	demoText, err := factory.BuildComponent(ctx, pkg, textItem)
+	if err != nil {
+		t.Fatalf("构建 Demo_Text 失败: %v", err)
+	}
+	window, err := factory.BuildComponent(ctx, pkg, windowItem)
+	if err != nil {
+		t.Fatalf("构建 WindowA 失败: %v", err)
+	}
+
+	text := func(name string) *widgets.GTextField {
+		t.Helper()
+		field, ok := demoText.ChildByName(name).Data().(*widgets.GTextField)
+		if !ok {
+			t.Fatalf("子对象 %s 不是 GTextField", name)
+		}
+		return field
+	}
+	combo := func(root *core.GComponent) *widgets.GComboBox {
+		t.Helper()
+		for _, child := range root.Children() {
+			if cb, ok := child.Data().(*widgets.GComboBox); ok {
+				return cb
+			}
+		}
+		t.Fatalf("WindowA 中没有 GComboBox")
+		return nil
+	}
+
+	if got := text("n2").Text(); got != "文本对齐" {
+		t.Fatalf("expected translated text, got %q", got)
+	}
+	if got := demoText.ChildByName("n3").Tooltips(); got != "样式提示" {
+		t.Fatalf("expected translated tooltips, got %q", got)
+	}
+	if got := text("n4").Text(); got != "Text with outline" {
+		t.Fatalf("untranslated text should keep original, got %q", got)
+	}
+	if items := combo(window).Items(); len(items) < 2 || items[1] != "第二项" || items[0] != "Item 1" {
+		t.Fatalf("unexpected combo items %v", items)
+	}
+
+	// 切换语言后已构建组件重新翻译
+	assets.SetStringTable(assets.StringTable{
+		pkg.ID + textItem.ID: {"n2": "Alignment"},
+	})
+	if got := text("n2").Text(); got != "Alignment" {
+		t.Fatalf("expected retranslated text, got %q", got)
+	}
+	if got := demoText.ChildByName("n3").Tooltips(); got != "" {
+		t.Fatalf("expected tooltips restored to original, got %q", got)
+	}
+	if items := combo(window).Items(); items[1] != "Item 2" {
+		t.Fatalf("expected combo item restored, got %v", items)
+	}
+
+	// 关闭翻译恢复包内原文
+	assets.SetStringTable(nil)
+	if got := text("n2").Text(); got != "Text Align" {
+		t.Fatalf("expected original text, got %q", got)
+	}
+}
+
+func TestTranslationRecordsDroppedOnDispose(t *testing.T) {
+	fuiData, err := os.ReadFile(filepath.Join("..", "..", "..", "demo", "assets", "Basics.fui"))
+	if err != nil {
+		t.Skipf("跳过测试：无法读取 .fui 文件: %v", err)
+	}
+	pkg, err := assets.ParsePackage(fuiData, "demo/assets/Basics")
+	if err != nil {
+		t.Fatalf("解析 .fui 文件失败: %v", err)
+	}
+	textItem := pkg.ItemByName("Demo_Text")
+	if textItem == nil {
+		t.Fatalf("未找到 Demo_Text 组件")
+	}
+	t.Cleanup(func() { assets.SetStringTable(nil) })
+	assets.SetStringTable(assets.StringTable{pkg.ID + textItem.ID: {"n2": "文本对齐"}})
+
+	factory := NewFactory(nil, nil)
+	factory.RegisterPackage(pkg)
+	ctx := context.Background()
+	kept, err := factory.BuildComponent(ctx, pkg, textItem)
+	if err != nil {
+		t.Fatalf("构建 Demo_Text 失败: %v", err)
+	}
+	// 嵌套组件也有各自的记录
+	base := len(factory.translations)
+	for i := 0; i < 100; i++ {
+		comp, err := factory.BuildComponent(ctx, pkg, textItem)
+		if err != nil {
+			t.Fatalf("构建 Demo_Text 失败: %v", err)
+		}
+		comp.Dispose()
+	}
+	if n := len(factory.translations); n > 4*base {
+		t.Fatalf("expected records of disposed components to be dropped, %d left", n)
+	}
+	live := 0
+	for _, tc := range factory.translations {
+		if tc.root.Value() != nil {
+			live++
+		}
+	}
+	if live != base {
+		t.Fatalf("expected only the kept component to have records, got %d of %d", live, base)
+	}
+
+	assets.SetStringTable(assets.StringTable{pkg.ID + textItem.ID: {"n2": "Alignment"}})
+	if got := kept.ChildByName("n2").Data().(*widgets.GTextField).Text(); got != "Alignment" {
+		t.Fatalf("expected the kept component to be retranslated, got %q", got)
+	}
+}
+
+func TestTranslationAppliesToComponentsBuiltBeforeTable(t *testing.T) {
+	fuiData, err := os.ReadFile(filepath.Join("..", "..", "..", "demo", "assets", "Basics.fui"))
+	if err != nil {
+		t.Skipf("跳过测试：无法读取 .fui 文件: %v", err)
+	}
+	pkg, err := assets.ParsePackage(fuiData, "demo/assets/Basics")
+	if err != nil {
+		t.Fatalf("解析 .fui 文件失败: %v", err)
+	}
+	textItem := pkg.ItemByName("Demo_Text")
+	barItem := pkg.ItemByName("ProgressBar5")
+	if textItem == nil || barItem == nil {
+		t.Fatalf("未找到 Demo_Text 或 ProgressBar5 组件")
+	}
+	t.Cleanup(func() { assets.SetStringTable(nil) })
+	assets.SetStringTable(nil)
+
+	factory := NewFactory(nil, nil)
+	factory.RegisterPackage(pkg)
+	ctx := context.Background()
+	demoText, err := factory.BuildComponent(ctx, pkg, textItem)
+	if err != nil {
+		t.Fatalf("构建 Demo_Text 失败: %v", err)
+	}
+	// 只有图形和空文本的组件没有可翻译字段，不产生记录
+	before := len(factory.translations)
+	if _, err := factory.BuildComponent(ctx, pkg, barItem); err != nil {
+		t.Fatalf("构建 ProgressBar5 失败: %v", err)
+	}
+	if n := len(factory.translations) - before; n != 0 {
+		t.Fatalf("expected no records for ProgressBar5, got %d", n)
+	}
+
+	field := demoText.ChildByName("n2").Data().(*widgets.GTextField)
+	if got := field.Text(); got != "Text Align" {
+		t.Fatalf("expected original text without a string table, got %q", got)
+	}
+	assets.SetStringTable(assets.StringTable{pkg.ID + textItem.ID: {"n2": "文本对齐"}})
+	if got := field.Text(); got != "文本对齐" {
+		t.Fatalf("expected the component built before the table to be translated, got %q", got)
+	}
+}
+
+// 模板缓存的可翻译字段在切换字符串表后重新收集：原文为空的字段在新表提供译文后也会记录
+func TestTranslationFieldsRecollectedAfterTableChange(t *testing.T) {
+	fuiData, err := os.ReadFile(filepath.Join("..", "..", "..", "demo", "assets", "Basics.fui"))
+	if err != nil {
+		t.Skipf("跳过测试：无法读取 .fui 文件: %v", err)
+	}
+	pkg, err := assets.ParsePackage(fuiData, "demo/assets/Basics")
+	if err != nil {
+		t.Fatalf("解析 .fui 文件失败: %v", err)
+	}
+	textItem := pkg.ItemByName("Demo_Text")
+	if textItem == nil {
+		t.Fatalf("未找到 Demo_Text 组件")
+	}
+	t.Cleanup(func() { assets.SetStringTable(nil) })
+	assets.SetStringTable(nil)
+
+	factory := NewFactory(nil, nil)
+	factory.RegisterPackage(pkg)
+	ctx := context.Background()
+	if _, err := factory.BuildComponent(ctx, pkg, textItem); err != nil {
+		t.Fatalf("构建 Demo_Text 失败: %v", err)
+	}
+	assets.SetStringTable(assets.StringTable{pkg.ID + textItem.ID: {"n3-tips": "样式提示"}})
+	comp, err := factory.BuildComponent(ctx, pkg, textItem)
+	if err != nil {
+		t.Fatalf("构建 Demo_Text 失败: %v", err)
+	}
+	if got := comp.ChildByName("n3").Tooltips(); got != "样式提示" {
+		t.Fatalf("expected tooltips from the new table, got %q", got)
+	}
+	assets.SetStringTable(nil)
+	if got := comp.ChildByName("n3").Tooltips(); got != "" {
+		t.Fatalf("expected tooltips restored to original, got %q", got)
+	}
+}
@@ -1,6 +1,9 @@
 package core
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // ComponentAccessor is implemented by widget wrappers that expose an underlying component.
 type ComponentAccessor interface {
@@ -26,10 +29,51 @@ func ComponentFrom(obj *GObject) *GComponent {
 	return componentFromObject(obj)
 }
 
+// templateHolder 是把资源内容放在模板组件中的控件（GButton、GLabel 等），
+// 其子对象位于模板组件而不是控件自身的组件中
+type templateHolder interface {
+	TemplateComponent() *GComponent
+}
+
+// ContentOf 返回子对象、控制器和动效所在的组件：按钮、标签等控件把资源内容放在模板组件中，
+// 此时返回模板组件，否则返回 comp 本身
+func ContentOf(comp *GComponent) *GComponent {
+	if comp == nil {
+		return nil
+	}
+	if holder, ok := comp.Data().(templateHolder); ok {
+		if tpl := holder.TemplateComponent(); tpl != nil {
+			return tpl
+		}
+	}
+	return comp
+}
+
+// childByName 在组件中查找子对象，找不到时继续在模板组件中查找
+func childByName(comp *GComponent, name string) *GObject {
+	if obj := comp.ChildByName(name); obj != nil {
+		return obj
+	}
+	if tpl := ContentOf(comp); tpl != comp {
+		return tpl.ChildByName(name)
+	}
+	return nil
+}
+
 // FindChildByPath resolves nested children using dot-separated names.
+// 路径可以穿过嵌套组件以及按钮、标签等控件的模板组件
 func FindChildByPath(comp *GComponent, path string) *GObject {
-	if comp == nil || path == "" {
-		return nil
+	obj, _ := findChildByPath(comp, path)
+	return obj
+}
+
+// findChildByPath 解析路径，失败时返回说明失败的路径段的错误
+func findChildByPath(comp *GComponent, path string) (*GObject, error) {
+	if comp == nil {
+		return nil, fmt.Errorf("core: find %q: component is nil", path)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("core: find child: empty path")
 	}
 	segments := strings.Split(path, ".")
 	current := comp
@@ -38,18 +82,98 @@ func FindChildByPath(comp *GComponent, path string) *GObject {
 		if segment == "" {
 			continue
 		}
-		obj = current.ChildByName(segment)
+		obj = childByName(current, segment)
 		if obj == nil {
-			return nil
+			return nil, fmt.Errorf("core: find %q: child %q not found", path, strings.Join(segments[:idx+1], "."))
 		}
 		if idx == len(segments)-1 {
-			return obj
+			return obj, nil
 		}
 		nested := componentFromObject(obj)
 		if nested == nil {
-			return nil
+			return nil, fmt.Errorf("core: find %q: %q is %T, not a component", path, strings.Join(segments[:idx+1], "."), obj.Data())
 		}
 		current = nested
 	}
-	return obj
+	if obj == nil {
+		return nil, fmt.Errorf("core: find child: empty path")
+	}
+	return obj, nil
+}
+
+// WidgetOf 返回对象对应的 T 类型控件，例如 *widgets.GButton、*widgets.GList。
+// 依次尝试自定义扩展实例、Data 中的控件、对象本身（*GObject）和其组件（*GComponent），
+// 也可以使用接口类型，例如 interface{ SetText(string) }
+func WidgetOf[T any](obj *GObject) (T, error) {
+	var zero T
+	if obj == nil {
+		return zero, fmt.Errorf("core: object is nil, want %T", zero)
+	}
+	if w, ok := widgetOf[T](obj); ok {
+		return w, nil
+	}
+	return zero, fmt.Errorf("core: object %q is %T, want %T", obj.Name(), obj.Data(), zero)
+}
+
+func widgetOf[T any](obj *GObject) (T, bool) {
+	if w, ok := obj.Extension().(T); ok {
+		return w, true
+	}
+	if w, ok := obj.Data().(T); ok {
+		return w, true
+	}
+	if w, ok := any(obj).(T); ok {
+		return w, true
+	}
+	if comp := componentFromObject(obj); comp != nil {
+		if w, ok := any(comp).(T); ok {
+			return w, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// GetChildAs 按 FindChildByPath 的点分路径查找子对象并返回 T 类型的控件，
+// 失败时的错误说明找不到的路径段或子对象的实际类型
+func GetChildAs[T any](comp *GComponent, path string) (T, error) {
+	var zero T
+	obj, err := findChildByPath(comp, path)
+	if err != nil {
+		return zero, err
+	}
+	if w, ok := widgetOf[T](obj); ok {
+		return w, nil
+	}
+	return zero, fmt.Errorf("core: find %q: child is %T, want %T", path, obj.Data(), zero)
+}
+
+// FindAll 按深度优先顺序返回 root 的所有后代中类型为 T 的控件（不包括 root 自身），
+// 会进入嵌套组件、列表项和控件的模板组件。模板组件与所属控件是同一个对象，不重复返回
+func FindAll[T any](root *GComponent) []T {
+	var out []T
+	var walk func(comp *GComponent)
+	walk = func(comp *GComponent) {
+		var tpl *GComponent
+		if holder, ok := comp.Data().(templateHolder); ok {
+			tpl = holder.TemplateComponent()
+		}
+		for _, child := range comp.Children() {
+			if child == nil {
+				continue
+			}
+			if tpl == nil || child != tpl.GObject {
+				if w, ok := widgetOf[T](child); ok {
+					out = append(out, w)
+				}
+			}
+			if nested := componentFromObject(child); nested != nil && nested != comp {
+				walk(nested)
+			}
+		}
+	}
+	if root != nil {
+		walk(root)
+	}
+	return out
 }
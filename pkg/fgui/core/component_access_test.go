@@ -0,0 +1,115 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeButton 模拟把子对象放在模板组件中的控件（widgets.GButton）
+type fakeButton struct {
+	*GComponent
+	template *GComponent
+}
+
+func (b *fakeButton) ComponentRoot() *GComponent     { return b.GComponent }
+func (b *fakeButton) TemplateComponent() *GComponent { return b.template }
+
+func newFakeButton(name string) *fakeButton {
+	// 与构建器一致：模板组件由另一个内置按钮控件承载
+	tpl := &fakeButton{GComponent: NewGComponent()}
+	tpl.SetData(tpl)
+	btn := &fakeButton{GComponent: newNamedComponent(name), template: tpl.GComponent}
+	btn.SetData(btn)
+	btn.AddChild(tpl.GObject)
+	return btn
+}
+
+type fakeText struct{ text string }
+
+func (t *fakeText) SetText(value string) { t.text = value }
+
+func newNamed(name string, data any) *GObject {
+	obj := NewGObject()
+	obj.SetName(name)
+	obj.SetData(data)
+	return obj
+}
+
+func newNamedComponent(name string) *GComponent {
+	comp := NewGComponent()
+	comp.SetName(name)
+	return comp
+}
+
+func buildLookupTree() (*GComponent, *fakeButton, *fakeText) {
+	root := newNamedComponent("root")
+	panel := newNamedComponent("panel")
+	root.AddChild(panel.GObject)
+	btn := newFakeButton("ok")
+	panel.AddChild(btn.GObject)
+	title := &fakeText{}
+	btn.template.AddChild(newNamed("title", title))
+	root.AddChild(newNamed("caption", &fakeText{}))
+	return root, btn, title
+}
+
+func TestGetChildAsCrossesNestedAndTemplateComponents(t *testing.T) {
+	root, btn, title := buildLookupTree()
+	got, err := GetChildAs[*fakeButton](root, "panel.ok")
+	if err != nil || got != btn {
+		t.Fatalf("GetChildAs button = %v, %v", got, err)
+	}
+	text, err := GetChildAs[*fakeText](root, "panel.ok.title")
+	if err != nil || text != title {
+		t.Fatalf("expected the title inside the template, got %v, %v", text, err)
+	}
+	if FindChildByPath(root, "panel.ok.title") == nil {
+		t.Fatalf("expected FindChildByPath to cross the template component")
+	}
+	// 接口类型、组件和对象本身
+	if setter, err := GetChildAs[interface{ SetText(string) }](root, "caption"); err != nil || setter == nil {
+		t.Fatalf("expected interface lookup to succeed: %v", err)
+	}
+	if comp, err := GetChildAs[*GComponent](root, "panel.ok"); err != nil || comp != btn.GComponent {
+		t.Fatalf("expected the button component, got %v, %v", comp, err)
+	}
+	if obj, err := WidgetOf[*GObject](btn.GObject); err != nil || obj != btn.GObject {
+		t.Fatalf("expected the object itself, got %v, %v", obj, err)
+	}
+}
+
+func TestGetChildAsErrors(t *testing.T) {
+	root, _, _ := buildLookupTree()
+	for path, want := range map[string]string{
+		"panel.missing.title": `core: find "panel.missing.title": child "panel.missing" not found`,
+		"caption.title":       `core: find "caption.title": "caption" is *core.fakeText, not a component`,
+		"panel.ok":            `core: find "panel.ok": child is *core.fakeButton, want *core.fakeText`,
+	} {
+		_, err := GetChildAs[*fakeText](root, path)
+		if err == nil || err.Error() != want {
+			t.Errorf("GetChildAs(%q) error = %v, want %q", path, err, want)
+		}
+	}
+	if _, err := GetChildAs[*fakeText](nil, "a"); err == nil {
+		t.Errorf("expected an error for a nil component")
+	}
+	_, err := WidgetOf[*fakeButton](root.GetChild("caption"))
+	if err == nil || !strings.Contains(err.Error(), `object "caption" is *core.fakeText, want *core.fakeButton`) {
+		t.Errorf("unexpected WidgetOf error %v", err)
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	root, btn, title := buildLookupTree()
+	buttons := FindAll[*fakeButton](root)
+	if len(buttons) != 1 || buttons[0] != btn {
+		t.Fatalf("expected the button once (not again for its template), got %d", len(buttons))
+	}
+	texts := FindAll[*fakeText](root)
+	if len(texts) != 2 || texts[0] != title {
+		t.Fatalf("expected both texts in depth-first order, got %v", texts)
+	}
+	if got := FindAll[*fakeText](nil); got != nil {
+		t.Fatalf("expected nil for a nil root")
+	}
+}
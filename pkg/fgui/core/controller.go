@@ -272,6 +272,20 @@ func (c *Controller) RemoveSelectionListener(id int) {
 	}
 }
 
+// dispose 移除全部监听器和动作，由所属组件释放时调用。
+func (c *Controller) dispose() {
+	c.listeners = nil
+	c.actions = nil
+}
+
+// ListenerCount returns the number of registered selection listeners.
+func (c *Controller) ListenerCount() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.listeners)
+}
+
 func (c *Controller) notifySelectionChanged() {
 	if c == nil || len(c.listeners) == 0 {
 		return
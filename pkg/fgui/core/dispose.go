@@ -0,0 +1,111 @@
+package core
+
+import "github.com/chslink/fairygui/pkg/fgui/tween"
+
+// Disposer 由需要在对象释放时清理资源的控件实现（ticker、舞台监听、控制器监听等）。
+// GObject.Dispose 在释放子对象之前调用对象扩展或 Data 上的 HandleDispose。
+type Disposer interface {
+	HandleDispose()
+}
+
+// Dispose 递归释放对象：调用控件的 HandleDispose，释放组件的动效、控制器、滚动面板和子对象，
+// 停止以对象为目标的补间，解除双向的关联，从父组件、弹出栈和显示列表中移除，并清空显示对象上的监听器。
+// 重复调用无效。
+// 对应 TypeScript 版本 GObject.dispose() / GComponent.dispose()
+func (g *GObject) Dispose() {
+	if g == nil || g.disposed {
+		return
+	}
+	g.disposed = true
+
+	// 扩展实例内嵌内置控件，优先调用以便自定义类型覆盖 HandleDispose
+	if handler, ok := g.extension.(Disposer); ok {
+		handler.HandleDispose()
+	} else if handler, ok := g.data.(Disposer); ok {
+		handler.HandleDispose()
+	}
+	hooks := g.disposeHooks
+	g.disposeHooks = nil
+	for _, fn := range hooks {
+		fn()
+	}
+	if comp := ComponentFrom(g); comp != nil && comp.GObject == g {
+		comp.dispose()
+	}
+
+	if g.parent != nil {
+		g.parent.RemoveChild(g)
+	}
+	if g.drag != nil {
+		g.StopDrag()
+	}
+	tween.Kill(g, false)
+	if g.relations != nil {
+		g.relations.ClearAll()
+	}
+	for _, dep := range append([]*RelationItem(nil), g.dependents...) {
+		if dep != nil && dep.owner != nil && dep.owner.relations != nil {
+			dep.owner.relations.ClearFor(g)
+		}
+	}
+	g.dependents = nil
+	g.group = nil
+	if rootInst != nil {
+		rootInst.forget(g)
+	}
+	if g.display != nil {
+		g.display.Destroy()
+	}
+}
+
+// OnDispose 注册对象释放时的回调，用于清理对象之外为它保存的状态（例如只供该对象使用的包）。
+// 回调在 HandleDispose 之后、释放子对象之前按注册顺序调用；对象已释放时立即调用。
+func (g *GObject) OnDispose(fn func()) {
+	if g == nil || fn == nil {
+		return
+	}
+	if g.disposed {
+		fn()
+		return
+	}
+	g.disposeHooks = append(g.disposeHooks, fn)
+}
+
+// IsDisposed 报告对象是否已经释放。补间管理器据此自动移除以已释放对象为目标的补间。
+func (g *GObject) IsDisposed() bool {
+	return g != nil && g.disposed
+}
+
+// dispose 释放组件持有的动效、控制器、滚动面板和子对象，由 GObject.Dispose 调用
+func (c *GComponent) dispose() {
+	for _, tx := range c.transitionList {
+		if tx != nil {
+			tx.dispose()
+		}
+	}
+	c.transitionList = nil
+	c.transitionCache = nil
+
+	for _, ctrl := range c.controllers {
+		if ctrl != nil {
+			ctrl.dispose()
+		}
+	}
+
+	if c.scrollPane != nil {
+		c.scrollPane.Dispose()
+	}
+
+	// 与 TypeScript 版本相同，先解除父子关系再逐个释放，避免逐个移除时的边界重算
+	children := c.children
+	c.children = nil
+	c.sortingChildCount = 0
+	for i := len(children) - 1; i >= 0; i-- {
+		if child := children[i]; child != nil {
+			child.parent = nil
+			child.Dispose()
+		}
+	}
+	c.mask = nil
+	c.boundsChanged = false
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+	"github.com/chslink/fairygui/internal/compat/laya/testutil"
+	"github.com/chslink/fairygui/pkg/fgui/tween"
+)
+
+type disposeCounts struct {
+	tweens, tickers, stageListeners int
+}
+
+func countDisposeLeaks(stage *laya.Stage) disposeCounts {
+	return disposeCounts{tween.ActiveCount(), TickerCount(), stage.Root().ListenerCount()}
+}
+
+func TestDisposeReleasesComponentTree(t *testing.T) {
+	env := testutil.NewStageEnv(t, 400, 300)
+	stage := env.Stage
+	prevStage := Root().Stage()
+	Root().AttachStage(stage)
+	defer Root().AttachStage(prevStage)
+
+	parent := NewGComponent()
+	parent.SetSize(400, 300)
+	stage.AddChild(parent.DisplayObject())
+	sibling := NewGObject()
+	parent.AddChild(sibling)
+	baseline := countDisposeLeaks(stage)
+
+	comp := NewGComponent()
+	comp.SetPosition(10, 10)
+	comp.SetSize(120, 120)
+	parent.AddChild(comp.GObject)
+	pane := comp.EnsureScrollPane(ScrollTypeBoth)
+	pane.SetContentSize(320, 260)
+	child := NewGObject()
+	child.SetResourceID("child")
+	comp.AddChild(child)
+	comp.AddTransition(TransitionInfo{
+		Name: "move",
+		Items: []TransitionItem{{TargetID: "child", Type: TransitionActionXY,
+			Tween: &TransitionTween{Duration: 1, End: TransitionValue{B1: true, B2: true, F1: 100, F2: 100}}}},
+		TotalDuration: 1,
+	})
+	comp.Transition("move").Play(1, 0)
+	tween.To(0, 1, 1).SetTarget(child, "alpha")
+	comp.AddRelation(parent.GObject, RelationTypeWidth, false)
+	sibling.AddRelation(comp.GObject, RelationTypeLeft_Right, false)
+
+	// 按下后不松开：滚动面板在舞台上注册拖动监听；滚动动画注册 ticker
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 60, Y: 60, Primary: true})
+	pane.tweenChange.X, pane.tweenDuration.X = 50, 1
+	pane.startTween(2)
+	if got := countDisposeLeaks(stage); got.tweens <= baseline.tweens || got.tickers <= baseline.tickers || got.stageListeners <= baseline.stageListeners {
+		t.Fatalf("expected the setup to register tweens, tickers and listeners, got %+v", got)
+	}
+
+	comp.Dispose()
+	if !comp.IsDisposed() || !child.IsDisposed() {
+		t.Fatalf("expected the component and its children to be disposed")
+	}
+	if comp.Parent() != nil || comp.NumChildren() != 0 || parent.NumChildren() != 1 {
+		t.Fatalf("expected the component to be removed from its parent and emptied")
+	}
+	if comp.DisplayObject().Parent() != nil || comp.DisplayObject().ListenerCount() != 0 {
+		t.Fatalf("expected the display object to be detached and cleared")
+	}
+	if sibling.Relations().Contains(comp.GObject) || len(parent.dependents) != 0 {
+		t.Fatalf("expected relations in both directions to be cleared")
+	}
+	if got := countDisposeLeaks(stage); got != baseline {
+		t.Fatalf("leaked after dispose: %+v, baseline %+v", got, baseline)
+	}
+	comp.Dispose()
+}
+
+type disposeRecorder struct {
+	*GComponent
+	calls int
+}
+
+func (r *disposeRecorder) HandleDispose() { r.calls++ }
+
+func TestDisposeCallsHandlerOnceAndForgetsPopup(t *testing.T) {
+	rec := &disposeRecorder{GComponent: NewGComponent()}
+	rec.SetData(rec)
+	Root().ShowPopup(rec.GObject, nil, PopupDirectionAuto)
+	if !Root().HasAnyPopup() {
+		t.Fatalf("expected the popup to be shown")
+	}
+	rec.Dispose()
+	rec.Dispose()
+	if rec.calls != 1 {
+		t.Fatalf("expected HandleDispose to run once, got %d", rec.calls)
+	}
+	if Root().HasAnyPopup() {
+		t.Fatalf("expected the disposed popup to be removed from the popup stack")
+	}
+}
+
+func TestOnDisposeRunsHooksOnce(t *testing.T) {
+	rec := &disposeRecorder{GComponent: NewGComponent()}
+	rec.SetData(rec)
+	var order []string
+	rec.OnDispose(func() { order = append(order, fmt.Sprintf("first %d", rec.calls)) })
+	rec.OnDispose(func() { order = append(order, "second") })
+	rec.Dispose()
+	rec.Dispose()
+	if len(order) != 2 || order[0] != "first 1" || order[1] != "second" {
+		t.Fatalf("expected hooks to run once in order after HandleDispose, got %v", order)
+	}
+	called := false
+	rec.OnDispose(func() { called = true })
+	if !called {
+		t.Fatalf("expected a hook registered after dispose to run immediately")
+	}
+}
@@ -0,0 +1,295 @@
+package core
+
+import (
+	"math"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+)
+
+var (
+	// draggingObject 是当前正在拖动的对象，同一时刻只有一个，对应 TypeScript 版本 GObject.draggingObject
+	draggingObject *GObject
+	// draggingQuery 在派发 DRAG_START 期间为 true；处理器调用 StopDrag 会将其清除以取消拖动
+	draggingQuery bool
+)
+
+// dragState 保存对象的拖动状态，首次设置 Draggable、DragBounds 或调用 StartDrag 时创建
+type dragState struct {
+	bounds *laya.Rect
+
+	// testing 表示已按下但尚未超过拖动阈值
+	testing bool
+	touch   bool
+	touchID int
+	// 按下时的舞台坐标，用于判断拖动阈值
+	beginX, beginY float64
+	// 开始拖动时指针在父组件中的坐标，以及对象的位置
+	pointerX, pointerY float64
+	objX, objY         float64
+	// 最近处理的指针位置；冒泡到舞台的移动事件与舞台自身的移动事件会重复到达
+	lastX, lastY float64
+
+	beginID  laya.ListenerID
+	stage    *laya.Sprite
+	moveID   laya.ListenerID
+	endID    laya.ListenerID
+	cancelID laya.ListenerID
+}
+
+func (g *GObject) dragInfo() *dragState {
+	if g.drag == nil {
+		g.drag = &dragState{}
+	}
+	return g.drag
+}
+
+// DraggingObject 返回当前正在拖动的对象，没有时返回 nil。
+// 对应 TypeScript 版本 GObject.draggingObject
+func DraggingObject() *GObject {
+	return draggingObject
+}
+
+// Draggable 报告对象是否可以被按住拖动。
+func (g *GObject) Draggable() bool {
+	return g != nil && g.draggable
+}
+
+// SetDraggable 设置对象是否可以被按住拖动。按下后移动超过 UIConfig 的拖动阈值时派发
+// laya.EventDragStart，之后跟随指针移动并派发 EventDragMove，松开时派发 EventDragEnd。
+// 对应 TypeScript 版本 GObject.draggable
+func (g *GObject) SetDraggable(value bool) {
+	if g == nil || g.draggable == value {
+		return
+	}
+	g.draggable = value
+	info := g.dragInfo()
+	if value {
+		info.beginID = g.OnWithID(laya.EventTouchBegin, g.onDragTouchBegin)
+		return
+	}
+	g.OffByID(laya.EventTouchBegin, info.beginID)
+	info.beginID = 0
+	if info.testing {
+		g.resetDrag()
+	}
+}
+
+// DragBounds 返回拖动范围（父组件坐标），未设置时返回 nil。
+func (g *GObject) DragBounds() *laya.Rect {
+	if g == nil || g.drag == nil || g.drag.bounds == nil {
+		return nil
+	}
+	rect := *g.drag.bounds
+	return &rect
+}
+
+// SetDragBounds 设置拖动范围。范围使用父组件坐标，拖动时对象的边框保持在范围内；传入 nil 取消限制。
+// 对应 TypeScript 版本 GObject.dragBounds（TypeScript 版本使用 GRoot 坐标）
+func (g *GObject) SetDragBounds(rect *laya.Rect) {
+	if g == nil {
+		return
+	}
+	if rect == nil {
+		if g.drag != nil {
+			g.drag.bounds = nil
+		}
+		return
+	}
+	bounds := *rect
+	g.dragInfo().bounds = &bounds
+}
+
+// Dragging 报告对象是否正在被拖动。
+func (g *GObject) Dragging() bool {
+	return g != nil && draggingObject == g
+}
+
+// StartDrag 立即开始拖动对象，跟随指定的触摸点（鼠标使用按下时事件中的 TouchID）。
+// 对象不需要设置 Draggable；正在拖动的其他对象会先停止并收到 EventDragEnd。
+// 对应 TypeScript 版本 GObject.startDrag()
+func (g *GObject) StartDrag(touchID int) {
+	if g == nil || g.disposed || !g.onStage() {
+		return
+	}
+	g.dragBegin(touchID)
+}
+
+// StopDrag 停止拖动，不派发 EventDragEnd。在 EventDragStart 处理器中调用可以取消本次拖动。
+// 对应 TypeScript 版本 GObject.stopDrag()
+func (g *GObject) StopDrag() {
+	if g == nil {
+		return
+	}
+	if draggingObject == g {
+		draggingObject = nil
+	}
+	if g.drag != nil {
+		g.resetDrag()
+	}
+	draggingQuery = false
+}
+
+func (g *GObject) onStage() bool {
+	stage := Root().Stage()
+	if stage == nil || g.display == nil {
+		return false
+	}
+	for sprite := g.display; sprite != nil; sprite = sprite.Parent() {
+		if sprite == stage.Root() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GObject) dragBegin(touchID int) {
+	if prev := draggingObject; prev != nil {
+		prev.StopDrag()
+		prev.Emit(laya.EventDragEnd, laya.PointerEvent{TouchID: touchID})
+	}
+	stage := Root().Stage()
+	pointer := stage.PointerPosition(touchID)
+	local := g.dragParentLocal(pointer)
+
+	info := g.dragInfo()
+	info.testing = false
+	info.touchID = touchID
+	info.pointerX, info.pointerY = local.X, local.Y
+	info.lastX, info.lastY = pointer.X, pointer.Y
+	info.objX, info.objY = g.x, g.y
+	draggingObject = g
+	g.listenStageDrag(stage)
+}
+
+// onDragTouchBegin 记录按下的位置并开始检测拖动阈值，对应 TypeScript 版本 GObject.__begin
+func (g *GObject) onDragTouchBegin(evt *laya.Event) {
+	pe, ok := evt.Data.(laya.PointerEvent)
+	if !ok || draggingObject == g {
+		return
+	}
+	stage := Root().Stage()
+	if stage == nil {
+		return
+	}
+	info := g.dragInfo()
+	info.testing = true
+	info.touchID = pe.TouchID
+	// 鼠标事件带有按键状态，触摸事件没有
+	info.touch = !pe.Buttons.Left && !pe.Buttons.Right && !pe.Buttons.Middle
+	info.beginX, info.beginY = pe.Position.X, pe.Position.Y
+	info.lastX, info.lastY = pe.Position.X, pe.Position.Y
+	g.listenStageDrag(stage)
+}
+
+func (g *GObject) listenStageDrag(stage *laya.Stage) {
+	info := g.drag
+	if info.stage != nil {
+		return
+	}
+	info.stage = stage.Root()
+	dispatcher := info.stage.Dispatcher()
+	info.moveID = dispatcher.OnWithID(laya.EventTouchMove, g.onDragMove)
+	info.endID = dispatcher.OnWithID(laya.EventTouchEnd, g.onDragEnd)
+	info.cancelID = dispatcher.OnWithID(laya.EventTouchCancel, g.onDragEnd)
+}
+
+// resetDrag 移除舞台上的拖动监听，对应 TypeScript 版本 GObject.reset()
+func (g *GObject) resetDrag() {
+	info := g.drag
+	info.testing = false
+	if info.stage == nil {
+		return
+	}
+	dispatcher := info.stage.Dispatcher()
+	dispatcher.OffByID(laya.EventTouchMove, info.moveID)
+	dispatcher.OffByID(laya.EventTouchEnd, info.endID)
+	dispatcher.OffByID(laya.EventTouchCancel, info.cancelID)
+	info.stage = nil
+	info.moveID, info.endID, info.cancelID = 0, 0, 0
+}
+
+// onDragMove 对应 TypeScript 版本 GObject.__moving
+func (g *GObject) onDragMove(evt *laya.Event) {
+	info := g.drag
+	pe, ok := evt.Data.(laya.PointerEvent)
+	if !ok || info == nil || pe.TouchID != info.touchID {
+		return
+	}
+	if pe.Position.X == info.lastX && pe.Position.Y == info.lastY {
+		return
+	}
+	info.lastX, info.lastY = pe.Position.X, pe.Position.Y
+	if draggingObject != g && g.draggable && info.testing {
+		cfg := GetUIConfig()
+		sensitivity := cfg.ClickDragSensitivity
+		if info.touch {
+			sensitivity = cfg.TouchDragSensitivity
+		}
+		if math.Abs(pe.Position.X-info.beginX) < sensitivity && math.Abs(pe.Position.Y-info.beginY) < sensitivity {
+			return
+		}
+		info.testing = false
+		draggingQuery = true
+		g.Emit(laya.EventDragStart, pe)
+		if !draggingQuery {
+			return
+		}
+		draggingQuery = false
+		g.dragBegin(pe.TouchID)
+	}
+	if draggingObject != g {
+		return
+	}
+
+	local := g.dragParentLocal(pe.Position)
+	x := info.objX + local.X - info.pointerX
+	y := info.objY + local.Y - info.pointerY
+	if b := info.bounds; b != nil {
+		// 拖动范围限制的是对象的边框；以中心为锚点的对象边框相对位置有偏移
+		var offX, offY float64
+		if g.pivotAsAnchor {
+			offX, offY = g.pivotX*g.ActualWidth(), g.pivotY*g.ActualHeight()
+		}
+		x = clampDrag(x-offX, g.ActualWidth(), b.X, b.W) + offX
+		y = clampDrag(y-offY, g.ActualHeight(), b.Y, b.H) + offY
+	}
+	g.SetPosition(math.Round(x), math.Round(y))
+	g.Emit(laya.EventDragMove, pe)
+}
+
+// clampDrag 把长度为 size 的区间限制在 [start, start+length] 内；区间放不下时与起点对齐
+func clampDrag(pos, size, start, length float64) float64 {
+	if pos+size > start+length {
+		pos = start + length - size
+	}
+	if pos < start {
+		pos = start
+	}
+	return pos
+}
+
+// onDragEnd 对应 TypeScript 版本 GObject.__end
+func (g *GObject) onDragEnd(evt *laya.Event) {
+	info := g.drag
+	pe, ok := evt.Data.(laya.PointerEvent)
+	if !ok || info == nil || pe.TouchID != info.touchID {
+		return
+	}
+	if draggingObject == g {
+		draggingObject = nil
+		g.resetDrag()
+		g.Emit(laya.EventDragEnd, pe)
+	} else if info.testing {
+		g.resetDrag()
+	}
+}
+
+func (g *GObject) dragParentLocal(pt laya.Point) laya.Point {
+	if g.parent != nil && g.parent.DisplayObject() != nil {
+		return g.parent.DisplayObject().GlobalToLocal(pt)
+	}
+	if parent := g.display.Parent(); parent != nil {
+		return parent.GlobalToLocal(pt)
+	}
+	return pt
+}
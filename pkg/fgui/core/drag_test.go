@@ -0,0 +1,226 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+	"github.com/chslink/fairygui/internal/compat/laya/testutil"
+)
+
+// pressTouchID 返回最近一次按下的触摸 ID；鼠标按下也分配递增的 ID
+func pressTouchID(env *testutil.StageEnv) func() int {
+	id := 0
+	env.Stage.Root().Dispatcher().On(laya.EventStageMouseDown, func(evt *laya.Event) {
+		id = evt.Data.(laya.PointerEvent).TouchID
+	})
+	return func() int { return id }
+}
+
+func pressAt(x, y float64) laya.MouseState {
+	return laya.MouseState{X: x, Y: y, Primary: true}
+}
+
+type dragEventLog struct {
+	start, move, end int
+}
+
+func recordDragEvents(obj *GObject) *dragEventLog {
+	log := &dragEventLog{}
+	obj.On(laya.EventDragStart, func(*laya.Event) { log.start++ })
+	obj.On(laya.EventDragMove, func(*laya.Event) { log.move++ })
+	obj.On(laya.EventDragEnd, func(*laya.Event) { log.end++ })
+	return log
+}
+
+func TestDraggableFollowsPointerWithinBounds(t *testing.T) {
+	env := attachTestStage(t)
+	parent := NewGComponent()
+	parent.SetPosition(50, 50)
+	parent.SetSize(200, 200)
+	Root().AddChild(parent.GObject)
+	defer parent.Dispose()
+	baseline := env.Stage.Root().ListenerCount()
+
+	obj := NewGObject()
+	obj.SetPosition(10, 10)
+	obj.SetSize(20, 20)
+	parent.AddChild(obj)
+	obj.SetDraggable(true)
+	obj.SetDragBounds(&laya.Rect{X: 0, Y: 0, W: 100, H: 100})
+	log := recordDragEvents(obj)
+
+	env.Advance(16*time.Millisecond, pressAt(65, 65))
+	env.Advance(16*time.Millisecond, pressAt(66, 65))
+	if log.start != 0 || obj.Dragging() {
+		t.Fatalf("expected no drag below the click sensitivity")
+	}
+	env.Advance(16*time.Millisecond, pressAt(75, 75))
+	if log.start != 1 || !obj.Dragging() || DraggingObject() != obj {
+		t.Fatalf("expected the drag to start past the sensitivity, log %+v", log)
+	}
+	env.Advance(16*time.Millisecond, pressAt(95, 85))
+	if obj.X() != 30 || obj.Y() != 20 {
+		t.Fatalf("expected the object to follow the pointer, got %v,%v", obj.X(), obj.Y())
+	}
+	// 范围使用父组件坐标，对象的右下角不能超出范围
+	env.Advance(16*time.Millisecond, pressAt(500, 500))
+	if obj.X() != 80 || obj.Y() != 80 {
+		t.Fatalf("expected the object to be clamped to the bounds, got %v,%v", obj.X(), obj.Y())
+	}
+	env.Advance(16*time.Millisecond, pressAt(0, 0))
+	if obj.X() != 0 || obj.Y() != 0 {
+		t.Fatalf("expected the object to be clamped to the bounds origin, got %v,%v", obj.X(), obj.Y())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 0, Y: 0})
+	if log.end != 1 || obj.Dragging() || DraggingObject() != nil {
+		t.Fatalf("expected the drag to end on release, log %+v", log)
+	}
+	if log.move != 4 {
+		t.Fatalf("expected a move event per pointer move while dragging, got %d", log.move)
+	}
+	if got := env.Stage.Root().ListenerCount(); got != baseline {
+		t.Fatalf("expected the stage listeners to be removed, got %d want %d", got, baseline)
+	}
+}
+
+func TestDragStartCanBeCancelled(t *testing.T) {
+	env := attachTestStage(t)
+	baseline := env.Stage.Root().ListenerCount()
+	obj := NewGObject()
+	obj.SetSize(50, 50)
+	Root().AddChild(obj)
+	defer obj.Dispose()
+	obj.SetDraggable(true)
+	obj.On(laya.EventDragStart, func(*laya.Event) { obj.StopDrag() })
+	log := recordDragEvents(obj)
+
+	env.Advance(16*time.Millisecond, pressAt(10, 10))
+	env.Advance(16*time.Millisecond, pressAt(30, 30))
+	env.Advance(16*time.Millisecond, pressAt(40, 40))
+	if log.start != 1 || log.move != 0 || obj.Dragging() || obj.X() != 0 {
+		t.Fatalf("expected the cancelled drag not to move the object, log %+v", log)
+	}
+	if got := env.Stage.Root().ListenerCount(); got != baseline {
+		t.Fatalf("expected the stage listeners to be removed after cancelling, got %d want %d", got, baseline)
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 40, Y: 40})
+	if log.end != 0 {
+		t.Fatalf("expected no drag end for a cancelled drag")
+	}
+}
+
+func touchAt(id int, x, y float64, phase laya.TouchPhase) laya.InputState {
+	return laya.InputState{Touches: []laya.TouchInput{{ID: id, Position: laya.Point{X: x, Y: y}, Phase: phase, Primary: true}}}
+}
+
+func TestDragFollowsItsOwnTouch(t *testing.T) {
+	env := attachTestStage(t)
+	obj := NewGObject()
+	obj.SetSize(50, 50)
+	Root().AddChild(obj)
+	defer obj.Dispose()
+	obj.SetDraggable(true)
+	log := recordDragEvents(obj)
+
+	env.AdvanceInput(16*time.Millisecond, touchAt(7, 10, 10, laya.TouchPhaseBegin))
+	env.AdvanceInput(16*time.Millisecond, touchAt(8, 100, 100, laya.TouchPhaseMove))
+	// 触摸的拖动阈值比鼠标大
+	env.AdvanceInput(16*time.Millisecond, touchAt(7, 15, 15, laya.TouchPhaseMove))
+	if log.start != 0 {
+		t.Fatalf("expected no drag from another touch or below the touch sensitivity")
+	}
+	env.AdvanceInput(16*time.Millisecond, touchAt(7, 30, 30, laya.TouchPhaseMove))
+	env.AdvanceInput(16*time.Millisecond, touchAt(7, 40, 50, laya.TouchPhaseMove))
+	env.AdvanceInput(16*time.Millisecond, touchAt(8, 200, 200, laya.TouchPhaseEnd))
+	if log.start != 1 || !obj.Dragging() || obj.X() != 10 || obj.Y() != 20 {
+		t.Fatalf("expected touch 7 to drag the object, got %v,%v log %+v", obj.X(), obj.Y(), log)
+	}
+	env.AdvanceInput(16*time.Millisecond, touchAt(7, 40, 50, laya.TouchPhaseCancel))
+	if log.end != 1 || obj.Dragging() {
+		t.Fatalf("expected a cancelled touch to end the drag")
+	}
+}
+
+func TestStartDragStopsPreviousDrag(t *testing.T) {
+	env := attachTestStage(t)
+	touchID := pressTouchID(env)
+	first, second := NewGObject(), NewGObject()
+	Root().AddChild(first)
+	Root().AddChild(second)
+	defer first.Dispose()
+	defer second.Dispose()
+	firstLog := recordDragEvents(first)
+
+	env.Advance(16*time.Millisecond, pressAt(10, 10))
+	first.StartDrag(touchID())
+	second.StartDrag(touchID())
+	if first.Dragging() || !second.Dragging() || firstLog.end != 1 {
+		t.Fatalf("expected the first drag to end when the second starts")
+	}
+	second.Dispose()
+	if DraggingObject() != nil {
+		t.Fatalf("expected dispose to stop the drag")
+	}
+	NewGObject().StartDrag(touchID())
+	if DraggingObject() != nil {
+		t.Fatalf("expected objects off the stage not to start dragging")
+	}
+}
+
+func TestWindowDragsByDragArea(t *testing.T) {
+	env := attachTestStage(t)
+	pane := NewGComponent()
+	pane.SetSize(200, 150)
+	dragArea := NewGObject()
+	dragArea.SetName("dragArea")
+	dragArea.SetSize(200, 30)
+	pane.AddChild(dragArea)
+
+	win := NewWindow()
+	win.SetContentPane(pane)
+	win.SetPosition(20, 20)
+	win.Show()
+	defer win.Dispose()
+
+	env.Advance(16*time.Millisecond, pressAt(40, 30))
+	env.Advance(16*time.Millisecond, pressAt(50, 40))
+	env.Advance(16*time.Millisecond, pressAt(80, 60))
+	if !win.Dragging() || dragArea.Dragging() {
+		t.Fatalf("expected the window, not the drag area, to be dragged")
+	}
+	if win.X() != 50 || win.Y() != 40 || dragArea.X() != 0 {
+		t.Fatalf("expected the window to follow the pointer, got %v,%v", win.X(), win.Y())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 80, Y: 60})
+	if win.Dragging() {
+		t.Fatalf("expected the window drag to end on release")
+	}
+}
+
+func TestDragDropManagerDropsOnTarget(t *testing.T) {
+	env := attachTestStage(t)
+	touchID := pressTouchID(env)
+	target := NewGObject()
+	target.SetPosition(200, 100)
+	target.SetSize(100, 100)
+	Root().AddChild(target)
+	defer target.Dispose()
+	var dropped any
+	target.On(laya.EventDrop, func(evt *laya.Event) { dropped = evt.Data })
+
+	env.Advance(16*time.Millisecond, pressAt(10, 10))
+	DragDrop().StartDrag(target, "", "payload", touchID())
+	agent := DragDrop().Agent()
+	if agent == nil || !agent.Dragging() || agent.X() != 10 || agent.Y() != 10 {
+		t.Fatalf("expected the agent to start dragging at the pointer")
+	}
+	env.Advance(16*time.Millisecond, pressAt(250, 150))
+	if agent.X() != 250 || agent.Y() != 150 {
+		t.Fatalf("expected the agent to follow the pointer, got %v,%v", agent.X(), agent.Y())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 250, Y: 150})
+	if dropped != "payload" || DragDrop().IsDragging() || !agent.IsDisposed() {
+		t.Fatalf("expected the payload to be dropped on the target, got %v", dropped)
+	}
+}
@@ -16,9 +16,6 @@ type DragDropManager struct {
 	sourceData any
 	dragging   bool
 	touchID    int
-
-	moveListener laya.ListenerID
-	upListener   laya.ListenerID
 }
 
 // DragDrop returns the global drag-drop manager singleton.
@@ -57,34 +54,26 @@ func (d *DragDropManager) StartDrag(source *GObject, icon string, sourceData any
 	d.sourceData = sourceData
 	d.touchID = touchID
 
-	// Create or reuse drag agent (a GObject displayed on top of everything).
+	// Create the drag agent (a GObject displayed on top of everything). It is not
+	// touchable so the drop target under the pointer can be found on release.
 	d.agent = NewGObject()
 	d.agent.SetSortingOrder(1000000)
 	d.agent.SetPivotWithAnchor(0.5, 0.5, false)
 	d.agent.SetSize(100, 100)
-
-	Root().AddChild(d.agent)
-
-	// Position agent at current mouse position.
-	if d.agent.DisplayObject() != nil && d.agent.DisplayObject().Parent() != nil {
-		_ = icon // icon is used in rendering layer; stored for reference
+	d.agent.SetTouchable(false)
+	_ = icon // icon is used in rendering layer; stored for reference
+
+	root := Root()
+	root.AddChild(d.agent)
+	if stage := root.Stage(); stage != nil {
+		local := root.DisplayObject().GlobalToLocal(stage.PointerPosition(touchID))
+		d.agent.SetPosition(local.X, local.Y)
 	}
 
 	d.dragging = true
-
-	// Listen for mouse move to update agent position.
-	stage := Root().Stage()
-	if stage != nil {
-		d.moveListener = stage.Root().Dispatcher().OnWithID(laya.EventMouseMove, func(evt *laya.Event) {
-			if pe, ok := evt.Data.(laya.PointerEvent); ok {
-				local := Root().DisplayObject().GlobalToLocal(laya.Point{X: pe.Position.X, Y: pe.Position.Y})
-				d.agent.SetPosition(local.X, local.Y)
-			}
-		})
-		d.upListener = stage.Root().Dispatcher().OnWithID(laya.EventStageMouseUp, func(evt *laya.Event) {
-			d.onDragEnd(evt)
-		})
-	}
+	// The agent follows the pointer through the built-in object dragging.
+	d.agent.On(laya.EventDragEnd, d.onDragEnd)
+	d.agent.StartDrag(touchID)
 }
 
 // Cancel stops the current drag operation without triggering DROP.
@@ -130,22 +119,11 @@ func (d *DragDropManager) onDragEnd(evt *laya.Event) {
 }
 
 func (d *DragDropManager) teardown() {
-	if d.agent != nil && d.agent.Parent() != nil {
-		d.agent.Parent().RemoveChild(d.agent)
+	if d.agent != nil {
+		d.agent.StopDrag()
+		d.agent.Dispose()
 	}
 	d.agent = nil
 	d.dragging = false
 	d.sourceData = nil
-
-	stage := Root().Stage()
-	if stage != nil {
-		if d.moveListener != 0 {
-			stage.Root().Dispatcher().OffByID(laya.EventMouseMove, d.moveListener)
-			d.moveListener = 0
-		}
-		if d.upListener != 0 {
-			stage.Root().Dispatcher().OffByID(laya.EventStageMouseUp, d.upListener)
-			d.upListener = 0
-		}
-	}
 }
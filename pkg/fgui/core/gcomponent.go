@@ -25,8 +25,9 @@ type GComponent struct {
 	sortingChildCount  int // Number of children with sortingOrder > 0
 	margin             Margin
 	overflow           OverflowType
-	boundsChanged      bool // 边界是否需要重新计算（对应 TypeScript _boundsChanged）
-	trackBounds        bool // 是否跟踪子对象边界（对应 TypeScript _trackBounds）
+	boundsChanged      bool       // 边界是否需要重新计算（对应 TypeScript _boundsChanged）
+	trackBounds        bool       // 是否跟踪子对象边界（对应 TypeScript _trackBounds）
+	alignOffset        laya.Point // 内容对齐偏移（对应 TypeScript _alignOffset）
 }
 
 // HitTestMode enumerates supported hit-test strategies.
@@ -255,6 +256,31 @@ func (c *GComponent) Margin() Margin {
 	return c.margin
 }
 
+// AlignOffset 返回内容的对齐偏移。
+func (c *GComponent) AlignOffset() laya.Point {
+	if c == nil {
+		return laya.Point{}
+	}
+	return c.alignOffset
+}
+
+// SetAlignOffset 设置内容的对齐偏移，内容小于视口时用于居中或靠右、靠下显示子对象。
+// 有滚动面板时移动遮罩容器，否则移动子对象容器；子对象自身的坐标不变。
+// 对应 TypeScript 版本 GList.handleAlign() 中对 _alignOffset 的处理
+func (c *GComponent) SetAlignOffset(x, y float64) {
+	if c == nil || (c.alignOffset.X == x && c.alignOffset.Y == y) {
+		return
+	}
+	c.alignOffset = laya.Point{X: x, Y: y}
+	if c.scrollPane != nil {
+		c.scrollPane.adjustMaskContainer()
+		return
+	}
+	if container := c.ensureContainer(); container != nil {
+		container.SetPosition(float64(c.margin.Left)+x, float64(c.margin.Top)+y)
+	}
+}
+
 // Overflow 返回组件的 overflow 类型
 func (c *GComponent) Overflow() OverflowType {
 	if c == nil {
@@ -58,6 +58,7 @@ internalVisible    bool // gear-controlled visibility (separate from user-contro
 	pivotY             float64
 	pivotAsAnchor      bool
 	data               any
+	extension          any
 	relations          *Relations
 	dependents         []*RelationItem
 	gears              [gears.SlotCount]gears.Gear
@@ -75,6 +76,7 @@ internalVisible    bool // gear-controlled visibility (separate from user-contro
 	maxHeight          float64
 	props              map[gears.ObjectPropID]any
 	tooltips           string
+	tooltipsListening  bool
 	group              *GObject
 	colorFilter        [4]float64
 	colorFilterEnabled bool
@@ -83,10 +85,15 @@ internalVisible    bool // gear-controlled visibility (separate from user-contro
 	customData         string
 	blendMode          BlendMode
 	sortingOrder       int // Z-order for rendering and interaction (0 = normal order)
+	draggable          bool
+	drag               *dragState
 
 	// displayLock for preventing GearDisplay from hiding objects during tween animations
 	displayLockToken uint32
 	displayLockCount int
+
+	disposed     bool
+	disposeHooks []func()
 }
 
 type ownerSizeChanged interface {
@@ -962,6 +969,17 @@ func (g *GObject) Data() any {
 	return g.data
 }
 
+// SetExtension 记录由 widgets.SetExtension 注册的自定义组件实例
+func (g *GObject) SetExtension(ext any) {
+	g.extension = ext
+}
+
+// Extension 返回自定义组件实例；对象不是由扩展创建时返回 nil
+// Data 仍然保存内置控件，框架内部的类型断言不受影响
+func (g *GObject) Extension() any {
+	return g.extension
+}
+
 // AsComponent returns the object as *GComponent if it is one, otherwise nil.
 func (g *GObject) AsComponent() *GComponent {
 	if g == nil {
@@ -973,7 +991,9 @@ func (g *GObject) AsComponent() *GComponent {
 	return nil
 }
 
-// AsButton returns the object as *widgets.GButton if it is one, otherwise nil.
+// AsButton returns the object's widget data; callers must type assert it to *widgets.GButton.
+//
+// Deprecated: use WidgetOf[*widgets.GButton](obj), which returns a typed widget and an error.
 func (g *GObject) AsButton() interface{} {
 	if g == nil {
 		return nil
@@ -983,7 +1003,9 @@ func (g *GObject) AsButton() interface{} {
 	return g.data
 }
 
-// AsList returns the object as *widgets.GList if it is one, otherwise nil.
+// AsList returns the object's widget data; callers must type assert it to *widgets.GList.
+//
+// Deprecated: use WidgetOf[*widgets.GList](obj), which returns a typed widget and an error.
 func (g *GObject) AsList() interface{} {
 	if g == nil {
 		return nil
@@ -991,7 +1013,9 @@ func (g *GObject) AsList() interface{} {
 	return g.data
 }
 
-// AsTextField returns the object as *widgets.GTextField if it is one, otherwise nil.
+// AsTextField returns the object's widget data; callers must type assert it to *widgets.GTextField.
+//
+// Deprecated: use WidgetOf[*widgets.GTextField](obj), which returns a typed widget and an error.
 func (g *GObject) AsTextField() interface{} {
 	if g == nil {
 		return nil
@@ -999,7 +1023,9 @@ func (g *GObject) AsTextField() interface{} {
 	return g.data
 }
 
-// AsLabel returns the object as *widgets.GLabel if it is one, otherwise nil.
+// AsLabel returns the object's widget data; callers must type assert it to *widgets.GLabel.
+//
+// Deprecated: use WidgetOf[*widgets.GLabel](obj), which returns a typed widget and an error.
 func (g *GObject) AsLabel() interface{} {
 	if g == nil {
 		return nil
@@ -1007,7 +1033,9 @@ func (g *GObject) AsLabel() interface{} {
 	return g.data
 }
 
-// AsImage returns the object as *widgets.GImage if it is one, otherwise nil.
+// AsImage returns the object's widget data; callers must type assert it to *widgets.GImage.
+//
+// Deprecated: use WidgetOf[*widgets.GImage](obj), which returns a typed widget and an error.
 func (g *GObject) AsImage() interface{} {
 	if g == nil {
 		return nil
@@ -1015,7 +1043,9 @@ func (g *GObject) AsImage() interface{} {
 	return g.data
 }
 
-// AsMovieClip returns the object as *widgets.GMovieClip if it is one, otherwise nil.
+// AsMovieClip returns the object's widget data; callers must type assert it to *widgets.GMovieClip.
+//
+// Deprecated: use WidgetOf[*widgets.GMovieClip](obj), which returns a typed widget and an error.
 func (g *GObject) AsMovieClip() interface{} {
 	if g == nil {
 		return nil
@@ -1023,7 +1053,9 @@ func (g *GObject) AsMovieClip() interface{} {
 	return g.data
 }
 
-// AsLoader returns the object as *widgets.GLoader if it is one, otherwise nil.
+// AsLoader returns the object's widget data; callers must type assert it to *widgets.GLoader.
+//
+// Deprecated: use WidgetOf[*widgets.GLoader](obj), which returns a typed widget and an error.
 func (g *GObject) AsLoader() interface{} {
 	if g == nil {
 		return nil
@@ -1039,12 +1071,17 @@ func (g *GObject) Tooltips() string {
 	return g.tooltips
 }
 
-// SetTooltips updates the tooltip text stored on this object.
+// SetTooltips updates the tooltip text stored on this object. A non-empty value
+// shows the text through GRoot.ShowTooltips while the pointer hovers the object.
+// 对应 TypeScript 版本 GObject.tooltips
 func (g *GObject) SetTooltips(value string) {
 	if g == nil {
 		return
 	}
 	g.tooltips = value
+	if value != "" {
+		g.listenTooltips()
+	}
 }
 
 // SetCustomData stores arbitrary package-defined metadata string.
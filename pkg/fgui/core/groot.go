@@ -44,6 +44,15 @@ type GRoot struct {
 
 	stageMouseDown laya.Listener
 	stageMouseUp   laya.Listener
+	stageWheel     laya.Listener
+	stageCancel    laya.Listener
+
+	objectCreator     ObjectCreator
+	tooltipWin        *GObject
+	defaultTooltipWin *GObject
+	defaultTooltipURL string
+	tooltipOwner      *GObject
+	tooltipTimer      laya.TimerHandle
 }
 
 // NewGRoot constructs a detached root. Use AttachStage to bind it to a stage.
@@ -114,6 +123,33 @@ func (r *GRoot) AdvanceInput(delta time.Duration, input laya.InputState) {
 	r.updateTextInputCursors(delta.Seconds())
 }
 
+// Focus 返回持有键盘焦点的对象，焦点不在 UI 对象上时返回 nil。
+// 舞台的按键事件从焦点对象开始向上冒泡。
+// 对应 TypeScript 版本 GRoot.focus
+func (r *GRoot) Focus() *GObject {
+	if r.stage == nil {
+		return nil
+	}
+	for sprite := r.stage.Focus(); sprite != nil; sprite = sprite.Parent() {
+		if owner := ownerAsGObject(sprite); owner != nil {
+			return owner
+		}
+	}
+	return nil
+}
+
+// SetFocus 把键盘焦点交给对象，传入 nil 清除焦点。
+func (r *GRoot) SetFocus(obj *GObject) {
+	if r.stage == nil {
+		return
+	}
+	if obj == nil || obj.display == nil {
+		r.stage.SetFocus(nil)
+		return
+	}
+	r.stage.SetFocus(obj.display)
+}
+
 // updateTextInputCursors 递归遍历组件树,更新所有 GTextInput 的光标
 func (r *GRoot) updateTextInputCursors(deltaTime float64) {
 	r.visitTextInputs(r.GComponent, deltaTime)
@@ -202,8 +238,18 @@ func (r *GRoot) HideAllPopups() {
 }
 
 // ShowWindow adds a Window to the root and manages the window stack.
+// Showing a window that is playing its hide animation cancels the hide and
+// replays the show animation.
+// 对应 TypeScript 版本 GRoot.showWindow()
 func (r *GRoot) ShowWindow(w *Window) {
-	if w == nil || w.isShowing {
+	if w == nil {
+		return
+	}
+	if w.isShowing {
+		if w.hiding {
+			w.doShowAnimation()
+		}
+		r.BringToFront(w)
 		return
 	}
 	w.isShowing = true
@@ -214,39 +260,27 @@ func (r *GRoot) ShowWindow(w *Window) {
 		r.showModalLayer()
 	}
 
-	w.onShown()
-	w.doShowAnimation()
+	w.init()
 }
 
-// HideWindow hides a Window with animation.
+// HideWindow hides a Window with animation. It is equivalent to w.Hide().
+// 对应 TypeScript 版本 GRoot.hideWindow()
 func (r *GRoot) HideWindow(w *Window) {
-	if w == nil || !w.isShowing {
+	if w == nil {
 		return
 	}
-	w.isShowing = false
-	w.isTop = false
-
-	for i, entry := range r.windowStack {
-		if entry == w {
-			r.windowStack = append(r.windowStack[:i], r.windowStack[i+1:]...)
-			break
-		}
-	}
-
-	if len(r.windowStack) == 0 {
-		r.hideModalLayer()
-	}
-
-	w.doHideAnimation()
-	w.onHide()
-	r.RemoveChild(w.GObject)
+	w.Hide()
 }
 
-// HideWindowImmediately hides a Window without animation.
+// HideWindowImmediately hides a Window without animation, cancelling any
+// running show or hide animation.
+// 对应 TypeScript 版本 GRoot.hideWindowImmediately()
 func (r *GRoot) HideWindowImmediately(w *Window) {
 	if w == nil || !w.isShowing {
 		return
 	}
+	w.cancelAnimation()
+	w.hiding = false
 	w.isShowing = false
 	w.isTop = false
 
@@ -261,8 +295,8 @@ func (r *GRoot) HideWindowImmediately(w *Window) {
 		r.hideModalLayer()
 	}
 
-	w.onHide()
 	r.RemoveChild(w.GObject)
+	w.onHide()
 }
 
 // BringToFront moves the window to the top of the stack.
@@ -457,8 +491,22 @@ func (r *GRoot) closePopup(popup *GObject) {
 	if popup == nil {
 		return
 	}
-	if popup.parent != nil {
-		popup.parent.RemoveChild(popup)
+	if popup.parent == nil {
+		return
+	}
+	popup.parent.RemoveChild(popup)
+	// Laya 在对象离开舞台时派发 UNDISPLAY，弹出框的使用者（如 GComboBox）据此处理关闭
+	if popup.display != nil {
+		popup.display.Dispatcher().Emit(laya.EventUndisplay, popup.display)
+	}
+	// 焦点仍在已关闭的弹出框内时清除，避免按键继续发给不可见的对象
+	if r.stage != nil && popup.display != nil {
+		for sprite := r.stage.Focus(); sprite != nil; sprite = sprite.Parent() {
+			if sprite == popup.display {
+				r.stage.SetFocus(nil)
+				break
+			}
+		}
 	}
 }
 
@@ -471,12 +519,43 @@ func (r *GRoot) indexOfPopup(popup *GObject) int {
 	return -1
 }
 
+// forget 移除根对象对已释放对象的引用：弹出栈、提示、键盘焦点和指针捕获。
+func (r *GRoot) forget(obj *GObject) {
+	if r.tooltipOwner == obj {
+		r.HideTooltips()
+	}
+	if r.tooltipWin == obj {
+		r.tooltipWin = nil
+	}
+	if r.defaultTooltipWin == obj {
+		r.defaultTooltipWin = nil
+	}
+	if idx := r.indexOfPopup(obj); idx != -1 {
+		r.popupStack = append(r.popupStack[:idx], r.popupStack[idx+1:]...)
+	}
+	for i, entry := range r.justClosed {
+		if entry == obj {
+			r.justClosed = append(r.justClosed[:i], r.justClosed[i+1:]...)
+			break
+		}
+	}
+	if r.stage != nil && obj.display != nil {
+		if r.stage.Focus() == obj.display {
+			r.stage.SetFocus(nil)
+		}
+		if r.stage.Capture() == obj.display {
+			r.stage.ReleaseCapture()
+		}
+	}
+}
+
 func (r *GRoot) registerStageListeners() {
 	if r.stage == nil {
 		return
 	}
 	root := r.stage.Root()
 	r.stageMouseDown = func(evt *laya.Event) {
+		r.HideTooltips()
 		if pe, ok := evt.Data.(laya.PointerEvent); ok {
 			if pe.Hit != nil {
 				r.CheckPopups(pe.Hit)
@@ -489,9 +568,19 @@ func (r *GRoot) registerStageListeners() {
 	}
 	r.stageMouseUp = func(evt *laya.Event) {
 		r.justClosed = r.justClosed[:0]
+		gestureClaimed = false
+	}
+	r.stageWheel = func(*laya.Event) {
+		r.HideTooltips()
+	}
+	// 触摸被系统取消时不会收到松开事件，同样结束滑块等控件对手势的占用
+	r.stageCancel = func(*laya.Event) {
+		gestureClaimed = false
 	}
 	root.Dispatcher().On(laya.EventStageMouseDown, r.stageMouseDown)
 	root.Dispatcher().On(laya.EventStageMouseUp, r.stageMouseUp)
+	root.Dispatcher().On(laya.EventMouseWheel, r.stageWheel)
+	root.Dispatcher().On(laya.EventTouchCancel, r.stageCancel)
 }
 
 func (r *GRoot) detachStage() {
@@ -505,8 +594,17 @@ func (r *GRoot) detachStage() {
 	if r.stageMouseUp != nil {
 		root.Dispatcher().Off(laya.EventStageMouseUp, r.stageMouseUp)
 	}
+	if r.stageWheel != nil {
+		root.Dispatcher().Off(laya.EventMouseWheel, r.stageWheel)
+	}
+	if r.stageCancel != nil {
+		root.Dispatcher().Off(laya.EventTouchCancel, r.stageCancel)
+	}
+	r.HideTooltips()
 	r.stageMouseDown = nil
 	r.stageMouseUp = nil
+	r.stageWheel = nil
+	r.stageCancel = nil
 	r.stage.RemoveChild(r.DisplayObject())
 	r.stage = nil
 }
@@ -31,6 +31,33 @@ const TWEEN_TIME_GO = 0.5
 // TWEEN_TIME_DEFAULT 惯性滚动的最小缓动时间
 const TWEEN_TIME_DEFAULT = 0.3
 
+var (
+	// draggingPane 是正在被拖动滚动的 ScrollPane，对应 TypeScript 版本 ScrollPane.draggingPane
+	draggingPane *ScrollPane
+	// gestureFlag 记录正在检测的手势方向：1=垂直，2=水平，对应 TypeScript 版本 ScrollPane._gestureFlag
+	gestureFlag int
+	// touchPanes 是收到本次按下的 ScrollPane，按事件冒泡顺序由内到外排列
+	touchPanes   []*ScrollPane
+	touchPanesID int
+	// gestureClaimed 表示本次按下已被滑块等控件占用，松开前 ScrollPane 不再滚动
+	gestureClaimed bool
+)
+
+// DraggingPane 返回正在被拖动滚动的 ScrollPane，没有时返回 nil。
+// 对应 TypeScript 版本 ScrollPane.draggingPane
+func DraggingPane() *ScrollPane {
+	return draggingPane
+}
+
+// ClaimScrollGesture 声明当前按下的拖动由调用者处理，松开前所有 ScrollPane 都不会因这次拖动而滚动。
+// GSlider、GScrollBar 在按下滑块时调用。
+func ClaimScrollGesture() {
+	gestureClaimed = true
+	if draggingPane != nil {
+		draggingPane.CancelDragging()
+	}
+}
+
 // ScrollPaneLoopOwner 定义循环列表拥有者的接口，避免循环导入
 type ScrollPaneLoopOwner interface {
 	ColumnGap() int
@@ -64,34 +91,39 @@ type ScrollPane struct {
 	container     *laya.Sprite
 	maskContainer *laya.Sprite
 
-	scrollType        ScrollType
-	scrollStep        float64
-	mouseWheelStep    float64
-	xPos              float64
-	yPos              float64
-	viewSize          laya.Point
-	contentSize       laya.Point
-	overlapSize       laya.Point
-	wheelListener     laya.Listener
-	wheelEnabled      bool
-	scrollRectDirty   bool
-	touchEffect       bool
-	bouncebackEffect  bool
-	pageMode          bool
-	pageSize          laya.Point
-	snapToItem        bool
-	inertiaDisabled   bool
-	beginTouch        laya.Point
-	lastTouch         laya.Point
-	containerOrigin   laya.Point
-	velocity          laya.Point
-	lastMoveTime      time.Time
-	dragging          bool
+	scrollType       ScrollType
+	scrollStep       float64
+	mouseWheelStep   float64
+	xPos             float64
+	yPos             float64
+	viewSize         laya.Point
+	contentSize      laya.Point
+	overlapSize      laya.Point
+	wheelListener    laya.Listener
+	wheelEnabled     bool
+	scrollRectDirty  bool
+	touchEffect      bool
+	bouncebackEffect bool
+	pageMode         bool
+	pageSize         laya.Point
+	snapToItem       bool
+	inertiaDisabled  bool
+	beginTouch       laya.Point
+	lastTouch        laya.Point
+	containerOrigin  laya.Point
+	velocity         laya.Point
+	lastMoveTime     time.Time
+	dragging         bool
+	// holdAreaDone 表示本次拖动已超过滚动阈值并确定了方向
+	holdAreaDone bool
+	// locked 为 true 时拖动和滚轮不再滚动面板，代码设置的滚动不受影响
+	locked            bool
 	mouseDownListener laya.Listener
-	stageMoveListener laya.Listener
-	stageUpListener   laya.Listener
-	scrollListeners   map[int]ScrollListener
-	nextListenerID    int
+	// 舞台监听按 ID 移除；同一函数字面量创建的闭包按函数指针无法区分，嵌套面板会互相移除监听
+	stageMoveID     laya.ListenerID
+	stageUpID       laya.ListenerID
+	scrollListeners map[int]ScrollListener
+	nextListenerID  int
 
 	// 滚动条相关
 	hzScrollBar      *GObject // 水平滚动条
@@ -112,17 +144,21 @@ type ScrollPane struct {
 	tweenChange      laya.Point // 动画变化量
 	tweenDuration    laya.Point // 动画持续时间
 	tweenTime        laya.Point // 当前动画时间
+	tweenTarget      laya.Point // setPos 动画的目标滚动位置
 	tickerCleanup    func()     // ticker 取消注册函数
 	decelerationRate float64    // 减速速率，默认 0.997
 	velocityScale    float64    // 速度缩放因子，默认 1.0
 
 	// 下拉/上拉刷新
-	header              *GObject
-	footer              *GObject
-	headerLockedSize    float64
-	footerLockedSize    float64
-	pullDownListeners   []func()
-	pullUpListeners     []func()
+	header            *GObject
+	footer            *GObject
+	headerLockedSize  float64
+	footerLockedSize  float64
+	pullDownListeners []func()
+	pullUpListeners   []func()
+
+	// 最近一次通知时所在的页，用于派发 laya.EventPageChanged
+	lastPageX, lastPageY int
 }
 
 func newScrollPane(owner *GComponent) *ScrollPane {
@@ -319,6 +355,9 @@ func (p *ScrollPane) SetViewSize(width, height float64) {
 		p.vtScrollBar.SetPosition(vtX, 0)
 	}
 
+	// 翻页模式下尺寸改变后保持在当前页
+	pageX, pageY := p.CurrentPageX(), p.CurrentPageY()
+
 	// 设置初始 viewSize（对应 TypeScript ScrollPane.ts:721-722）
 	p.viewSize = laya.Point{X: width, Y: height}
 
@@ -352,7 +391,12 @@ func (p *ScrollPane) SetViewSize(width, height float64) {
 
 	p.updateScrollRect()
 	p.refreshOverlap()
-	p.clampPosition()
+	if p.pageMode {
+		p.stopTween()
+		p.setPos(float64(pageX)*p.pageSize.X, float64(pageY)*p.pageSize.Y)
+	} else {
+		p.clampPosition()
+	}
 	p.notifyScrollListeners()
 	p.updateScrollBars() // 更新滚动条显示百分比（对应 TypeScript ScrollPane.ts:735）
 }
@@ -394,12 +438,51 @@ func (p *ScrollPane) LoopCheckingCurrent() bool {
 	}
 
 	if changed {
-		p.container.SetPosition(float64(-int(p.xPos)), float64(-int(p.yPos)))
+		p.applyPosition()
 	}
 
 	return changed
 }
 
+// loopCheckingNewPos 循环滚动时把目标位置换算到离当前位置较近的一侧，必要时先平移当前位置，
+// 使动画沿较短的方向滚动。对应 TypeScript 版本 ScrollPane.loopCheckingNewPos()
+func (p *ScrollPane) loopCheckingNewPos(value float64, axis string) float64 {
+	overlap, pos := p.overlapSize.X, p.xPos
+	if axis == "y" {
+		overlap, pos = p.overlapSize.Y, p.yPos
+	}
+	if overlap == 0 {
+		return value
+	}
+	changed := false
+	if value < 0.001 {
+		value += p.getLoopPartSize(2, axis)
+		if value > pos {
+			v := p.getLoopPartSize(6, axis)
+			v = math.Ceil((value-pos)/v) * v
+			pos = clampScroll(pos+v, overlap)
+			changed = true
+		}
+	} else if value >= overlap {
+		value -= p.getLoopPartSize(2, axis)
+		if value < pos {
+			v := p.getLoopPartSize(6, axis)
+			v = math.Ceil((pos-value)/v) * v
+			pos = clampScroll(pos-v, overlap)
+			changed = true
+		}
+	}
+	if changed {
+		if axis == "x" {
+			p.xPos = pos
+		} else {
+			p.yPos = pos
+		}
+		p.applyPosition()
+	}
+	return value
+}
+
 // getLoopPartSize 获取循环部分的大小
 func (p *ScrollPane) getLoopPartSize(division int, axis string) float64 {
 	var gap float64
@@ -495,41 +578,99 @@ func (p *ScrollPane) PosY() float64 {
 	return p.yPos
 }
 
-// SetPos updates the scroll offsets (ani 参数保留以兼容原接口，目前忽略)。
-func (p *ScrollPane) SetPos(x, y float64, _ bool) {
+// SetPos updates the scroll offsets. 当 ani 为 true 时以缓动动画（TWEEN_TIME_GO 秒）滚动到目标位置，
+// 动画期间 PosX/PosY 跟随显示位置变化并逐帧通知滚动监听器；用户按下时动画停止在当前位置。
+// 对应 TypeScript 版本 ScrollPane.setPos()
+func (p *ScrollPane) SetPos(x, y float64, ani bool) {
 	if p == nil {
 		return
 	}
-	p.setPos(x, y)
+	p.scrollTo(x, y, ani)
 }
 
 // SetPercX updates horizontal scroll by百分比。
-func (p *ScrollPane) SetPercX(value float64, _ bool) {
+func (p *ScrollPane) SetPercX(value float64, ani bool) {
 	if p == nil {
 		return
 	}
-	if p.overlapSize.X <= 0 {
-		p.setPos(0, p.yPos)
-		return
-	}
-	p.setPos(value*p.overlapSize.X, p.yPos)
+	_, y := p.targetPos()
+	p.scrollTo(value*p.overlapSize.X, y, ani)
 }
 
 // SetPercY updates vertical scroll by百分比。
-func (p *ScrollPane) SetPercY(value float64, _ bool) {
+func (p *ScrollPane) SetPercY(value float64, ani bool) {
 	if p == nil {
 		return
 	}
-	if p.overlapSize.Y <= 0 {
-		p.setPos(p.xPos, 0)
+	x, _ := p.targetPos()
+	p.scrollTo(x, value*p.overlapSize.Y, ani)
+}
+
+// CurrentPageX 返回翻页模式下横向的当前页，滚动超过半页时算作下一页；非翻页模式返回 0。
+// 对应 TypeScript 版本 ScrollPane.currentPageX
+func (p *ScrollPane) CurrentPageX() int {
+	if p == nil || !p.pageMode {
+		return 0
+	}
+	pageW, _ := p.pageExtent()
+	return currentPage(p.xPos, pageW)
+}
+
+// CurrentPageY 返回翻页模式下纵向的当前页；非翻页模式返回 0。
+// 对应 TypeScript 版本 ScrollPane.currentPageY
+func (p *ScrollPane) CurrentPageY() int {
+	if p == nil || !p.pageMode {
+		return 0
+	}
+	_, pageH := p.pageExtent()
+	return currentPage(p.yPos, pageH)
+}
+
+func currentPage(pos, pageSize float64) int {
+	if pageSize <= 0 {
+		return 0
+	}
+	page := math.Floor(pos / pageSize)
+	if pos-page*pageSize > pageSize*0.5 {
+		page++
+	}
+	return int(page)
+}
+
+// SetCurrentPageX 在翻页模式下横向滚动到指定页。
+// 对应 TypeScript 版本 ScrollPane.setCurrentPageX()
+func (p *ScrollPane) SetCurrentPageX(page int, ani bool) {
+	if p == nil || !p.pageMode || p.overlapSize.X <= 0 {
+		return
+	}
+	pageW, _ := p.pageExtent()
+	_, y := p.targetPos()
+	p.scrollTo(float64(page)*pageW, y, ani)
+}
+
+// SetCurrentPageY 在翻页模式下纵向滚动到指定页。
+// 对应 TypeScript 版本 ScrollPane.setCurrentPageY()
+func (p *ScrollPane) SetCurrentPageY(page int, ani bool) {
+	if p == nil || !p.pageMode || p.overlapSize.Y <= 0 {
 		return
 	}
-	p.setPos(p.xPos, value*p.overlapSize.Y)
+	_, pageH := p.pageExtent()
+	x, _ := p.targetPos()
+	p.scrollTo(x, float64(page)*pageH, ani)
+}
+
+// targetPos 返回滚动的目标位置：setPos 动画期间为动画的终点，否则为当前位置
+func (p *ScrollPane) targetPos() (float64, float64) {
+	if p.tweening == 1 {
+		return p.tweenTarget.X, p.tweenTarget.Y
+	}
+	return p.xPos, p.yPos
 }
 
 // ScrollToRect scrolls the viewport so the specified rectangle becomes visible.
-// The rectangle is expressed in the owner's local coordinates.
-func (p *ScrollPane) ScrollToRect(x, y, width, height float64, _ bool) {
+// The rectangle is expressed in the owner's local coordinates. 翻页模式下滚动到矩形所在的页。
+// 对应 TypeScript 版本 ScrollPane.scrollToView()
+func (p *ScrollPane) ScrollToRect(x, y, width, height float64, ani bool) {
 	if p == nil {
 		return
 	}
@@ -539,17 +680,18 @@ func (p *ScrollPane) ScrollToRect(x, y, width, height float64, _ bool) {
 	if height < 0 {
 		height = 0
 	}
-	targetX := p.xPos
-	targetY := p.yPos
+	curX, curY := p.targetPos()
+	targetX := curX
+	targetY := curY
 	viewW := p.viewSize.X
 	viewH := p.viewSize.Y
 	if viewW > 0 {
-		visibleRight := p.xPos + viewW
+		visibleRight := curX + viewW
 		rectRight := x + width
 		if width >= viewW {
 			targetX = x
 		} else {
-			if x < p.xPos {
+			if x < curX {
 				targetX = x
 			} else if rectRight > visibleRight {
 				targetX = rectRight - viewW
@@ -557,19 +699,28 @@ func (p *ScrollPane) ScrollToRect(x, y, width, height float64, _ bool) {
 		}
 	}
 	if viewH > 0 {
-		visibleBottom := p.yPos + viewH
+		visibleBottom := curY + viewH
 		rectBottom := y + height
 		if height >= viewH {
 			targetY = y
 		} else {
-			if y < p.yPos {
+			if y < curY {
 				targetY = y
 			} else if rectBottom > visibleBottom {
 				targetY = rectBottom - viewH
 			}
 		}
 	}
-	p.SetPos(targetX, targetY, false)
+	if p.pageMode {
+		pageW, pageH := p.pageExtent()
+		if targetX != curX && pageW > 0 {
+			targetX = math.Floor(x/pageW) * pageW
+		}
+		if targetY != curY && pageH > 0 {
+			targetY = math.Floor(y/pageH) * pageH
+		}
+	}
+	p.scrollTo(targetX, targetY, ani)
 }
 
 // OnOwnerSizeChanged updates viewport when宿主尺寸发生变化。
@@ -580,18 +731,33 @@ func (p *ScrollPane) OnOwnerSizeChanged() {
 	p.SetViewSize(p.owner.Width(), p.owner.Height())
 }
 
-// Dispose detaches事件监听。
+// Dispose detaches事件监听，并释放滚动条、下拉刷新的头部和尾部。
+// 对应 TypeScript 版本 ScrollPane.dispose()
 func (p *ScrollPane) Dispose() {
 	if p == nil {
 		return
 	}
-	// 停止动画并取消 ticker 注册
-	p.killTween()
+	// 停止动画并取消 ticker 注册，释放时不派发滚动结束事件
+	p.tweening = 0
+	if p.tickerCleanup != nil {
+		p.tickerCleanup()
+		p.tickerCleanup = nil
+	}
+	if draggingPane == p {
+		draggingPane = nil
+	}
 
 	p.unregisterEvents()
-	// 清理滚动条引用
+	// 滚动条直接挂在宿主的显示对象上，不是宿主的子对象，需要单独释放
+	for _, obj := range []*GObject{p.hzScrollBar, p.vtScrollBar, p.header, p.footer} {
+		if obj != nil {
+			obj.Dispose()
+		}
+	}
 	p.hzScrollBar = nil
 	p.vtScrollBar = nil
+	p.header = nil
+	p.footer = nil
 }
 
 // SetHzScrollBar 设置水平滚动条。
@@ -610,6 +776,31 @@ func (p *ScrollPane) SetVtScrollBar(bar *GObject) {
 	p.vtScrollBar = bar
 }
 
+// HzScrollBar 返回水平滚动条，没有时返回 nil。
+func (p *ScrollPane) HzScrollBar() *GObject {
+	if p == nil {
+		return nil
+	}
+	return p.hzScrollBar
+}
+
+// VtScrollBar 返回垂直滚动条，没有时返回 nil。
+func (p *ScrollPane) VtScrollBar() *GObject {
+	if p == nil {
+		return nil
+	}
+	return p.vtScrollBar
+}
+
+// adjustMaskContainer 按宿主的对齐偏移移动遮罩容器；边距已计入容器位置和裁剪矩形。
+// 对应 TypeScript 版本 ScrollPane.adjustMaskContainer()
+func (p *ScrollPane) adjustMaskContainer() {
+	if p.maskContainer == nil || p.owner == nil {
+		return
+	}
+	p.maskContainer.SetPosition(math.Floor(p.owner.alignOffset.X), math.Floor(p.owner.alignOffset.Y))
+}
+
 // HzScrollBarURL 返回水平滚动条资源 URL。
 func (p *ScrollPane) HzScrollBarURL() string {
 	if p == nil {
@@ -650,6 +841,45 @@ func (p *ScrollPane) Floating() bool {
 	return p.floating
 }
 
+// scrollTo 立即或以动画滚动到指定位置，对应 TypeScript 版本 ScrollPane.setPos() 与 refresh2()
+func (p *ScrollPane) scrollTo(x, y float64, ani bool) {
+	p.stopTween()
+	if p.loop == 1 || p.loop == 3 {
+		x = p.loopCheckingNewPos(x, "x")
+	}
+	if p.loop == 2 || p.loop == 3 {
+		y = p.loopCheckingNewPos(y, "y")
+	}
+	x = clampScroll(x, p.overlapSize.X)
+	y = clampScroll(y, p.overlapSize.Y)
+	if !ani || p.container == nil || p.owner == nil {
+		p.setPos(x, y)
+		return
+	}
+	margin := p.owner.Margin()
+	start := p.container.Position()
+	p.tweenTarget = laya.Point{X: x, Y: y}
+	p.tweenStart = start
+	p.tweenChange = laya.Point{
+		X: float64(margin.Left) - x - start.X,
+		Y: float64(margin.Top) - y - start.Y,
+	}
+	if p.tweenChange.X == 0 && p.tweenChange.Y == 0 {
+		p.setPos(x, y)
+		return
+	}
+	p.tweenDuration = laya.Point{X: TWEEN_TIME_GO, Y: TWEEN_TIME_GO}
+	p.startTween(1)
+}
+
+// clampScroll 把滚动位置限制在 [0, overlap] 内
+func clampScroll(pos, overlap float64) float64 {
+	if overlap < 0 {
+		overlap = 0
+	}
+	return math.Min(math.Max(pos, 0), overlap)
+}
+
 func (p *ScrollPane) setPos(x, y float64) {
 	if p == nil {
 		return
@@ -810,14 +1040,18 @@ func (p *ScrollPane) handleMouseWheel(deltaX, deltaY float64) {
 	if p == nil {
 		return
 	}
-	if !p.wheelEnabled {
+	if !p.wheelEnabled || p.locked {
 		return
 	}
+	x, y := p.targetPos()
 	if deltaY != 0 && (p.scrollType == ScrollTypeVertical || p.scrollType == ScrollTypeBoth) {
-		p.setPos(p.xPos, p.yPos-deltaY*p.mouseWheelStep)
+		y -= deltaY * p.mouseWheelStep
 	}
 	if deltaX != 0 && (p.scrollType == ScrollTypeHorizontal || p.scrollType == ScrollTypeBoth) {
-		p.setPos(p.xPos-deltaX*p.mouseWheelStep, p.yPos)
+		x -= deltaX * p.mouseWheelStep
+	}
+	if x != p.xPos || y != p.yPos {
+		p.scrollTo(x, y, false)
 	}
 }
 
@@ -841,14 +1075,12 @@ func (p *ScrollPane) ScrollRight() {
 	p.scrollBy(p.scrollStep, 0)
 }
 
-// ScrollTop 滚动到顶部。
-// 参数 ani 控制是否使用动画（当前未实现动画效果）。
+// ScrollTop 滚动到顶部，ani 为 true 时使用动画。
 func (p *ScrollPane) ScrollTop(ani bool) {
 	p.SetPercY(0, ani)
 }
 
-// ScrollBottom 滚动到底部。
-// 参数 ani 控制是否使用动画（当前未实现动画效果）。
+// ScrollBottom 滚动到底部，ani 为 true 时使用动画。
 func (p *ScrollPane) ScrollBottom(ani bool) {
 	p.SetPercY(1, ani)
 }
@@ -857,15 +1089,14 @@ func (p *ScrollPane) scrollBy(dx, dy float64) {
 	if p == nil {
 		return
 	}
-	x := p.xPos
-	y := p.yPos
+	x, y := p.targetPos()
 	if dx != 0 && (p.scrollType == ScrollTypeHorizontal || p.scrollType == ScrollTypeBoth) {
 		x += dx
 	}
 	if dy != 0 && (p.scrollType == ScrollTypeVertical || p.scrollType == ScrollTypeBoth) {
 		y += dy
 	}
-	p.setPos(x, y)
+	p.scrollTo(x, y, false)
 }
 
 func (p *ScrollPane) currentScrollInfo() ScrollInfo {
@@ -894,18 +1125,37 @@ func (p *ScrollPane) currentScrollInfo() ScrollInfo {
 
 }
 
+// notifyScrollListeners 回调滚动监听并派发 laya.EventScroll；翻页模式下当前页改变时派发 laya.EventPageChanged
 func (p *ScrollPane) notifyScrollListeners() {
-	if p == nil || len(p.scrollListeners) == 0 {
+	if p == nil {
 		return
 	}
-	info := p.currentScrollInfo()
-	for _, fn := range p.scrollListeners {
-		if fn != nil {
-			fn(info)
+	if len(p.scrollListeners) > 0 {
+		info := p.currentScrollInfo()
+		for _, fn := range p.scrollListeners {
+			if fn != nil {
+				fn(info)
+			}
+		}
+	}
+	p.dispatch(laya.EventScroll)
+	if p.pageMode {
+		pageX, pageY := p.CurrentPageX(), p.CurrentPageY()
+		if pageX != p.lastPageX || pageY != p.lastPageY {
+			p.lastPageX, p.lastPageY = pageX, pageY
+			p.dispatch(laya.EventPageChanged)
 		}
 	}
 }
 
+// dispatch 从宿主的显示对象派发事件，事件向父级冒泡，Data 为此 ScrollPane
+func (p *ScrollPane) dispatch(evt laya.EventType) {
+	if p.owner == nil || p.owner.DisplayObject() == nil {
+		return
+	}
+	p.owner.DisplayObject().EmitWithBubble(evt, p)
+}
+
 func clamp01(value float64) float64 {
 	if value < 0 {
 		return 0
@@ -917,7 +1167,7 @@ func clamp01(value float64) float64 {
 }
 
 func (p *ScrollPane) onMouseDown(evt laya.Event) {
-	if p == nil || !p.touchEffect || p.owner == nil {
+	if p == nil || !p.touchEffect || p.locked || p.owner == nil {
 		return
 	}
 	pe, ok := evt.Data.(laya.PointerEvent)
@@ -928,24 +1178,173 @@ func (p *ScrollPane) onMouseDown(evt laya.Event) {
 	if display == nil || p.container == nil {
 		return
 	}
+	// 按下时停止滚动动画，从当前位置开始拖动（对应 TypeScript 版本 __mouseDown）
+	p.stopTween()
 	local := display.GlobalToLocal(pe.Position)
 	p.beginTouch = local
 	p.lastTouch = local
 	p.containerOrigin = p.container.Position()
 	p.velocity = laya.Point{}
 	p.lastMoveTime = time.Now()
+	p.holdAreaDone = false
+	if p.dragging {
+		return
+	}
 	p.dragging = true
+	// 按下事件由内向外冒泡，第一个收到的 ScrollPane 开始新的一组嵌套面板
+	if touchPanesID != pe.TouchID || len(touchPanes) == 0 {
+		touchPanes = nil
+		touchPanesID = pe.TouchID
+		gestureFlag = 0
+	}
+	touchPanes = append(touchPanes, p)
 	p.registerStageDragEvents()
 }
 
-func (p *ScrollPane) onStageMouseMove(evt laya.Event) {
-	if p == nil || !p.dragging || p.owner == nil {
+// CancelDragging 取消正在进行的拖动滚动，本次按下不再滚动此面板。
+// 对应 TypeScript 版本 ScrollPane.cancelDragging()
+func (p *ScrollPane) CancelDragging() {
+	if p == nil {
 		return
 	}
+	p.unregisterStageDragEvents()
+	if draggingPane == p {
+		draggingPane = nil
+	}
+	gestureFlag = 0
+	p.dragging = false
+	p.holdAreaDone = false
+	p.velocity = laya.Point{}
+}
 
-	// 如果正在 tween 动画，先停止（对应 TypeScript __mouseDown 第984行）
-	if p.tweening != 0 {
-		p.killTween()
+// IsDragged 报告面板是否正在被拖动滚动。对应 TypeScript 版本 ScrollPane.isDragged
+func (p *ScrollPane) IsDragged() bool {
+	return p != nil && draggingPane == p
+}
+
+// Locked 报告面板是否已锁定。
+func (p *ScrollPane) Locked() bool {
+	return p != nil && p.locked
+}
+
+// SetLocked 锁定或解锁面板。锁定后拖动和滚轮不再滚动面板，正在进行的拖动和惯性滚动立即停止；
+// SetPos、ScrollToRect 等代码调用的滚动不受影响。例如弹出菜单显示期间锁定下层的列表。
+func (p *ScrollPane) SetLocked(value bool) {
+	if p == nil || p.locked == value {
+		return
+	}
+	p.locked = value
+	if !value {
+		return
+	}
+	if p.dragging {
+		p.CancelDragging()
+	}
+	if p.tweening == 2 {
+		p.stopTween()
+	}
+}
+
+// passHoldArea 判断拖动是否超过滚动阈值。检测到多个方向的手势时（横向面板嵌套在纵向面板中），
+// 要求移动方向与本面板的滚动方向一致。对应 TypeScript 版本 __mouseMove 中的手势检测
+func (p *ScrollPane) passHoldArea(local laya.Point) bool {
+	if p.holdAreaDone {
+		return true
+	}
+	sensitivity := GetUIConfig().TouchScrollSensitivity
+	diffX := math.Abs(local.X - p.beginTouch.X)
+	diffY := math.Abs(local.Y - p.beginTouch.Y)
+	switch p.scrollType {
+	case ScrollTypeVertical:
+		gestureFlag |= 1
+		if diffY < sensitivity {
+			return false
+		}
+		// 已有横向手势在检测，严格要求按垂直方向移动，避免冲突
+		if gestureFlag&2 != 0 && diffY < diffX {
+			return false
+		}
+	case ScrollTypeHorizontal:
+		gestureFlag |= 2
+		if diffX < sensitivity {
+			return false
+		}
+		if gestureFlag&1 != 0 && diffX < diffY {
+			return false
+		}
+	default:
+		gestureFlag = 3
+		if diffX < sensitivity && diffY < sensitivity {
+			return false
+		}
+	}
+	return true
+}
+
+// canScrollToward 报告指针按 (dx, dy) 移动时面板能否沿主要移动方向继续滚动，
+// 到达内容边缘时返回 false
+func (p *ScrollPane) canScrollToward(dx, dy float64) bool {
+	if math.Abs(dy) >= math.Abs(dx) {
+		if dy == 0 || p.scrollType == ScrollTypeHorizontal || p.overlapSize.Y <= 0 {
+			return false
+		}
+		// 指针向上移动时内容向下滚动
+		return (dy < 0 && p.yPos < p.overlapSize.Y) || (dy > 0 && p.yPos > 0)
+	}
+	if p.scrollType == ScrollTypeVertical || p.overlapSize.X <= 0 {
+		return false
+	}
+	return (dx < 0 && p.xPos < p.overlapSize.X) || (dx > 0 && p.xPos > 0)
+}
+
+// arbitrate 决定本面板能否处理这次移动。内层面板优先；内层面板到达内容边缘后，
+// 同方向的外层面板从当前位置接管拖动。
+func (p *ScrollPane) arbitrate(local, delta laya.Point) bool {
+	if obj := DraggingObject(); obj != nil && obj.onStage() {
+		return false
+	}
+	for _, q := range touchPanes {
+		if q == p {
+			break
+		}
+		if q.owner == nil || !q.owner.onStage() {
+			continue
+		}
+		if q == draggingPane {
+			if q.canScrollToward(delta.X, delta.Y) || !p.canScrollToward(delta.X, delta.Y) {
+				return false
+			}
+			// 内层面板已到边缘，由本面板接管，从当前位置开始计算拖动
+			q.velocity = laya.Point{}
+			p.beginTouch = local
+			p.containerOrigin = p.container.Position()
+			p.holdAreaDone = true
+			return true
+		}
+		// 内层面板还能沿这个方向滚动时让给内层面板
+		if draggingPane == nil && q.dragging && q.canScrollToward(delta.X, delta.Y) {
+			return false
+		}
+	}
+	if draggingPane != nil && draggingPane != p && draggingPane.owner != nil && draggingPane.owner.onStage() {
+		return false
+	}
+	if draggingPane == nil && !p.canScrollToward(delta.X, delta.Y) {
+		// 开始拖动时本面板已在边缘，外层面板能滚动时交给外层面板
+		outer := false
+		for _, q := range touchPanes {
+			if outer && q.dragging && q.owner != nil && q.owner.onStage() && q.canScrollToward(delta.X, delta.Y) {
+				return false
+			}
+			outer = outer || q == p
+		}
+	}
+	return true
+}
+
+func (p *ScrollPane) onStageMouseMove(evt laya.Event) {
+	if p == nil || !p.dragging || p.owner == nil {
+		return
 	}
 
 	pe, ok := evt.Data.(laya.PointerEvent)
@@ -956,7 +1355,32 @@ func (p *ScrollPane) onStageMouseMove(evt laya.Event) {
 	if display == nil {
 		return
 	}
+	if gestureClaimed {
+		p.velocity = laya.Point{}
+		return
+	}
 	local := display.GlobalToLocal(pe.Position)
+	if local == p.lastTouch {
+		// 冒泡到舞台的移动事件与舞台自身的移动事件会重复到达
+		return
+	}
+	if !p.passHoldArea(local) {
+		return
+	}
+	delta := laya.Point{X: local.X - p.lastTouch.X, Y: local.Y - p.lastTouch.Y}
+	if !p.arbitrate(local, delta) {
+		p.velocity = laya.Point{}
+		p.lastTouch = local
+		return
+	}
+	draggingPane = p
+	p.holdAreaDone = true
+
+	// 如果正在 tween 动画，先停止（对应 TypeScript __mouseDown 第984行）
+	if p.tweening != 0 {
+		p.killTween()
+	}
+
 	now := time.Now()
 	elapsed := now.Sub(p.lastMoveTime).Seconds()
 	deltaX := local.X - p.lastTouch.X
@@ -986,6 +1410,16 @@ func (p *ScrollPane) onStageMouseUp(evt laya.Event) {
 	}
 	p.dragging = false
 	p.unregisterStageDragEvents()
+	if draggingPane == p {
+		draggingPane = nil
+	}
+	gestureFlag = 0
+	touchPanes = nil
+	if !p.holdAreaDone {
+		// 本次按下没有滚动这个面板
+		return
+	}
+	p.holdAreaDone = false
 
 	if !p.touchEffect {
 		// 没有触摸效果，直接返回
@@ -1077,6 +1511,7 @@ func (p *ScrollPane) onStageMouseUp(evt laya.Event) {
 	if p.tweenChange.X == 0 && p.tweenChange.Y == 0 {
 		p.containerOrigin = p.container.Position()
 		p.updateScrollBarVisible()
+		p.dispatch(laya.EventScrollEnd)
 		debugLog("[ScrollPane] 无需动画，直接返回")
 		return
 	}
@@ -1136,18 +1571,16 @@ func (p *ScrollPane) registerStageDragEvents() {
 		return
 	}
 	dispatcher := stage.Root().Dispatcher()
-	if p.stageMoveListener == nil {
-		p.stageMoveListener = func(evt *laya.Event) {
+	if p.stageMoveID == 0 {
+		p.stageMoveID = dispatcher.OnWithID(laya.EventMouseMove, func(evt *laya.Event) {
 			p.onStageMouseMove(*evt)
-		}
+		})
 	}
-	if p.stageUpListener == nil {
-		p.stageUpListener = func(evt *laya.Event) {
+	if p.stageUpID == 0 {
+		p.stageUpID = dispatcher.OnWithID(laya.EventStageMouseUp, func(evt *laya.Event) {
 			p.onStageMouseUp(*evt)
-		}
+		})
 	}
-	dispatcher.On(laya.EventMouseMove, p.stageMoveListener)
-	dispatcher.On(laya.EventStageMouseUp, p.stageUpListener)
 }
 
 func (p *ScrollPane) unregisterStageDragEvents() {
@@ -1160,12 +1593,9 @@ func (p *ScrollPane) unregisterStageDragEvents() {
 		return
 	}
 	dispatcher := stage.Root().Dispatcher()
-	if p.stageMoveListener != nil {
-		dispatcher.Off(laya.EventMouseMove, p.stageMoveListener)
-	}
-	if p.stageUpListener != nil {
-		dispatcher.Off(laya.EventStageMouseUp, p.stageUpListener)
-	}
+	dispatcher.OffByID(laya.EventMouseMove, p.stageMoveID)
+	dispatcher.OffByID(laya.EventStageMouseUp, p.stageUpID)
+	p.stageMoveID, p.stageUpID = 0, 0
 }
 
 func (p *ScrollPane) snapToNearestPage() {
@@ -1176,14 +1606,12 @@ func (p *ScrollPane) snapToNearestPage() {
 		p.clampPosition()
 		return
 	}
-	pageW := p.pageSize.X
-	if pageW <= 0 {
-		pageW = p.viewSize.X
-	}
-	pageH := p.pageSize.Y
-	if pageH <= 0 {
-		pageH = p.viewSize.Y
-	}
+	p.setPos(p.nearestPagePos())
+}
+
+// nearestPagePos 返回离当前位置最近的页的位置
+func (p *ScrollPane) nearestPagePos() (float64, float64) {
+	pageW, pageH := p.pageExtent()
 	targetX := p.xPos
 	targetY := p.yPos
 	if p.scrollType != ScrollTypeVertical && pageW > 0 && p.overlapSize.X > 0 {
@@ -1192,7 +1620,20 @@ func (p *ScrollPane) snapToNearestPage() {
 	if p.scrollType != ScrollTypeHorizontal && pageH > 0 && p.overlapSize.Y > 0 {
 		targetY = math.Round(targetY/pageH) * pageH
 	}
-	p.setPos(targetX, targetY)
+	return targetX, targetY
+}
+
+// pageExtent 返回页的尺寸，未设置时使用视口尺寸
+func (p *ScrollPane) pageExtent() (float64, float64) {
+	pageW := p.pageSize.X
+	if pageW <= 0 {
+		pageW = p.viewSize.X
+	}
+	pageH := p.pageSize.Y
+	if pageH <= 0 {
+		pageH = p.viewSize.Y
+	}
+	return pageW, pageH
 }
 
 // updateScrollBars 更新滚动条的位置和显示百分比
@@ -1286,16 +1727,16 @@ func (p *ScrollPane) startTween(tweenType int) {
 
 	// 注册到 ticker 更新
 	// 注意：这里不应该检查 tickerCleanup 是否为 nil，因为 killTween 已经清除了它
-	p.tickerCleanup = RegisterTicker(func(delta time.Duration) {
+	// 动画结束时可能立即开始下一段动画（翻页对齐），因此只取消本次注册的 ticker
+	var cleanup func()
+	cleanup = RegisterTicker(func(delta time.Duration) {
 		// 每帧更新
-		completed := !p.tweenUpdate(delta.Seconds())
-		if completed && p.tickerCleanup != nil {
-			// 动画完成，取消注册
+		if !p.tweenUpdate(delta.Seconds()) {
 			debugLog("[ScrollPane] Tween 动画完成")
-			p.tickerCleanup()
-			p.tickerCleanup = nil
+			cleanup()
 		}
 	})
+	p.tickerCleanup = cleanup
 
 	debugLog("[ScrollPane] Ticker 已注册: cleanup=%v", p.tickerCleanup != nil)
 
@@ -1310,14 +1751,11 @@ func (p *ScrollPane) killTween() {
 
 	// 如果是类型1的 tween，需要立即设置到终点
 	if p.tweening == 1 {
-		if p.container != nil {
-			endX := p.tweenStart.X + p.tweenChange.X
-			endY := p.tweenStart.Y + p.tweenChange.Y
-			p.container.SetPosition(endX, endY)
-		}
-		if p.owner != nil && p.owner.DisplayObject() != nil {
-			// 通知滚动事件
-		}
+		p.xPos = clampScroll(p.tweenTarget.X, p.overlapSize.X)
+		p.yPos = clampScroll(p.tweenTarget.Y, p.overlapSize.Y)
+		p.applyPosition()
+		p.notifyScrollListeners()
+		p.updateScrollBars()
 	}
 
 	p.tweening = 0
@@ -1329,11 +1767,7 @@ func (p *ScrollPane) killTween() {
 	}
 
 	p.updateScrollBarVisible()
-
-	// 通知滚动结束
-	if p.owner != nil && p.owner.DisplayObject() != nil {
-		// 触发 SCROLL_END 事件
-	}
+	p.dispatch(laya.EventScrollEnd)
 }
 
 // tweenUpdate 每帧更新 Tween 动画
@@ -1344,6 +1778,11 @@ func (p *ScrollPane) tweenUpdate(delta float64) bool {
 	}
 
 	changed := false
+	// 容器位置包含 margin 偏移（见 applyPosition）
+	var margin Margin
+	if p.owner != nil {
+		margin = p.owner.Margin()
+	}
 
 	// 调试：记录帧更新
 	debugLog("[ScrollPane] tweenUpdate: delta=%.4f, type=%d, change=(%.2f,%.2f), time=(%.2f,%.2f)",
@@ -1368,8 +1807,8 @@ func (p *ScrollPane) tweenUpdate(delta float64) bool {
 		}
 
 		// 边界检查
-		threshold1 := 0.0
-		threshold2 := -p.overlapSize.X
+		threshold1 := float64(margin.Left)
+		threshold2 := threshold1 - p.overlapSize.X
 
 		// 回弹效果检查
 		if p.tweening == 2 && p.bouncebackEffect {
@@ -1425,8 +1864,8 @@ func (p *ScrollPane) tweenUpdate(delta float64) bool {
 				p.tweenTime.Y, p.tweenDuration.Y, ratio, newY)
 		}
 
-		threshold1 := 0.0
-		threshold2 := -p.overlapSize.Y
+		threshold1 := float64(margin.Top)
+		threshold2 := threshold1 - p.overlapSize.Y
 
 		if p.tweening == 2 && p.bouncebackEffect {
 			if (newY > 20+threshold1 && p.tweenChange.Y > 0) ||
@@ -1460,28 +1899,14 @@ func (p *ScrollPane) tweenUpdate(delta float64) bool {
 		}
 	}
 
-	// 更新 posX/posY
-	if p.tweening == 2 {
-		if p.overlapSize.X > 0 && p.container != nil {
-			pos := p.container.Position()
-			p.xPos = clamp01(-pos.X / p.overlapSize.X)
-		}
-		if p.overlapSize.Y > 0 && p.container != nil {
-			pos := p.container.Position()
-			p.yPos = clamp01(-pos.Y / p.overlapSize.Y)
-		}
-		if p.pageMode {
-			p.snapToNearestPage()
-		}
+	// 更新 posX/posY，动画期间逐帧通知滚动（对应 TypeScript 版本派发 Events.SCROLL）
+	if changed && p.syncTweenPos() {
+		p.notifyScrollListeners()
 	}
 
 	// 检查动画是否完成
 	if p.tweenChange.X == 0 && p.tweenChange.Y == 0 {
-		p.tweening = 0
-		// 注意：LoopCheckingCurrent 方法尚未实现，暂时跳过
-		// p.LoopCheckingCurrent()
-		p.updateScrollBars()
-		p.updateScrollBarVisible()
+		p.finishTween()
 		return false
 	}
 
@@ -1492,6 +1917,69 @@ func (p *ScrollPane) tweenUpdate(delta float64) bool {
 	return true
 }
 
+// stopTween 停止动画，滚动位置停留在当前显示的位置
+func (p *ScrollPane) stopTween() {
+	if p == nil || p.tweening == 0 {
+		return
+	}
+	p.tweening = 0
+	if p.tickerCleanup != nil {
+		p.tickerCleanup()
+		p.tickerCleanup = nil
+	}
+	if p.syncTweenPos() {
+		p.notifyScrollListeners()
+	}
+	// 回弹途中停止时容器可能在边界外
+	p.applyPosition()
+	p.updateScrollBars()
+	p.dispatch(laya.EventScrollEnd)
+}
+
+// syncTweenPos 根据容器的位置更新 posX/posY，返回位置是否改变。
+// 对应 TypeScript 版本 ScrollPane.scrollingPosX/scrollingPosY
+func (p *ScrollPane) syncTweenPos() bool {
+	if p.container == nil || p.owner == nil {
+		return false
+	}
+	margin := p.owner.Margin()
+	pos := p.container.Position()
+	x := clampScroll(float64(margin.Left)-pos.X, p.overlapSize.X)
+	y := clampScroll(float64(margin.Top)-pos.Y, p.overlapSize.Y)
+	changed := x != p.xPos || y != p.yPos
+	p.xPos, p.yPos = x, y
+	return changed
+}
+
+// finishTween 处理动画结束：setPos 动画精确停在目标位置，循环滚动时调整位置，
+// 翻页模式下惯性滚动结束后以动画对齐到最近的页
+func (p *ScrollPane) finishTween() {
+	tweenType := p.tweening
+	p.tweening = 0
+	if p.tickerCleanup != nil {
+		p.tickerCleanup()
+		p.tickerCleanup = nil
+	}
+	if tweenType == 1 {
+		p.xPos = clampScroll(p.tweenTarget.X, p.overlapSize.X)
+		p.yPos = clampScroll(p.tweenTarget.Y, p.overlapSize.Y)
+		p.applyPosition()
+	} else {
+		p.syncTweenPos()
+	}
+	if p.loop != 0 {
+		p.LoopCheckingCurrent()
+	}
+	p.notifyScrollListeners()
+	p.updateScrollBars()
+	if tweenType == 2 && p.pageMode {
+		if x, y := p.nearestPagePos(); x != p.xPos || y != p.yPos {
+			p.scrollTo(x, y, true)
+			return
+		}
+	}
+	p.dispatch(laya.EventScrollEnd)
+}
 
 // updateTargetAndDuration2 根据速度计算目标位置和动画时间（完整实现）
 // 对应 TypeScript ScrollPane.ts:1540-1587
@@ -1656,12 +2144,14 @@ func (p *ScrollPane) firePullDownRelease() {
 	for _, fn := range p.pullDownListeners {
 		fn()
 	}
+	p.dispatch(laya.EventPullDownRelease)
 }
 
 func (p *ScrollPane) firePullUpRelease() {
 	for _, fn := range p.pullUpListeners {
 		fn()
 	}
+	p.dispatch(laya.EventPullUpRelease)
 }
 
 // IsBottomMost reports whether the pane is scrolled to the bottom.
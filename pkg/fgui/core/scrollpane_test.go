@@ -144,3 +144,335 @@ func TestScrollPaneSnapToPage(t *testing.T) {
 		t.Fatalf("expected snap to 100, got %.2f", pane.PosX())
 	}
 }
+
+func newAnimatedScrollPane(t *testing.T, contentHeight float64) (*GComponent, *ScrollPane) {
+	comp := NewGComponent()
+	comp.SetSize(100, 100)
+	pane := comp.EnsureScrollPane(ScrollTypeVertical)
+	pane.SetContentSize(100, contentHeight)
+	t.Cleanup(func() {
+		comp.Dispose()
+		if pane.tickerCleanup != nil {
+			t.Errorf("expected the scroll tween ticker to be removed")
+		}
+	})
+	return comp, pane
+}
+
+func TestScrollPaneAnimatedSetPos(t *testing.T) {
+	_, pane := newAnimatedScrollPane(t, 500)
+	var events []float64
+	pane.AddScrollListener(func(info ScrollInfo) { events = append(events, pane.PosY()) })
+	events = nil
+
+	pane.SetPos(0, 300, true)
+	if pane.PosY() != 0 || len(events) != 0 {
+		t.Fatalf("expected the animation to start from the current position, got %.2f", pane.PosY())
+	}
+	tickAll(100 * time.Millisecond)
+	tickAll(100 * time.Millisecond)
+	mid := pane.PosY()
+	if mid <= 0 || mid >= 300 || len(events) != 2 {
+		t.Fatalf("expected scroll events while animating, pos %.2f events %v", mid, events)
+	}
+	if got := pane.container.Position().Y; got != -mid {
+		t.Fatalf("expected the container to follow the animation, got %.2f want %.2f", got, -mid)
+	}
+	tickAll(400 * time.Millisecond)
+	if pane.PosY() != 300 || pane.container.Position().Y != -300 || pane.tweening != 0 {
+		t.Fatalf("expected the animation to end at the target, got %.2f", pane.PosY())
+	}
+
+	pane.ScrollTop(true)
+	tickAll(100 * time.Millisecond)
+	pane.SetPos(0, 50, false)
+	if pane.PosY() != 50 || pane.tweening != 0 {
+		t.Fatalf("expected an immediate SetPos to stop the animation, got %.2f", pane.PosY())
+	}
+}
+
+func TestScrollPaneAnimationCancelledByPress(t *testing.T) {
+	env := attachTestStage(t)
+	comp, pane := newAnimatedScrollPane(t, 500)
+	Root().AddChild(comp.GObject)
+
+	pane.ScrollBottom(true)
+	tickAll(100 * time.Millisecond)
+	mid := pane.PosY()
+	env.Advance(16*time.Millisecond, pressAt(50, 50))
+	if pane.tweening != 0 || pane.PosY() != mid {
+		t.Fatalf("expected a press to stop the animation at %.2f, got %.2f", mid, pane.PosY())
+	}
+	tickAll(500 * time.Millisecond)
+	if pane.PosY() != mid {
+		t.Fatalf("expected the cancelled animation not to resume, got %.2f", pane.PosY())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 50, Y: 50})
+}
+
+func TestScrollPaneAnimatedScrollSnapsToPage(t *testing.T) {
+	_, pane := newAnimatedScrollPane(t, 500)
+	pane.pageMode = true
+	pane.pageSize = laya.Point{X: 100, Y: 100}
+	pane.ScrollToRect(0, 250, 20, 20, true)
+	tickAll(600 * time.Millisecond)
+	if pane.PosY() != 200 {
+		t.Fatalf("expected the scroll to stop at the page containing the rect, got %.2f", pane.PosY())
+	}
+}
+
+func TestScrollPaneAnimatedLoopTakesShortestPath(t *testing.T) {
+	_, pane := newAnimatedScrollPane(t, 400)
+	pane.SetLoop(2)
+	pane.SetPos(0, 50, false)
+
+	// 循环内容的一半是 200：目标 0 换算为 200，当前位置先平移到 250
+	pane.SetPos(0, 0, true)
+	if pane.PosY() != 250 {
+		t.Fatalf("expected the current position to shift into the loop, got %.2f", pane.PosY())
+	}
+	tickAll(100 * time.Millisecond)
+	if pos := pane.PosY(); pos <= 200 || pos >= 250 {
+		t.Fatalf("expected the animation to scroll backwards, got %.2f", pos)
+	}
+	tickAll(500 * time.Millisecond)
+	if pane.PosY() != 200 {
+		t.Fatalf("expected the animation to end at the looped target, got %.2f", pane.PosY())
+	}
+}
+
+// newNestedPanes 创建嵌套的滚动面板：外层 200x200，内层 200x100 位于外层内容的顶部
+func newNestedPanes(t *testing.T, outerType, innerType ScrollType) (*ScrollPane, *ScrollPane, *GComponent) {
+	outer := NewGComponent()
+	outer.SetSize(200, 200)
+	outerPane := outer.EnsureScrollPane(outerType)
+	outerPane.SetContentSize(200, 600)
+	inner := NewGComponent()
+	inner.SetSize(200, 100)
+	innerPane := inner.EnsureScrollPane(innerType)
+	if innerType == ScrollTypeHorizontal {
+		innerPane.SetContentSize(600, 100)
+	} else {
+		innerPane.SetContentSize(200, 150)
+	}
+	outer.AddChild(inner.GObject)
+	Root().AddChild(outer.GObject)
+	t.Cleanup(outer.Dispose)
+	return outerPane, innerPane, inner
+}
+
+func dragPointer(env *testutil.StageEnv, points ...laya.Point) {
+	for _, pt := range points {
+		env.Advance(16*time.Millisecond, pressAt(pt.X, pt.Y))
+	}
+	last := points[len(points)-1]
+	env.Advance(16*time.Millisecond, laya.MouseState{X: last.X, Y: last.Y})
+}
+
+func TestNestedScrollPanesLockDirection(t *testing.T) {
+	env := attachTestStage(t)
+	outerPane, innerPane, _ := newNestedPanes(t, ScrollTypeVertical, ScrollTypeHorizontal)
+
+	// 低于阈值的移动不滚动任何面板
+	env.Advance(16*time.Millisecond, pressAt(150, 50))
+	env.Advance(16*time.Millisecond, pressAt(140, 45))
+	if innerPane.PosX() != 0 || outerPane.PosY() != 0 || DraggingPane() != nil {
+		t.Fatalf("expected no scrolling below the sensitivity")
+	}
+	env.Advance(16*time.Millisecond, pressAt(100, 40))
+	env.Advance(16*time.Millisecond, pressAt(80, 30))
+	if DraggingPane() != innerPane || innerPane.PosX() != 70 || outerPane.PosY() != 0 {
+		t.Fatalf("expected a horizontal drag to scroll only the inner pane, got %.2f/%.2f", innerPane.PosX(), outerPane.PosY())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 80, Y: 30})
+	if DraggingPane() != nil {
+		t.Fatalf("expected release to clear the dragging pane")
+	}
+
+	innerPane.SetPos(0, 0, false)
+	dragPointer(env, laya.Point{X: 100, Y: 80}, laya.Point{X: 105, Y: 50}, laya.Point{X: 108, Y: 20})
+	if outerPane.PosY() != 60 || innerPane.PosX() != 0 {
+		t.Fatalf("expected a vertical drag to scroll only the outer pane, got %.2f/%.2f", innerPane.PosX(), outerPane.PosY())
+	}
+}
+
+func TestNestedScrollPaneHandsOverAtEdge(t *testing.T) {
+	env := attachTestStage(t)
+	outerPane, innerPane, _ := newNestedPanes(t, ScrollTypeVertical, ScrollTypeVertical)
+
+	env.Advance(16*time.Millisecond, pressAt(100, 90))
+	env.Advance(16*time.Millisecond, pressAt(100, 60))
+	if DraggingPane() != innerPane || innerPane.PosY() != 30 || outerPane.PosY() != 0 {
+		t.Fatalf("expected the inner pane to scroll first, got %.2f/%.2f", innerPane.PosY(), outerPane.PosY())
+	}
+	// 内层面板滚到底后由外层面板从当前位置接管
+	env.Advance(16*time.Millisecond, pressAt(100, 40))
+	env.Advance(16*time.Millisecond, pressAt(100, 10))
+	if DraggingPane() != outerPane || innerPane.PosY() != 50 || outerPane.PosY() != 30 {
+		t.Fatalf("expected the outer pane to take over at the inner edge, got %.2f/%.2f", innerPane.PosY(), outerPane.PosY())
+	}
+	// 反向拖动时外层面板保持拖动
+	env.Advance(16*time.Millisecond, pressAt(100, 25))
+	if innerPane.PosY() != 50 || outerPane.PosY() != 15 {
+		t.Fatalf("expected the outer pane to keep the drag, got %.2f/%.2f", innerPane.PosY(), outerPane.PosY())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 100, Y: 25})
+
+	// 内层面板在顶部时向下拖动直接交给外层面板
+	innerPane.SetPos(0, 0, false)
+	outerPane.SetPos(0, 60, false)
+	dragPointer(env, laya.Point{X: 100, Y: 30}, laya.Point{X: 100, Y: 60}, laya.Point{X: 100, Y: 70})
+	if innerPane.PosY() != 0 || outerPane.PosY() != 20 {
+		t.Fatalf("expected the outer pane to scroll when the inner pane is at its edge, got %.2f/%.2f", innerPane.PosY(), outerPane.PosY())
+	}
+}
+
+func TestClaimedGestureDoesNotScroll(t *testing.T) {
+	env := attachTestStage(t)
+	outerPane, _, inner := newNestedPanes(t, ScrollTypeVertical, ScrollTypeHorizontal)
+	inner.SetTouchable(false)
+	handle := NewGObject()
+	handle.SetSize(50, 50)
+	outerPane.owner.AddChild(handle)
+	handle.On(laya.EventMouseDown, func(*laya.Event) { ClaimScrollGesture() })
+
+	dragPointer(env, laya.Point{X: 20, Y: 40}, laya.Point{X: 20, Y: 10}, laya.Point{X: 20, Y: 0})
+	if outerPane.PosY() != 0 || DraggingPane() != nil {
+		t.Fatalf("expected a claimed drag not to scroll, got %.2f", outerPane.PosY())
+	}
+	dragPointer(env, laya.Point{X: 100, Y: 150}, laya.Point{X: 100, Y: 120})
+	if outerPane.PosY() != 30 {
+		t.Fatalf("expected the claim to end on release, got %.2f", outerPane.PosY())
+	}
+
+	// 触摸取消同样结束占用
+	ClaimScrollGesture()
+	env.Stage.Root().Dispatcher().Emit(laya.EventTouchCancel, laya.PointerEvent{})
+	dragPointer(env, laya.Point{X: 100, Y: 150}, laya.Point{X: 100, Y: 120})
+	if outerPane.PosY() != 60 {
+		t.Fatalf("expected the claim to end on touch cancel, got %.2f", outerPane.PosY())
+	}
+}
+
+type scrollEventLog struct {
+	scroll, end, page int
+}
+
+func recordScrollEvents(obj *GObject, pane *ScrollPane) *scrollEventLog {
+	log := &scrollEventLog{}
+	count := func(n *int) laya.Listener {
+		return func(evt *laya.Event) {
+			if evt.Data == pane {
+				*n++
+			}
+		}
+	}
+	obj.On(laya.EventScroll, count(&log.scroll))
+	obj.On(laya.EventScrollEnd, count(&log.end))
+	obj.On(laya.EventPageChanged, count(&log.page))
+	return log
+}
+
+func TestScrollPaneEventsBubble(t *testing.T) {
+	comp, pane := newAnimatedScrollPane(t, 500)
+	parent := NewGComponent()
+	parent.AddChild(comp.GObject)
+	log := recordScrollEvents(parent.GObject, pane)
+
+	pane.SetPos(0, 50, false)
+	if log.scroll != 1 || log.end != 0 {
+		t.Fatalf("expected a bubbled scroll event without scroll end, log %+v", log)
+	}
+	pane.SetPos(0, 300, true)
+	tickAll(100 * time.Millisecond)
+	tickAll(100 * time.Millisecond)
+	if log.scroll < 3 || log.end != 0 {
+		t.Fatalf("expected scroll events while animating, log %+v", log)
+	}
+	tickAll(400 * time.Millisecond)
+	if pane.PosY() != 300 || log.end != 1 {
+		t.Fatalf("expected one scroll end after the animation, log %+v", log)
+	}
+}
+
+func TestScrollPaneCurrentPage(t *testing.T) {
+	comp, pane := newAnimatedScrollPane(t, 500)
+	pane.pageMode = true
+	pane.OnOwnerSizeChanged()
+	log := recordScrollEvents(comp.GObject, pane)
+
+	pane.SetPos(0, 140, false)
+	if pane.CurrentPageY() != 1 || log.page != 1 {
+		t.Fatalf("expected page 1 below half a page, got %d log %+v", pane.CurrentPageY(), log)
+	}
+	pane.SetPos(0, 160, false)
+	if pane.CurrentPageY() != 2 || log.page != 2 {
+		t.Fatalf("expected page 2 past half a page, got %d log %+v", pane.CurrentPageY(), log)
+	}
+	pane.SetCurrentPageY(3, true)
+	tickAll(600 * time.Millisecond)
+	if pane.PosY() != 300 || pane.CurrentPageY() != 3 || log.page != 3 || log.end != 1 {
+		t.Fatalf("expected an animated move to page 3, got %.2f log %+v", pane.PosY(), log)
+	}
+	pane.SetCurrentPageX(1, false)
+	if pane.PosX() != 0 || pane.CurrentPageX() != 0 {
+		t.Fatalf("expected no horizontal page without horizontal overlap")
+	}
+
+	// 视口尺寸改变后保持在当前页
+	comp.SetSize(100, 50)
+	if pane.CurrentPageY() != 3 || pane.PosY() != 150 || log.page != 3 {
+		t.Fatalf("expected the pane to stay on page 3 after resizing, got %.2f", pane.PosY())
+	}
+	comp.SetSize(100, 120)
+	if pane.CurrentPageY() != 3 || pane.PosY() != 360 {
+		t.Fatalf("expected the pane to stay on page 3 after growing, got %.2f", pane.PosY())
+	}
+}
+
+func TestScrollPaneLockAndCancelDragging(t *testing.T) {
+	env := attachTestStage(t)
+	comp, pane := newAnimatedScrollPane(t, 500)
+	Root().AddChild(comp.GObject)
+	log := recordScrollEvents(comp.GObject, pane)
+
+	env.Advance(16*time.Millisecond, pressAt(50, 90))
+	env.Advance(16*time.Millisecond, pressAt(50, 60))
+	if !pane.IsDragged() || pane.PosY() != 30 {
+		t.Fatalf("expected the pane to be dragged, got %.2f", pane.PosY())
+	}
+	// 锁定时停止拖动，松开后不再有惯性滚动
+	pane.SetLocked(true)
+	env.Advance(16*time.Millisecond, pressAt(50, 20))
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 50, Y: 20})
+	tickAll(time.Second)
+	if pane.IsDragged() || pane.PosY() != 30 || log.end != 0 {
+		t.Fatalf("expected locking to cancel the drag, got %.2f log %+v", pane.PosY(), log)
+	}
+	dragPointer(env, laya.Point{X: 50, Y: 90}, laya.Point{X: 50, Y: 40})
+	pane.handleMouseWheel(0, -1)
+	if pane.PosY() != 30 {
+		t.Fatalf("expected a locked pane to ignore drags and the wheel, got %.2f", pane.PosY())
+	}
+	pane.SetPos(0, 100, false)
+	if pane.PosY() != 100 {
+		t.Fatalf("expected SetPos to scroll a locked pane")
+	}
+
+	pane.SetLocked(false)
+	env.Advance(16*time.Millisecond, pressAt(50, 90))
+	env.Advance(16*time.Millisecond, pressAt(50, 60))
+	pane.CancelDragging()
+	env.Advance(16*time.Millisecond, pressAt(50, 20))
+	if pane.IsDragged() || pane.PosY() != 130 {
+		t.Fatalf("expected CancelDragging to stop the drag, got %.2f", pane.PosY())
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 50, Y: 20})
+	dragPointer(env, laya.Point{X: 50, Y: 90}, laya.Point{X: 50, Y: 60})
+	for i := 0; i < 30; i++ {
+		tickAll(100 * time.Millisecond)
+	}
+	if pane.PosY() <= 130 || log.end != 1 {
+		t.Fatalf("expected the next drag to scroll and end, got %.2f log %+v", pane.PosY(), log)
+	}
+}
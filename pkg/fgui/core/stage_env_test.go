@@ -0,0 +1,16 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/chslink/fairygui/internal/compat/laya/testutil"
+)
+
+// attachTestStage 把根对象挂到新的舞台上，测试结束时恢复原来的舞台
+func attachTestStage(t *testing.T) *testutil.StageEnv {
+	env := testutil.NewStageEnv(t, 400, 300)
+	prev := Root().Stage()
+	Root().AttachStage(env.Stage)
+	t.Cleanup(func() { Root().AttachStage(prev) })
+	return env
+}
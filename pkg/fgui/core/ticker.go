@@ -31,6 +31,13 @@ func RegisterTicker(fn func(time.Duration)) func() {
 	}
 }
 
+// TickerCount returns the number of registered tickers, mainly for leak checks.
+func TickerCount() int {
+	tickerMutex.Lock()
+	defer tickerMutex.Unlock()
+	return len(tickers)
+}
+
 func tickAll(delta time.Duration) {
 	tickerMutex.Lock()
 	if len(tickers) == 0 {
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"log"
+	"time"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+)
+
+// ObjectCreator 根据资源 URL 创建对象，builder.FactoryObjectCreator 实现了该接口。
+// GRoot 用它创建 UIConfig 中配置的默认提示组件。
+type ObjectCreator interface {
+	CreateObject(url string) *GObject
+}
+
+// SetObjectCreator 设置根对象按 URL 创建对象时使用的创建器，已创建的默认提示组件会被释放并重新创建。
+func (r *GRoot) SetObjectCreator(creator ObjectCreator) {
+	r.objectCreator = creator
+	if r.defaultTooltipWin != nil {
+		r.HideTooltips()
+		r.defaultTooltipWin.Dispose()
+		r.defaultTooltipWin = nil
+	}
+}
+
+// ShowTooltips 使用默认提示组件（UIConfig.TooltipsWin）在指针附近显示文字提示。
+// 默认提示组件的文字通过 SetTitle 或 SetText 设置，通常是一个标签组件。
+// 对应 TypeScript 版本 GRoot.showTooltips()
+func (r *GRoot) ShowTooltips(msg string) {
+	url := GetUIConfig().TooltipsWin
+	if r.defaultTooltipWin == nil || r.defaultTooltipWin.IsDisposed() || r.defaultTooltipURL != url {
+		// UIConfig.TooltipsWin 改变后重新创建默认提示组件
+		if r.defaultTooltipWin != nil {
+			r.HideTooltips()
+			r.defaultTooltipWin.Dispose()
+			r.defaultTooltipWin = nil
+		}
+		if url == "" {
+			log.Printf("[GRoot] UIConfig.TooltipsWin not defined")
+			return
+		}
+		if r.objectCreator == nil {
+			log.Printf("[GRoot] no ObjectCreator to create tooltips %s", url)
+			return
+		}
+		r.defaultTooltipWin = r.objectCreator.CreateObject(url)
+		r.defaultTooltipURL = url
+		if r.defaultTooltipWin == nil {
+			return
+		}
+	}
+	if title, ok := widgetOf[interface{ SetTitle(string) }](r.defaultTooltipWin); ok {
+		title.SetTitle(msg)
+	} else if text, ok := widgetOf[interface{ SetText(string) }](r.defaultTooltipWin); ok {
+		text.SetText(msg)
+	}
+	r.ShowTooltipsWin(r.defaultTooltipWin, nil)
+}
+
+// ShowTooltipsWin 显示自定义的提示组件。position 为舞台坐标，nil 时显示在指针右下方；
+// 提示组件超出根对象右侧或底部时翻到另一侧。
+// 对应 TypeScript 版本 GRoot.showTooltipsWin()
+func (r *GRoot) ShowTooltipsWin(tooltipWin *GObject, position *laya.Point) {
+	if tooltipWin == nil {
+		return
+	}
+	r.HideTooltips()
+	r.tooltipWin = tooltipWin
+
+	var pt laya.Point
+	if position != nil {
+		pt = *position
+	} else if r.stage != nil {
+		mouse := r.stage.Mouse()
+		pt = laya.Point{X: mouse.X + 10, Y: mouse.Y + 20}
+	}
+	pt = r.DisplayObject().GlobalToLocal(pt)
+	xx, yy := pt.X, pt.Y
+	width, height := tooltipWin.Width(), tooltipWin.Height()
+	if xx+width > r.Width() {
+		xx = xx - width - 1
+		if xx < 0 {
+			xx = 10
+		}
+	}
+	if yy+height > r.Height() {
+		yy = yy - height - 1
+		if xx-width-1 > 0 {
+			xx = xx - width - 1
+		}
+		if yy < 0 {
+			yy = 10
+		}
+	}
+	tooltipWin.SetPosition(xx, yy)
+	r.AddChild(tooltipWin)
+}
+
+// HideTooltips 隐藏正在显示的提示，并取消等待显示的提示。
+// 对应 TypeScript 版本 GRoot.hideTooltips()
+func (r *GRoot) HideTooltips() {
+	r.cancelPendingTooltips()
+	r.tooltipOwner = nil
+	if r.tooltipWin != nil {
+		if r.tooltipWin.Parent() != nil {
+			r.RemoveChild(r.tooltipWin)
+		}
+		r.tooltipWin = nil
+	}
+}
+
+// TooltipsWin 返回正在显示的提示组件，没有时返回 nil。
+func (r *GRoot) TooltipsWin() *GObject {
+	return r.tooltipWin
+}
+
+// scheduleTooltips 在 UIConfig.TooltipsDelay 后显示对象的提示，对应 TypeScript 版本 GObject.__rollOver
+func (r *GRoot) scheduleTooltips(obj *GObject) {
+	r.cancelPendingTooltips()
+	scheduler := r.Scheduler()
+	if scheduler == nil {
+		return
+	}
+	delay := time.Duration(GetUIConfig().TooltipsDelay * float64(time.Second))
+	r.tooltipTimer = scheduler.Once(delay, func() {
+		r.tooltipTimer = laya.TimerHandle{}
+		if obj.tooltips != "" && !obj.disposed && obj.onStage() {
+			r.ShowTooltips(obj.tooltips)
+			if r.tooltipWin != nil {
+				r.tooltipOwner = obj
+			}
+		}
+	})
+}
+
+func (r *GRoot) cancelPendingTooltips() {
+	if scheduler := r.Scheduler(); scheduler != nil {
+		scheduler.Cancel(r.tooltipTimer)
+	}
+	r.tooltipTimer = laya.TimerHandle{}
+}
+
+// listenTooltips 在对象首次设置提示时注册悬停监听；监听器在提示为空时不做任何事
+func (g *GObject) listenTooltips() {
+	if g.tooltipsListening {
+		return
+	}
+	g.tooltipsListening = true
+	g.On(laya.EventRollOver, func(evt *laya.Event) {
+		// 悬停事件会冒泡，只显示最内层带提示的对象的提示
+		if g.tooltips != "" && innermostTooltipsOwner(evt) == g {
+			Root().scheduleTooltips(g)
+		}
+	})
+	g.On(laya.EventRollOut, func(*laya.Event) {
+		// 显示期间清空了提示文字时仍然隐藏本对象的提示
+		if r := Root(); g.tooltips != "" || r.tooltipOwner == g {
+			r.HideTooltips()
+		}
+	})
+}
+
+func innermostTooltipsOwner(evt *laya.Event) *GObject {
+	pe, ok := evt.Data.(laya.PointerEvent)
+	if !ok {
+		return nil
+	}
+	for sprite := pe.Target; sprite != nil; sprite = sprite.Parent() {
+		if owner := ownerAsGObject(sprite); owner != nil && owner.tooltips != "" {
+			return owner
+		}
+	}
+	return nil
+}
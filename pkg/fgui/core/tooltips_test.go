@@ -0,0 +1,141 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+)
+
+type fakeTooltip struct {
+	*GComponent
+	title string
+}
+
+func (f *fakeTooltip) SetTitle(value string) { f.title = value }
+
+type fakeCreator struct {
+	urls []string
+	tip  *fakeTooltip
+}
+
+func (c *fakeCreator) CreateObject(url string) *GObject {
+	c.urls = append(c.urls, url)
+	c.tip = &fakeTooltip{GComponent: NewGComponent()}
+	c.tip.SetData(c.tip)
+	c.tip.SetSize(100, 30)
+	return c.tip.GObject
+}
+
+func setupTooltips(t *testing.T) *fakeCreator {
+	cfg := GetUIConfig()
+	prevURL, prevDelay := cfg.TooltipsWin, cfg.TooltipsDelay
+	cfg.TooltipsWin, cfg.TooltipsDelay = "ui://Basics/Tips", 0.1
+	creator := &fakeCreator{}
+	Root().SetObjectCreator(creator)
+	t.Cleanup(func() {
+		Root().HideTooltips()
+		Root().SetObjectCreator(nil)
+		cfg.TooltipsWin, cfg.TooltipsDelay = prevURL, prevDelay
+	})
+	return creator
+}
+
+func TestTooltipsShowAfterHoverDelay(t *testing.T) {
+	env := attachTestStage(t)
+	creator := setupTooltips(t)
+	obj := NewGObject()
+	obj.SetPosition(50, 50)
+	obj.SetSize(50, 50)
+	obj.SetTooltips("hello")
+	Root().AddChild(obj)
+	defer obj.Dispose()
+
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+	env.Advance(50*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+	if Root().TooltipsWin() != nil {
+		t.Fatalf("expected the tooltip to wait for the hover delay")
+	}
+	env.Advance(60*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+	tip := Root().TooltipsWin()
+	if tip == nil || creator.tip == nil || tip != creator.tip.GObject || creator.tip.title != "hello" {
+		t.Fatalf("expected the default tooltip to show the text, got %v", tip)
+	}
+	if tip.Parent() != Root().GComponent || tip.X() != 70 || tip.Y() != 80 {
+		t.Fatalf("expected the tooltip below-right of the pointer, got %v,%v", tip.X(), tip.Y())
+	}
+
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 300, Y: 250})
+	if Root().TooltipsWin() != nil || tip.Parent() != nil {
+		t.Fatalf("expected roll-out to hide the tooltip")
+	}
+
+	// 按下或滚动滚轮都会隐藏提示；默认提示组件只创建一次
+	for _, hide := range []laya.MouseState{{X: 60, Y: 60, Primary: true}, {X: 60, Y: 60, WheelY: 1}} {
+		env.Advance(16*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+		env.Advance(200*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+		if Root().TooltipsWin() == nil {
+			t.Fatalf("expected the tooltip to show again")
+		}
+		env.Advance(16*time.Millisecond, hide)
+		if Root().TooltipsWin() != nil {
+			t.Fatalf("expected %+v to hide the tooltip", hide)
+		}
+		env.Advance(16*time.Millisecond, laya.MouseState{X: 300, Y: 250})
+	}
+	if len(creator.urls) != 1 || creator.urls[0] != "ui://Basics/Tips" {
+		t.Fatalf("expected the default tooltip to be created once from UIConfig, got %v", creator.urls)
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+	env.Advance(200*time.Millisecond, laya.MouseState{X: 60, Y: 60})
+	obj.Dispose()
+	if Root().TooltipsWin() != nil {
+		t.Fatalf("expected disposing the hovered object to hide its tooltip")
+	}
+}
+
+func TestTooltipsPreferInnermostObject(t *testing.T) {
+	env := attachTestStage(t)
+	creator := setupTooltips(t)
+	parent := NewGComponent()
+	parent.SetSize(200, 200)
+	parent.SetTooltips("parent")
+	child := NewGObject()
+	child.SetSize(50, 50)
+	child.SetTooltips("child")
+	parent.AddChild(child)
+	Root().AddChild(parent.GObject)
+	defer parent.Dispose()
+
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 10, Y: 10})
+	env.Advance(200*time.Millisecond, laya.MouseState{X: 10, Y: 10})
+	if creator.tip == nil || creator.tip.title != "child" {
+		t.Fatalf("expected the child's tooltip")
+	}
+	// 清空提示后不再显示
+	child.SetTooltips("")
+	parent.SetTooltips("")
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 300, Y: 250})
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 10, Y: 10})
+	env.Advance(200*time.Millisecond, laya.MouseState{X: 10, Y: 10})
+	if Root().TooltipsWin() != nil {
+		t.Fatalf("expected no tooltip once the text is cleared")
+	}
+}
+
+func TestShowTooltipsWinStaysInsideRoot(t *testing.T) {
+	attachTestStage(t)
+	defer Root().HideTooltips()
+	custom := NewGObject()
+	custom.SetSize(120, 60)
+	Root().ShowTooltipsWin(custom, &laya.Point{X: 350, Y: 280})
+	if Root().TooltipsWin() != custom || custom.X() != 108 || custom.Y() != 219 {
+		t.Fatalf("expected the tooltip to flip inside the root, got %v,%v", custom.X(), custom.Y())
+	}
+	other := NewGObject()
+	other.SetSize(10, 10)
+	Root().ShowTooltipsWin(other, &laya.Point{X: 5, Y: 5})
+	if custom.Parent() != nil || other.X() != 5 || other.Y() != 5 {
+		t.Fatalf("expected the new tooltip to replace the previous one")
+	}
+}
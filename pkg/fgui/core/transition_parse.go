@@ -4,18 +4,34 @@ import "github.com/chslink/fairygui/pkg/fgui/utils"
 
 // SetupTransitions 从 buffer 中解析 transition 数据并添加到组件中
 func (c *GComponent) SetupTransitions(buf *utils.ByteBuffer, start int) {
-	if c == nil || buf == nil || start < 0 {
+	if c == nil {
 		return
 	}
+	c.AddTransitions(ParseTransitions(buf, start))
+}
+
+// TransitionTemplate 是从组件数据解析出的一条 Transition 定义。目标对象保存为子对象下标，
+// 由 AddTransitions 按组件实例解析，因此同一组件资源的多个实例可以共享解析结果
+type TransitionTemplate struct {
+	info    TransitionInfo
+	targets []int // 与 info.Items 一一对应，-1 表示组件本身
+}
+
+// ParseTransitions 解析组件数据第 5 段中的 transition 定义
+func ParseTransitions(buf *utils.ByteBuffer, start int) []TransitionTemplate {
+	if buf == nil || start < 0 {
+		return nil
+	}
 	saved := buf.Pos()
 	defer buf.SetPos(saved)
 	if !buf.Seek(start, 5) {
-		return
+		return nil
 	}
 	count := int(buf.ReadInt16())
 	if count <= 0 {
-		return
+		return nil
 	}
+	var templates []TransitionTemplate
 	for i := 0; i < count; i++ {
 		nextPos := int(buf.ReadInt16()) + buf.Pos()
 		if nextPos > buf.Len() {
@@ -49,6 +65,7 @@ func (c *GComponent) SetupTransitions(buf *utils.ByteBuffer, start int) {
 			itemCount = int(buf.ReadInt16())
 		}
 		info.Items = make([]TransitionItem, 0, itemCount)
+		targets := make([]int, 0, itemCount)
 		maxDuration := 0.0
 		for j := 0; j < itemCount; j++ {
 			if buf.Pos() >= nextPos || nextPos-buf.Pos() < 2 {
@@ -60,7 +77,7 @@ func (c *GComponent) SetupTransitions(buf *utils.ByteBuffer, start int) {
 				buf.SetPos(nextPos)
 				break
 			}
-			if parsed := c.parseTransitionItem(buf, curPos, dataLen); parsed != nil {
+			if parsed, target := parseTransitionItem(buf, curPos, dataLen); parsed != nil {
 				end := parsed.Time
 				if parsed.Tween != nil {
 					end += parsed.Tween.Duration
@@ -69,43 +86,65 @@ func (c *GComponent) SetupTransitions(buf *utils.ByteBuffer, start int) {
 					maxDuration = end
 				}
 				info.Items = append(info.Items, *parsed)
+				targets = append(targets, target)
 			}
 			buf.SetPos(curPos + dataLen)
 		}
 		info.ItemCount = len(info.Items)
 		info.TotalDuration = maxDuration
 		if info.ItemCount > 0 || info.Name != "" {
-			c.AddTransition(info)
+			templates = append(templates, TransitionTemplate{info: info, targets: targets})
 		}
 		buf.SetPos(nextPos)
 	}
+	return templates
 }
 
-func (c *GComponent) parseTransitionItem(buf *utils.ByteBuffer, start, length int) *TransitionItem {
+// AddTransitions 按 ParseTransitions 的结果为组件添加 Transition，目标在组件的子对象中解析。
+// 每个实例持有自己的 Items 副本，Tween 等只读数据与模板共享
+func (c *GComponent) AddTransitions(templates []TransitionTemplate) {
+	if c == nil {
+		return
+	}
+	for _, tpl := range templates {
+		info := tpl.info
+		info.Items = make([]TransitionItem, len(tpl.info.Items))
+		copy(info.Items, tpl.info.Items)
+		for i, target := range tpl.targets {
+			if target >= 0 {
+				info.Items[i].TargetID = c.resolveTransitionTargetID(target)
+			}
+		}
+		c.AddTransition(info)
+	}
+}
+
+// parseTransitionItem 解析一个 transition 条目，同时返回目标子对象的下标（-1 表示组件本身）
+func parseTransitionItem(buf *utils.ByteBuffer, start, length int) (*TransitionItem, int) {
+	target := -1
 	if buf == nil || length <= 0 {
-		return nil
+		return nil, target
 	}
 	saved := buf.Pos()
 	defer buf.SetPos(saved)
 	limit := start + length
 	if limit > buf.Len() || !buf.Seek(start, 0) {
-		return nil
+		return nil, target
 	}
 	rem := func() int { return limit - buf.Pos() }
 	if rem() <= 0 {
-		return nil
+		return nil, target
 	}
 	action := transitionActionFromByte(int(buf.ReadByte()))
 	item := TransitionItem{Type: action}
 	if rem() >= 4 {
 		item.Time = float64(buf.ReadFloat32())
 	} else {
-		return nil
+		return nil, target
 	}
 	if rem() >= 2 {
-		targetIndex := int(buf.ReadInt16())
-		if targetIndex >= 0 {
-			item.TargetID = c.resolveTransitionTargetID(targetIndex)
+		if index := int(buf.ReadInt16()); index >= 0 {
+			target = index
 		}
 	}
 	if rem() >= 2 {
@@ -191,7 +230,7 @@ func (c *GComponent) parseTransitionItem(buf *utils.ByteBuffer, start, length in
 			decodeTransitionValue(buf, limit, action, &item.Value)
 		}
 	}
-	return &item
+	return &item, target
 }
 
 func transitionActionFromByte(value int) TransitionAction {
@@ -20,6 +20,7 @@ type Transition struct {
 	tasks        []*tween.GTweener
 	targetCache  map[string]*GObject
 	shakeTargets map[*GObject]struct{}
+	onComplete   func()
 }
 
 func newTransition(owner *GComponent, info TransitionInfo) *Transition {
@@ -126,10 +127,17 @@ func (t *Transition) SetTimeScale(scale float64) {
 
 // Play 启动 Transition。times<=0 表示使用 AutoPlayTimes（≤0 则单次播放），delay<0 表示使用 AutoPlayDelay。
 func (t *Transition) Play(times int, delay float64) {
+	t.PlayWithCallback(nil, times, delay)
+}
+
+// PlayWithCallback 启动 Transition，播放结束时调用 onComplete；被 Stop 或重新播放打断时不会调用。
+// 对应 TypeScript 版本 Transition.play(onComplete, times, delay)
+func (t *Transition) PlayWithCallback(onComplete func(), times int, delay float64) {
 	t.mu.Lock()
 	info := t.info
 	t.stopAllTweensLocked()
 	t.playing = false
+	t.onComplete = onComplete
 	t.tasks = nil
 	if times == 0 {
 		times = info.AutoPlayTimes
@@ -177,6 +185,7 @@ func (t *Transition) Stop(complete bool) {
 	info := t.info
 	t.stopAllTweensLocked()
 	t.playing = false
+	t.onComplete = nil
 	t.mu.Unlock()
 
 	if complete {
@@ -213,10 +222,25 @@ func (t *Transition) Stop(complete bool) {
 	}
 }
 
+// dispose 停止播放并释放目标缓存，由所属组件释放时调用。
+// 对应 TypeScript 版本 Transition.dispose()
+func (t *Transition) dispose() {
+	t.Stop(false)
+	t.mu.Lock()
+	t.targetCache = make(map[string]*GObject)
+	t.mu.Unlock()
+}
+
 func (t *Transition) finishPlayback() {
 	t.mu.Lock()
-	defer t.mu.Unlock()
 	t.finishLocked()
+	callback := t.onComplete
+	t.onComplete = nil
+	t.mu.Unlock()
+	// 回调中可能再次播放本 Transition，需在释放锁后调用
+	if callback != nil {
+		callback()
+	}
 }
 
 func (t *Transition) finishLocked() {
@@ -23,9 +23,17 @@ type UIConfig struct {
 	ButtonSoundVolumeScale        float64
 	PopupMenu                     string
 	PopupMenuSeperator            string
+	// TooltipsWin 是 GRoot.ShowTooltips 使用的默认提示组件，TooltipsDelay 是悬停后显示提示前等待的秒数
+	TooltipsWin                   string
+	TooltipsDelay                 float64
 	GlobalModalWaiting            string
 	WindowModalWaiting            string
 	BringWindowToFrontOnClick     bool
+	// ClickDragSensitivity/TouchDragSensitivity 是鼠标、触摸按下后开始拖动前需要移动的像素数
+	ClickDragSensitivity          float64
+	TouchDragSensitivity          float64
+	// TouchScrollSensitivity 是按下后开始拖动滚动 ScrollPane 前需要移动的像素数
+	TouchScrollSensitivity        float64
 	FrameTimeForAsyncUIConstruction float64
 }
 
@@ -38,9 +46,13 @@ var globalUIConfig = &UIConfig{
 	DefaultScrollBounceEffect:        true,
 	ImageFilter:                      ImageFilterLinear,
 	PopupMenu:                        "",
+	TooltipsDelay:                    0.1,
 	ButtonSound:                      "",
 	ButtonSoundVolumeScale:           1,
 	BringWindowToFrontOnClick:        true,
+	ClickDragSensitivity:             2,
+	TouchDragSensitivity:             10,
+	TouchScrollSensitivity:           20,
 	FrameTimeForAsyncUIConstruction:  0.002,
 }
 
@@ -68,6 +80,12 @@ func SetDefaultButtonSound(soundURL string) {
 	globalUIConfig.ButtonSound = soundURL
 }
 
+// SetDefaultTooltips 设置默认提示组件资源
+// 对应 TypeScript 版本的 fgui.UIConfig.tooltipsWin
+func SetDefaultTooltips(url string) {
+	globalUIConfig.TooltipsWin = url
+}
+
 // SetDefaultPopupMenu 设置默认右键菜单资源
 // 对应 TypeScript 版本的 fgui.UIConfig.popupMenu
 func SetDefaultPopupMenu(menuURL string) {
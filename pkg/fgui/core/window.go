@@ -1,42 +1,52 @@
 package core
 
 import (
+	"log"
+	"math"
+
 	"github.com/chslink/fairygui/internal/compat/laya"
+	"github.com/chslink/fairygui/pkg/fgui/tween"
 )
 
+// WindowAnimation 是窗口的显示或隐藏动画，动画结束时必须调用 done。
+// 动画被打断（例如隐藏途中再次显示）时，以窗口为目标（SetTarget）的补间和正在播放的
+// Transition 动画会被停止，晚到的 done 会被忽略。
+type WindowAnimation func(w *Window, done func())
+
 // Window provides a modal popup container with optional close button, drag area,
 // and content area. It mirrors the FairyGUI Window class.
 type Window struct {
 	*GComponent
 
-	contentPane        *GComponent
-	closeButton        *GObject
-	dragArea           *GObject
-	contentArea        *GObject
-	frame              *GObject
-	modalWaitingPane   *GObject
+	contentPane      *GComponent
+	closeButton      *GObject
+	dragArea         *GObject
+	contentArea      *GObject
+	frame            *GObject
+	modalWaitingPane *GObject
 
 	modal               bool
 	bringToFrontOnClick bool
 	isShowing           bool
 	isTop               bool
 	initDone            bool
+	loading             bool
+	// hiding 表示隐藏动画正在播放，窗口仍在窗口栈中
+	hiding bool
 
-	dragging      bool
-	dragStartPosX float64
-	dragStartPosY float64
-	dragMouseX    float64
-	dragMouseY    float64
+	uiSources []IUISource
 
-	uiSource     IUISource
+	showAnimation WindowAnimation
+	hideAnimation WindowAnimation
+	// animToken 在每次开始或取消动画时递增，用于忽略被打断的动画的 done
+	animToken      int
+	animTransition *Transition
 
 	onInitHandler  func()
 	onShownHandler func()
 	onHideHandler  func()
 
-	dragListenerID   laya.ListenerID
-	moveListenerID   laya.ListenerID
-	upListenerID     laya.ListenerID
+	dragListenerID laya.ListenerID
 }
 
 // NewWindow constructs a Window. The content pane must be set before showing.
@@ -66,6 +76,11 @@ func (w *Window) SetContentPane(pane *GComponent) {
 	w.contentPane = pane
 	w.AddChild(pane.GObject)
 
+	if w.dragArea != nil {
+		w.dragArea.SetDraggable(false)
+		w.dragArea.OffByID(laya.EventDragStart, w.dragListenerID)
+		w.dragListenerID = 0
+	}
 	w.frame = pane.ChildByName("frame")
 	w.closeButton = pane.ChildByName("closeButton")
 	w.dragArea = pane.ChildByName("dragArea")
@@ -78,16 +93,24 @@ func (w *Window) SetContentPane(pane *GComponent) {
 	if w.dragArea != nil {
 		w.setupDragHandlers()
 	}
-
-	w.initDone = true
-	if w.onInitHandler != nil {
-		w.onInitHandler()
-	}
 }
 
-// SetUISource configures lazy loading via an IUISource.
+// SetUISource configures lazy loading via an IUISource, replacing any sources
+// added before. See AddUISource.
 func (w *Window) SetUISource(src IUISource) {
-	w.uiSource = src
+	w.uiSources = w.uiSources[:0]
+	w.AddUISource(src)
+}
+
+// AddUISource 添加窗口首次显示前需要加载的资源。显示时未加载的资源会调用 Load，
+// 加载期间显示模态等待界面，全部加载完成后才初始化窗口并播放显示动画。
+// Load 的回调必须在 UI 所在的 goroutine 中调用。
+// 对应 TypeScript 版本 Window.addUISource()
+func (w *Window) AddUISource(src IUISource) {
+	if w == nil || src == nil {
+		return
+	}
+	w.uiSources = append(w.uiSources, src)
 }
 
 // Show displays the window on the root stage.
@@ -107,13 +130,16 @@ func (w *Window) ShowOn(root *GRoot) {
 	root.ShowWindow(w)
 }
 
-// Hide hides the window (with animation if configured).
+// Hide hides the window, playing the hide animation if one is set. The window
+// stays in the window stack until the animation completes.
+// 对应 TypeScript 版本 Window.hide()
 func (w *Window) Hide() {
 	if w == nil {
 		return
 	}
-	root := Root()
-	root.HideWindow(w)
+	if w.isShowing {
+		w.doHideAnimation()
+	}
 }
 
 // HideImmediately removes the window without animation.
@@ -177,13 +203,54 @@ func (w *Window) IsTop() bool {
 	return w.isTop
 }
 
-// ShowModalWait displays a loading indicator on the window.
+// ShowModalWait 在窗口上显示 UIConfig.WindowModalWaiting 指定的等待界面，覆盖内容区域
+// （没有内容区域时覆盖整个窗口）。msg 非空时设置为等待界面的标题或文字。
+// 对应 TypeScript 版本 Window.showModalWait()
 func (w *Window) ShowModalWait(msg string) {
 	if w == nil {
 		return
 	}
-	// Load from UIConfig.globalModalWaiting URL if available.
-	_ = msg
+	url := GetUIConfig().WindowModalWaiting
+	if url == "" {
+		return
+	}
+	if w.modalWaitingPane == nil || w.modalWaitingPane.IsDisposed() {
+		creator := Root().objectCreator
+		if creator == nil {
+			log.Printf("[Window] no ObjectCreator to create modal waiting %s", url)
+			return
+		}
+		w.modalWaitingPane = creator.CreateObject(url)
+		if w.modalWaitingPane == nil {
+			return
+		}
+	}
+	if msg != "" {
+		if title, ok := widgetOf[interface{ SetTitle(string) }](w.modalWaitingPane); ok {
+			title.SetTitle(msg)
+		} else if text, ok := widgetOf[interface{ SetText(string) }](w.modalWaitingPane); ok {
+			text.SetText(msg)
+		}
+	}
+	w.layoutModalWaitPane()
+	w.AddChild(w.modalWaitingPane)
+}
+
+// layoutModalWaitPane 对应 TypeScript 版本 Window.layoutModalWaitPane()
+func (w *Window) layoutModalWaitPane() {
+	pane := w.modalWaitingPane
+	if w.contentArea != nil {
+		x, y := w.contentArea.X(), w.contentArea.Y()
+		if w.contentPane != nil {
+			x += w.contentPane.X()
+			y += w.contentPane.Y()
+		}
+		pane.SetPosition(x, y)
+		pane.SetSize(w.contentArea.Width(), w.contentArea.Height())
+		return
+	}
+	pane.SetPosition(0, 0)
+	pane.SetSize(w.Width(), w.Height())
 }
 
 // CloseModalWait hides the loading indicator.
@@ -191,13 +258,34 @@ func (w *Window) CloseModalWait() {
 	if w == nil {
 		return
 	}
+	if w.modalWaitingPane != nil && w.modalWaitingPane.Parent() != nil {
+		w.RemoveChild(w.modalWaitingPane)
+	}
 }
 
-// SetOnInit registers a callback invoked after the content pane is set.
+// IsModalWaiting reports whether the modal waiting pane is displayed.
+func (w *Window) IsModalWaiting() bool {
+	return w != nil && w.modalWaitingPane != nil && w.modalWaitingPane.Parent() != nil
+}
+
+// SetOnInit registers a callback invoked once, the first time the window is
+// shown and after all UI sources have loaded.
 func (w *Window) SetOnInit(fn func()) {
 	w.onInitHandler = fn
 }
 
+// SetShowAnimation 设置显示动画，nil 表示没有动画。EventShown 回调在动画结束后调用。
+// 对应 TypeScript 版本中重写 Window.doShowAnimation()
+func (w *Window) SetShowAnimation(anim WindowAnimation) {
+	w.showAnimation = anim
+}
+
+// SetHideAnimation 设置隐藏动画，nil 表示立即隐藏。动画结束后窗口才从根对象移除并调用 onHide 回调。
+// 对应 TypeScript 版本中重写 Window.doHideAnimation()
+func (w *Window) SetHideAnimation(anim WindowAnimation) {
+	w.hideAnimation = anim
+}
+
 // SetOnShown registers a callback invoked when the window is shown.
 func (w *Window) SetOnShown(fn func()) {
 	w.onShownHandler = fn
@@ -218,11 +306,146 @@ func (w *Window) ContentArea() *GObject {
 	return w.contentArea
 }
 
-// doShowAnimation runs the show animation (override for custom behavior).
-func (w *Window) doShowAnimation() {}
+// ScaleWindowAnimation 返回以窗口中心为轴心、从 from 缩放到 to 的动画。
+// 从另一个被打断的动画中途开始时，从当前缩放值继续。
+func ScaleWindowAnimation(from, to, duration float64) WindowAnimation {
+	return func(w *Window, done func()) {
+		start, remaining := from, duration
+		if sx, _ := w.Scale(); (sx-from)*(sx-to) < 0 {
+			// 剩余的时长与剩余的缩放量成比例；只影响本次播放
+			start = sx
+			remaining = duration * math.Abs(to-sx) / math.Abs(to-from)
+		}
+		w.SetPivot(0.5, 0.5)
+		w.SetScale(start, start)
+		tween.To(start, to, remaining).
+			SetEase(tween.EaseTypeQuadOut).
+			SetTarget(w.GObject).
+			OnUpdate(func(tw *tween.GTweener) {
+				scale := tw.Value().X
+				w.SetScale(scale, scale)
+			}).
+			OnComplete(func(*tween.GTweener) {
+				done()
+			})
+	}
+}
 
-// doHideAnimation runs the hide animation (override for custom behavior).
-func (w *Window) doHideAnimation() {}
+// TransitionWindowAnimation 返回播放内容组件中指定名字的 Transition 的动画；
+// 找不到该 Transition 时立即结束。
+func TransitionWindowAnimation(name string) WindowAnimation {
+	return func(w *Window, done func()) {
+		var trans *Transition
+		if w.contentPane != nil {
+			trans = w.contentPane.Transition(name)
+		}
+		if trans == nil {
+			done()
+			return
+		}
+		w.animTransition = trans
+		trans.PlayWithCallback(done, 1, 0)
+	}
+}
+
+// init 在窗口显示时调用，首次显示时先加载 UI 资源再初始化。
+// 对应 TypeScript 版本 Window.init() 和 Window.__onShown()
+func (w *Window) init() {
+	if w.initDone {
+		w.doShowAnimation()
+		return
+	}
+	if w.loading {
+		return
+	}
+	pending := 0
+	for _, src := range w.uiSources {
+		if !src.IsLoaded() {
+			pending++
+		}
+	}
+	if pending == 0 {
+		w.initWindow()
+		return
+	}
+	w.loading = true
+	w.ShowModalWait("")
+	for _, src := range w.uiSources {
+		if src.IsLoaded() {
+			continue
+		}
+		src.Load(func() {
+			pending--
+			if pending > 0 || w.disposed {
+				return
+			}
+			w.loading = false
+			w.CloseModalWait()
+			w.initWindow()
+		})
+	}
+}
+
+// initWindow 对应 TypeScript 版本 Window._init()
+func (w *Window) initWindow() {
+	w.initDone = true
+	if w.onInitHandler != nil {
+		w.onInitHandler()
+	}
+	if w.isShowing {
+		w.doShowAnimation()
+	}
+}
+
+// doShowAnimation 播放显示动画，动画结束后调用 onShown。
+// 对应 TypeScript 版本 Window.doShowAnimation()
+func (w *Window) doShowAnimation() {
+	w.cancelAnimation()
+	w.hiding = false
+	if w.showAnimation == nil {
+		w.onShown()
+		return
+	}
+	token := w.animToken
+	w.showAnimation(w, func() {
+		if token == w.animToken && w.isShowing {
+			w.animTransition = nil
+			w.onShown()
+		}
+	})
+}
+
+// doHideAnimation 播放隐藏动画，动画结束后调用 HideImmediately。
+// 对应 TypeScript 版本 Window.doHideAnimation()
+func (w *Window) doHideAnimation() {
+	if w.hiding {
+		return
+	}
+	w.cancelAnimation()
+	// 尚未初始化（例如资源仍在加载）的窗口没有可播放的内容
+	if !w.initDone || w.hideAnimation == nil {
+		w.HideImmediately()
+		return
+	}
+	w.hiding = true
+	token := w.animToken
+	w.hideAnimation(w, func() {
+		if token == w.animToken && w.hiding {
+			w.animTransition = nil
+			w.HideImmediately()
+		}
+	})
+}
+
+// cancelAnimation 停止正在播放的显示或隐藏动画，其 done 回调不再生效
+func (w *Window) cancelAnimation() {
+	w.animToken++
+	tween.Kill(w.GObject, false)
+	if w.animTransition != nil {
+		w.animTransition.Stop(false)
+		w.animTransition = nil
+	}
+}
 
 func (w *Window) onShown() {
 	if w.onShownHandler != nil {
@@ -236,38 +459,27 @@ func (w *Window) onHide() {
 	}
 }
 
+// setupDragHandlers 让拖动区域可拖动，开始拖动时改为拖动整个窗口。
+// 对应 TypeScript 版本 Window.dragArea 的设置和 Window.__dragStart
 func (w *Window) setupDragHandlers() {
 	if w.dragArea == nil {
 		return
 	}
-
-	stage := Root().Stage()
-	if stage == nil {
-		return
-	}
-
-	w.dragListenerID = w.dragArea.OnWithID(laya.EventMouseDown, func(evt *laya.Event) {
+	w.dragArea.SetDraggable(true)
+	w.dragListenerID = w.dragArea.OnWithID(laya.EventDragStart, func(evt *laya.Event) {
+		w.dragArea.StopDrag()
+		touchID := 0
 		if pe, ok := evt.Data.(laya.PointerEvent); ok {
-			w.dragging = true
-			w.dragMouseX = pe.Position.X
-			w.dragMouseY = pe.Position.Y
-			w.dragStartPosX = w.X()
-			w.dragStartPosY = w.Y()
-		}
-	})
-
-	w.moveListenerID = stage.Root().Dispatcher().OnWithID(laya.EventMouseMove, func(evt *laya.Event) {
-		if !w.dragging {
-			return
-		}
-		if pe, ok := evt.Data.(laya.PointerEvent); ok {
-			dx := pe.Position.X - w.dragMouseX
-			dy := pe.Position.Y - w.dragMouseY
-			w.SetPosition(w.dragStartPosX+dx, w.dragStartPosY+dy)
+			touchID = pe.TouchID
 		}
+		w.StartDrag(touchID)
 	})
+}
 
-	w.upListenerID = stage.Root().Dispatcher().OnWithID(laya.EventStageMouseUp, func(evt *laya.Event) {
-		w.dragging = false
-	})
+// HandleDispose 从窗口栈中移除窗口，由 GObject.Dispose 调用。
+// 对应 TypeScript 版本 Window.dispose()
+func (w *Window) HandleDispose() {
+	if w.isShowing {
+		Root().HideWindowImmediately(w)
+	}
 }
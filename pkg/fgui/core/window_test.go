@@ -0,0 +1,250 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/pkg/fgui/tween"
+)
+
+type windowEventLog struct {
+	init, shown, hide int
+}
+
+func newTestWindow(t *testing.T) (*Window, *windowEventLog) {
+	pane := NewGComponent()
+	pane.SetSize(200, 100)
+	area := NewGObject()
+	area.SetName("contentArea")
+	area.SetPosition(10, 20)
+	area.SetSize(180, 70)
+	pane.AddChild(area)
+
+	win := NewWindow()
+	win.SetContentPane(pane)
+	win.SetSize(200, 100)
+	log := &windowEventLog{}
+	win.SetOnInit(func() { log.init++ })
+	win.SetOnShown(func() { log.shown++ })
+	win.SetOnHide(func() { log.hide++ })
+	t.Cleanup(win.Dispose)
+	return win, log
+}
+
+func inWindowStack(w *Window) bool {
+	for _, entry := range Root().windowStack {
+		if entry == w {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWindowHideAnimationDelaysOnHide(t *testing.T) {
+	win, log := newTestWindow(t)
+	win.SetShowAnimation(ScaleWindowAnimation(0.1, 1, 0.3))
+	win.SetHideAnimation(ScaleWindowAnimation(1, 0.1, 0.3))
+
+	win.Show()
+	if log.init != 1 || log.shown != 0 {
+		t.Fatalf("expected onShown to wait for the show animation, log %+v", log)
+	}
+	tween.Advance(150 * time.Millisecond)
+	if sx, _ := win.Scale(); sx <= 0.1 || sx >= 1 {
+		t.Fatalf("expected the window to be scaling up, got %v", sx)
+	}
+	tween.Advance(200 * time.Millisecond)
+	if sx, _ := win.Scale(); log.shown != 1 || sx != 1 {
+		t.Fatalf("expected the show animation to finish, scale %v log %+v", sx, log)
+	}
+
+	win.Hide()
+	win.Hide()
+	if log.hide != 0 || !win.IsShowing() || win.Parent() == nil || !inWindowStack(win) {
+		t.Fatalf("expected the window to stay shown while hiding, log %+v", log)
+	}
+	tween.Advance(350 * time.Millisecond)
+	if log.hide != 1 || win.IsShowing() || win.Parent() != nil || inWindowStack(win) {
+		t.Fatalf("expected onHide after the hide animation, log %+v", log)
+	}
+
+	win.Show()
+	tween.Advance(350 * time.Millisecond)
+	if log.init != 1 || log.shown != 2 {
+		t.Fatalf("expected onInit only on the first show, log %+v", log)
+	}
+}
+
+func TestWindowShowAndHideInterruptEachOther(t *testing.T) {
+	win, log := newTestWindow(t)
+	win.SetShowAnimation(ScaleWindowAnimation(0.1, 1, 0.3))
+	win.SetHideAnimation(ScaleWindowAnimation(1, 0.1, 0.3))
+
+	// 显示途中隐藏：不再调用 onShown，从当前缩放开始缩小
+	win.Show()
+	tween.Advance(150 * time.Millisecond)
+	mid, _ := win.Scale()
+	win.Hide()
+	if sx, _ := win.Scale(); sx != mid {
+		t.Fatalf("expected the hide animation to continue from scale %v, got %v", mid, sx)
+	}
+	tween.Advance(500 * time.Millisecond)
+	if log.shown != 0 || log.hide != 1 || win.IsShowing() {
+		t.Fatalf("expected hide during show to cancel onShown, log %+v", log)
+	}
+
+	// 隐藏途中显示：取消隐藏，窗口保留在窗口栈中
+	win.Show()
+	tween.Advance(350 * time.Millisecond)
+	win.Hide()
+	tween.Advance(100 * time.Millisecond)
+	win.Show()
+	tween.Advance(500 * time.Millisecond)
+	if sx, _ := win.Scale(); log.shown != 2 || log.hide != 1 || !win.IsShowing() || sx != 1 || !inWindowStack(win) {
+		t.Fatalf("expected show during hide to cancel the hide, scale %v log %+v", sx, log)
+	}
+
+	win.HideImmediately()
+	if log.hide != 2 || win.Parent() != nil || tween.IsTweening(win.GObject) {
+		t.Fatalf("expected HideImmediately to skip the animation, log %+v", log)
+	}
+}
+
+func TestInterruptedAnimationKeepsFullDuration(t *testing.T) {
+	win, log := newTestWindow(t)
+	win.SetShowAnimation(ScaleWindowAnimation(0.1, 1, 0.3))
+	win.SetHideAnimation(ScaleWindowAnimation(1, 0.1, 0.3))
+
+	// 多次中途打断，缩短的只是被打断后的那一次播放
+	for i := 0; i < 4; i++ {
+		win.Show()
+		tween.Advance(150 * time.Millisecond)
+		win.Hide()
+		tween.Advance(50 * time.Millisecond)
+	}
+	tween.Advance(500 * time.Millisecond)
+	if win.IsShowing() {
+		t.Fatalf("expected the last hide to finish")
+	}
+
+	shown, hide := log.shown, log.hide
+	win.Show()
+	tween.Advance(250 * time.Millisecond)
+	if log.shown != shown {
+		t.Fatalf("expected a full show to still take the whole duration")
+	}
+	tween.Advance(100 * time.Millisecond)
+	win.Hide()
+	tween.Advance(250 * time.Millisecond)
+	if log.shown != shown+1 || log.hide != hide || !win.IsShowing() {
+		t.Fatalf("expected a full hide to still take the whole duration, log %+v", log)
+	}
+	tween.Advance(100 * time.Millisecond)
+	if win.IsShowing() {
+		t.Fatalf("expected the hide animation to finish")
+	}
+}
+
+type fakeUISource struct {
+	loaded  bool
+	pending []func()
+}
+
+func (s *fakeUISource) FileName() string     { return "fake" }
+func (s *fakeUISource) IsLoaded() bool       { return s.loaded }
+func (s *fakeUISource) Load(callback func()) { s.pending = append(s.pending, callback) }
+func (s *fakeUISource) complete() {
+	s.loaded = true
+	for _, fn := range s.pending {
+		fn()
+	}
+	s.pending = nil
+}
+
+func TestWindowLoadsUISourcesBeforeInit(t *testing.T) {
+	cfg := GetUIConfig()
+	prev := cfg.WindowModalWaiting
+	cfg.WindowModalWaiting = "ui://Basics/Waiting"
+	creator := &fakeCreator{}
+	Root().SetObjectCreator(creator)
+	t.Cleanup(func() {
+		Root().SetObjectCreator(nil)
+		cfg.WindowModalWaiting = prev
+	})
+
+	win, log := newTestWindow(t)
+	first, second := &fakeUISource{}, &fakeUISource{}
+	win.AddUISource(first)
+	win.AddUISource(second)
+
+	win.Show()
+	if log.init != 0 || log.shown != 0 || !win.IsModalWaiting() || len(first.pending) != 1 {
+		t.Fatalf("expected the window to wait for its sources, log %+v", log)
+	}
+	if pane := creator.tip; pane.X() != 10 || pane.Y() != 20 || pane.Width() != 180 {
+		t.Fatalf("expected the waiting pane to cover the content area, got %v,%v %v", pane.X(), pane.Y(), pane.Width())
+	}
+	// 加载期间重复显示不会重复加载
+	win.Show()
+	first.complete()
+	if log.init != 0 || len(first.pending) != 0 || len(second.pending) != 1 {
+		t.Fatalf("expected init to wait for every source, log %+v", log)
+	}
+	second.complete()
+	if log.init != 1 || log.shown != 1 || win.IsModalWaiting() {
+		t.Fatalf("expected init and show after loading, log %+v", log)
+	}
+}
+
+func TestWindowHiddenWhileLoading(t *testing.T) {
+	win, log := newTestWindow(t)
+	src := &fakeUISource{}
+	win.SetUISource(src)
+	win.SetHideAnimation(ScaleWindowAnimation(1, 0.1, 0.3))
+
+	win.Show()
+	win.Hide()
+	if log.hide != 1 || win.IsShowing() {
+		t.Fatalf("expected a loading window to hide immediately, log %+v", log)
+	}
+	src.complete()
+	if log.init != 1 || log.shown != 0 {
+		t.Fatalf("expected init without show for a hidden window, log %+v", log)
+	}
+}
+
+func TestWindowTransitionAnimation(t *testing.T) {
+	win, log := newTestWindow(t)
+	child := NewGObject()
+	child.SetResourceID("child")
+	win.ContentPane().AddChild(child)
+	win.ContentPane().AddTransition(TransitionInfo{
+		Name: "hide",
+		Items: []TransitionItem{{
+			TargetID: "child",
+			Type:     TransitionActionXY,
+			Tween: &TransitionTween{
+				Duration: 0.2,
+				Start:    TransitionValue{B1: true, B2: true},
+				End:      TransitionValue{B1: true, B2: true, F1: 100, F2: 0},
+			},
+		}},
+		TotalDuration: 0.2,
+	})
+	win.SetHideAnimation(TransitionWindowAnimation("hide"))
+	win.SetShowAnimation(TransitionWindowAnimation("missing"))
+
+	win.Show()
+	if log.shown != 1 {
+		t.Fatalf("expected a missing transition to finish immediately")
+	}
+	win.Hide()
+	tween.Advance(100 * time.Millisecond)
+	if log.hide != 0 || !win.ContentPane().Transition("hide").Playing() {
+		t.Fatalf("expected the hide transition to be playing")
+	}
+	tween.Advance(150 * time.Millisecond)
+	if log.hide != 1 || child.X() != 100 {
+		t.Fatalf("expected onHide when the transition completes, x %v log %+v", child.X(), log)
+	}
+}
@@ -36,3 +36,39 @@ func ListenStateChanged(obj *GObject, fn func(*laya.Event)) CancelFunc {
 	id := obj.OnStateChanged(fn)
 	return func() { obj.OffStateChanged(id) }
 }
+
+// ListenClickItem registers a listener for list item clicks, including items
+// activated with Enter or Space while the list has keyboard focus.
+func ListenClickItem(obj *GObject, fn func(item *GObject)) CancelFunc {
+	id := obj.OnWithID(laya.EventClickItem, func(evt *laya.Event) {
+		if item, ok := evt.Data.(*GObject); ok {
+			fn(item)
+		}
+	})
+	return func() { obj.OffByID(laya.EventClickItem, id) }
+}
+
+// ListenScroll registers a listener for scroll position changes. Scroll events
+// bubble, so fn receives the pane that scrolled.
+func ListenScroll(obj *GObject, fn func(pane *ScrollPane)) CancelFunc {
+	return listenScrollPane(obj, laya.EventScroll, fn)
+}
+
+// ListenScrollEnd registers a listener for the end of a drag, inertia or animated scroll.
+func ListenScrollEnd(obj *GObject, fn func(pane *ScrollPane)) CancelFunc {
+	return listenScrollPane(obj, laya.EventScrollEnd, fn)
+}
+
+// ListenPageChanged registers a listener for current page changes of page-mode panes.
+func ListenPageChanged(obj *GObject, fn func(pane *ScrollPane)) CancelFunc {
+	return listenScrollPane(obj, laya.EventPageChanged, fn)
+}
+
+func listenScrollPane(obj *GObject, evt laya.EventType, fn func(pane *ScrollPane)) CancelFunc {
+	id := obj.OnWithID(evt, func(e *laya.Event) {
+		if pane, ok := e.Data.(*ScrollPane); ok {
+			fn(pane)
+		}
+	})
+	return func() { obj.OffByID(evt, id) }
+}
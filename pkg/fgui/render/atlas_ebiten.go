@@ -8,10 +8,13 @@ import (
 	"image"
 	_ "image/png"
 	"log"
+	"maps"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
 )
 
 // AtlasManager loads and caches atlas textures and sprite images.
@@ -33,7 +36,15 @@ func NewAtlasManager(loader assets.Loader) *AtlasManager {
 }
 
 // LoadPackage ensures all atlas textures referenced by the package are loaded.
+// Missing atlases are fetched in parallel through an assets.BatchLoader; atlases
+// that load are kept even when others fail, and the failures are reported as an
+// *assets.BatchError.
 func (m *AtlasManager) LoadPackage(ctx context.Context, pkg *assets.Package) error {
+	if pkg == nil {
+		return nil
+	}
+	keys := make(map[string][]string)
+	var requests []assets.ResourceRequest
 	for _, item := range pkg.Items {
 		if item.Type != assets.PackageItemTypeAtlas {
 			continue
@@ -42,53 +53,154 @@ func (m *AtlasManager) LoadPackage(ctx context.Context, pkg *assets.Package) err
 		if _, ok := m.atlasImages[key]; ok {
 			continue
 		}
-		data, err := m.loader.LoadOne(ctx, item.File, assets.ResourceImage)
-		if err != nil {
-			return err
+		if item.RawData != nil {
+			// 图片数据已随包加载（例如编辑器工程中内嵌在 .jta 动画里的帧），直接解码
+			img, _, err := image.Decode(bytes.NewReader(item.RawData.Bytes()))
+			if err != nil {
+				return fmt.Errorf("render: decode atlas %s: %w", item.File, err)
+			}
+			m.atlasImages[key] = ebiten.NewImageFromImage(img)
+			continue
+		}
+		if _, ok := keys[item.File]; !ok {
+			requests = append(requests, assets.ResourceRequest{Key: item.File, Type: assets.ResourceImage})
+		}
+		keys[item.File] = append(keys[item.File], key)
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	batch, ok := m.loader.(*assets.BatchLoader)
+	if !ok {
+		batch = assets.NewBatchLoader(m.loader, 0)
+	}
+	files, loadErr := batch.Load(ctx, requests)
+	if loadErr != nil && files == nil {
+		return loadErr
+	}
+	for _, req := range requests {
+		data, ok := files[req.Key]
+		if !ok {
+			continue
 		}
 		img, _, err := image.Decode(bytes.NewReader(data))
 		if err != nil {
-			return err
+			return fmt.Errorf("render: decode atlas %s: %w", req.Key, err)
+		}
+		texture := ebiten.NewImageFromImage(img)
+		for _, key := range keys[req.Key] {
+			m.atlasImages[key] = texture
 		}
-		m.atlasImages[key] = ebiten.NewImageFromImage(img)
 	}
-	return nil
+	return loadErr
 }
 
 // ResolveSprite returns an Ebiten image representing the sprite for the given item.
+// When core.ContentScaleLevel selects a loaded @2x/@3x variant from item.HighResolution
+// the variant's sprite is returned instead; SpriteDensity reports its pixel density so
+// renderers can draw it at the item's logical size. Changing the scale level swaps the
+// texture on the next draw without rebuilding components.
 func (m *AtlasManager) ResolveSprite(item *assets.PackageItem) (any, error) {
 	if item == nil || item.Sprite == nil || item.Sprite.Atlas == nil {
 		return nil, errors.New("render: package item has no sprite data")
 	}
-	if sprite, ok := m.spriteCache[spriteKey(item)]; ok {
+	source, _ := m.spriteSource(item)
+	if sprite, ok := m.spriteCache[spriteKey(source)]; ok {
 		return sprite, nil
 	}
-	atlasImg, ok := m.atlasImages[atlasKey(item.Sprite.Atlas)]
+	atlasImg, ok := m.atlasImages[atlasKey(source.Sprite.Atlas)]
 	if !ok {
 		return nil, errors.New("render: atlas texture not loaded")
 	}
 	rect := image.Rect(
-		item.Sprite.Rect.X,
-		item.Sprite.Rect.Y,
-		item.Sprite.Rect.X+item.Sprite.Rect.Width,
-		item.Sprite.Rect.Y+item.Sprite.Rect.Height,
+		source.Sprite.Rect.X,
+		source.Sprite.Rect.Y,
+		source.Sprite.Rect.X+source.Sprite.Rect.Width,
+		source.Sprite.Rect.Y+source.Sprite.Rect.Height,
 	)
 	atlasBounds := atlasImg.Bounds()
 	if rect.Dx() <= 0 || rect.Dy() <= 0 {
-		return nil, fmt.Errorf("render: sprite %s has invalid rect %v", item.ID, rect)
+		return nil, fmt.Errorf("render: sprite %s has invalid rect %v", source.ID, rect)
 	}
 	if !rect.In(atlasBounds) {
 		rect = rect.Intersect(atlasBounds)
 		if rect.Dx() <= 0 || rect.Dy() <= 0 {
-			return nil, fmt.Errorf("render: sprite %s rect out of atlas bounds %v", item.ID, atlasBounds)
+			return nil, fmt.Errorf("render: sprite %s rect out of atlas bounds %v", source.ID, atlasBounds)
 		}
 	}
 	sub := atlasImg.SubImage(rect)
 	spriteImg := ebiten.NewImageFromImage(sub)
-	m.spriteCache[spriteKey(item)] = spriteImg
+	m.spriteCache[spriteKey(source)] = spriteImg
 	return spriteImg, nil
 }
 
+// SpriteDensity returns the pixel density of the image ResolveSprite returns for item
+// relative to the item's logical size: 1 for the base texture, 2 for an @2x variant.
+func (m *AtlasManager) SpriteDensity(item *assets.PackageItem) float64 {
+	_, density := m.spriteSource(item)
+	return density
+}
+
+// spriteSource picks the high-resolution variant for the current content scale level
+// when its atlas is loaded, falling back to item itself.
+// 对应 TypeScript 版本 GLoader.loadFromPackage 中的 getHighResolution()
+func (m *AtlasManager) spriteSource(item *assets.PackageItem) (*assets.PackageItem, float64) {
+	variant := item.HighResolutionItem(core.ContentScaleLevel)
+	if variant == item || variant.Sprite == nil || variant.Sprite.Atlas == nil {
+		return item, 1
+	}
+	if _, ok := m.atlasImages[atlasKey(variant.Sprite.Atlas)]; !ok {
+		return item, 1
+	}
+	return variant, item.ResolutionScale(variant)
+}
+
+// ReloadPackage loads the atlas textures of pkg, a reparsed copy of old, before
+// evicting everything cached for old. When loading fails nothing is evicted, so
+// components built from old keep rendering, and the textures loaded so far are
+// released.
+func (m *AtlasManager) ReloadPackage(ctx context.Context, old, pkg *assets.Package) error {
+	staged := NewAtlasManager(m.loader)
+	if err := staged.LoadPackage(ctx, pkg); err != nil {
+		for _, img := range staged.atlasImages {
+			img.Deallocate()
+		}
+		return err
+	}
+	m.UnloadPackage(old)
+	maps.Copy(m.atlasImages, staged.atlasImages)
+	return nil
+}
+
+// UnloadPackage evicts every atlas texture, sprite and movie clip frame cached for
+// the package and releases their GPU memory. A later LoadPackage reloads them.
+func (m *AtlasManager) UnloadPackage(pkg *assets.Package) {
+	if pkg == nil {
+		return
+	}
+	prefix := pkg.ID + ":"
+	for key, img := range m.spriteCache {
+		if strings.HasPrefix(key, prefix) {
+			img.Deallocate()
+			delete(m.spriteCache, key)
+		}
+	}
+	moviePrefix := "mc:" + prefix
+	for key, img := range m.movieCache {
+		if strings.HasPrefix(key, moviePrefix) {
+			img.Deallocate()
+			delete(m.movieCache, key)
+		}
+	}
+	for key, img := range m.atlasImages {
+		if strings.HasPrefix(key, prefix) {
+			img.Deallocate()
+			delete(m.atlasImages, key)
+		}
+	}
+}
+
 func atlasKey(item *assets.PackageItem) string {
 	if item == nil {
 		return ""
@@ -126,11 +238,13 @@ func (m *AtlasManager) AddAtlasImage(item *assets.PackageItem, img *ebiten.Image
 
 // GetAtlasImage returns the loaded atlas texture for the given PackageItem.
 // This is used for BMFont atlas mode where glyphs are extracted from a single atlas texture.
+// A loaded high-resolution variant of the atlas is preferred; see AtlasDensity.
 func (m *AtlasManager) GetAtlasImage(item *assets.PackageItem) (*ebiten.Image, error) {
 	if item == nil {
 		return nil, errors.New("render: nil package item")
 	}
-	key := atlasKey(item)
+	source, _ := m.atlasSource(item)
+	key := atlasKey(source)
 	img, ok := m.atlasImages[key]
 	if !ok {
 		return nil, fmt.Errorf("render: atlas texture not loaded for %s", key)
@@ -138,6 +252,23 @@ func (m *AtlasManager) GetAtlasImage(item *assets.PackageItem) (*ebiten.Image, e
 	return img, nil
 }
 
+// AtlasDensity returns the pixel density of the texture GetAtlasImage returns for item.
+func (m *AtlasManager) AtlasDensity(item *assets.PackageItem) float64 {
+	_, density := m.atlasSource(item)
+	return density
+}
+
+func (m *AtlasManager) atlasSource(item *assets.PackageItem) (*assets.PackageItem, float64) {
+	variant := item.HighResolutionItem(core.ContentScaleLevel)
+	if variant == item {
+		return item, 1
+	}
+	if _, ok := m.atlasImages[atlasKey(variant)]; !ok {
+		return item, 1
+	}
+	return variant, item.ResolutionScale(variant)
+}
+
 // ResolveMovieClipFrame returns an Ebiten image for the supplied movie clip frame.
 func (m *AtlasManager) ResolveMovieClipFrame(item *assets.PackageItem, frame *assets.MovieClipFrame) (*ebiten.Image, error) {
 	if frame == nil || frame.Sprite == nil || frame.Sprite.Atlas == nil {
@@ -2,12 +2,17 @@ package render
 
 import (
 	"context"
+	"errors"
 	"image"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
+
+	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
 )
 
 func TestAtlasManagerWithRealFUI(t *testing.T) {
@@ -52,3 +57,135 @@ func TestAtlasManagerWithRealFUI(t *testing.T) {
 	}
 
 }
+
+func TestAtlasManagerLoadPackageReportsMissingAtlas(t *testing.T) {
+	root := filepath.Join("..", "..", "..", "demo", "assets")
+	data, err := os.ReadFile(filepath.Join(root, "Bag.fui"))
+	if err != nil {
+		t.Skipf("demo assets not available: %v", err)
+	}
+	pkg, err := assets.ParsePackage(data, "Bag")
+	if err != nil {
+		t.Fatalf("ParsePackage failed: %v", err)
+	}
+
+	// Only the descriptor is present, so every atlas request fails.
+	fsys := fstest.MapFS{"Bag.fui": {Data: data}}
+	manager := NewAtlasManager(assets.NewFSLoader(fsys, ""))
+	err = manager.LoadPackage(context.Background(), pkg)
+	var batchErr *assets.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *assets.BatchError, got %v", err)
+	}
+	if len(batchErr.Keys()) == 0 {
+		t.Fatalf("expected failed atlas keys")
+	}
+}
+
+func TestAtlasManagerReloadPackageKeepsOldOnFailure(t *testing.T) {
+	root := filepath.Join("..", "..", "..", "demo", "assets")
+	data, err := os.ReadFile(filepath.Join(root, "Bag.fui"))
+	if err != nil {
+		t.Skipf("demo assets not available: %v", err)
+	}
+	parse := func() *assets.Package {
+		pkg, err := assets.ParsePackage(data, "Bag")
+		if err != nil {
+			t.Fatalf("ParsePackage failed: %v", err)
+		}
+		return pkg
+	}
+	old := parse()
+	manager := NewAtlasManager(assets.NewFileLoader(root))
+	if err := manager.LoadPackage(context.Background(), old); err != nil {
+		t.Fatalf("LoadPackage failed: %v", err)
+	}
+	loaded := len(manager.atlasImages)
+	if loaded == 0 {
+		t.Skip("no atlases in package")
+	}
+
+	// The republished package references an atlas that has not been written yet.
+	broken := parse()
+	for _, item := range broken.Items {
+		if item.Type == assets.PackageItemTypeAtlas {
+			item.File = "Bag_missing.png"
+		}
+	}
+	if err := manager.ReloadPackage(context.Background(), old, broken); err == nil {
+		t.Fatalf("expected ReloadPackage to fail")
+	}
+	if len(manager.atlasImages) != loaded {
+		t.Fatalf("expected the old atlases to be kept, got %d of %d", len(manager.atlasImages), loaded)
+	}
+
+	if err := manager.ReloadPackage(context.Background(), old, parse()); err != nil {
+		t.Fatalf("ReloadPackage failed: %v", err)
+	}
+	if len(manager.atlasImages) != loaded {
+		t.Fatalf("expected the reloaded atlases to replace the old ones, got %d of %d", len(manager.atlasImages), loaded)
+	}
+}
+
+func TestAtlasManagerHighResolutionSprite(t *testing.T) {
+	pkg := &assets.Package{ID: "hres0001", Name: "HighRes"}
+	atlas := &assets.PackageItem{ID: "atlas0", Type: assets.PackageItemTypeAtlas, Width: 16, Height: 16}
+	atlas2x := &assets.PackageItem{ID: "atlas0_2x", Type: assets.PackageItemTypeAtlas, Width: 32, Height: 32}
+	icon := &assets.PackageItem{ID: "icon", Type: assets.PackageItemTypeImage, Width: 10, Height: 8,
+		HighResolution: []string{"icon2x"},
+		Sprite:         &assets.AtlasSprite{Atlas: atlas, Rect: assets.Rect{Width: 10, Height: 8}}}
+	icon2x := &assets.PackageItem{ID: "icon2x", Type: assets.PackageItemTypeImage, Width: 20, Height: 16,
+		Sprite: &assets.AtlasSprite{Atlas: atlas2x, Rect: assets.Rect{Width: 20, Height: 16}}}
+	for _, item := range []*assets.PackageItem{atlas, atlas2x, icon, icon2x} {
+		pkg.AddItem(item)
+	}
+
+	manager := NewAtlasManager(nil)
+	if err := manager.AddAtlasImage(atlas, ebiten.NewImage(16, 16)); err != nil {
+		t.Fatalf("AddAtlasImage failed: %v", err)
+	}
+
+	saved := core.ContentScaleLevel
+	t.Cleanup(func() { core.ContentScaleLevel = saved })
+
+	// @2x atlas 尚未加载时回退到基础纹理
+	core.ContentScaleLevel = 1
+	if d := manager.SpriteDensity(icon); d != 1 {
+		t.Fatalf("expected density 1 without the @2x atlas, got %v", d)
+	}
+
+	if err := manager.AddAtlasImage(atlas2x, ebiten.NewImage(32, 32)); err != nil {
+		t.Fatalf("AddAtlasImage failed: %v", err)
+	}
+	cases := []struct {
+		level   int
+		size    image.Point
+		density float64
+	}{
+		{0, image.Pt(10, 8), 1},
+		{1, image.Pt(20, 16), 2},
+		{0, image.Pt(10, 8), 1}, // 切回基础级别无需重建
+	}
+	for _, tc := range cases {
+		core.ContentScaleLevel = tc.level
+		sprite, err := manager.ResolveSprite(icon)
+		if err != nil {
+			t.Fatalf("level %d: ResolveSprite failed: %v", tc.level, err)
+		}
+		if got := sprite.(*ebiten.Image).Bounds().Size(); got != tc.size {
+			t.Fatalf("level %d: expected sprite size %v, got %v", tc.level, tc.size, got)
+		}
+		if d := manager.SpriteDensity(icon); d != tc.density {
+			t.Fatalf("level %d: expected density %v, got %v", tc.level, tc.density, d)
+		}
+	}
+
+	core.ContentScaleLevel = 1
+	img, err := manager.GetAtlasImage(atlas)
+	if err != nil {
+		t.Fatalf("GetAtlasImage failed: %v", err)
+	}
+	if img.Bounds().Dx() != 16 || manager.AtlasDensity(atlas) != 1 {
+		t.Fatalf("atlas without HighResolution entries should keep the base texture")
+	}
+}
@@ -1369,7 +1369,7 @@ func drawPackageItem(target *ebiten.Image, item *assets.PackageItem, geo ebiten.
 	}
 
 	opts := &ebiten.DrawImageOptions{
-		GeoM: geo,
+		GeoM: densityGeo(geo, atlas.SpriteDensity(item)),
 	}
 	applyTintColor(opts, nil, alpha, sprite)
 
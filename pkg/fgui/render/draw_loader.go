@@ -95,8 +95,13 @@ func renderLoaderPackageItem(target *ebiten.Image, loader *widgets.GLoader, item
 	method := int(loader.FillMethod())
 	amount := loader.FillAmount()
 
+	// 高清纹理（@2x/@3x）在像素空间绘制，再整体缩回逻辑尺寸
+	density := atlas.SpriteDensity(item)
+	geo = densityGeo(geo, density)
+
 	if grid := loader.Scale9Grid(); grid != nil {
 		debugLabel := fmt.Sprintf("loader=%s item=%s", safeLoaderName(loader), item.ID)
+		grid = assets.ScaledRect(grid, density)
 		bounds := img.Bounds()
 		left := int(grid.X)
 		top := int(grid.Y)
@@ -104,6 +109,8 @@ func renderLoaderPackageItem(target *ebiten.Image, loader *widgets.GLoader, item
 		bottom := int(math.Max(0, float64(bounds.Dy())-float64(grid.Y+grid.Height)))
 		slice := nineSlice{left: left, right: right, top: top, bottom: bottom}
 		dstW, dstH := loader.ContentSize()
+		dstW *= density
+		dstH *= density
 		if dstW <= 0 {
 			dstW = float64(bounds.Dx())
 		}
@@ -128,6 +135,8 @@ func renderLoaderPackageItem(target *ebiten.Image, loader *widgets.GLoader, item
 	}
 
 	dstW, dstH := loader.ContentSize()
+	dstW *= density
+	dstH *= density
 	if dstW <= 0 {
 		dstW = float64(img.Bounds().Dx())
 	}
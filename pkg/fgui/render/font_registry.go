@@ -11,6 +11,10 @@ var (
 	bitmapFonts sync.Map // key -> *assets.BitmapFont
 )
 
+func init() {
+	assets.OnPackageUnload(UnregisterBitmapFonts)
+}
+
 // RegisterBitmapFonts registers all bitmap fonts contained in the given package.
 func RegisterBitmapFonts(pkg *assets.Package) {
 	if pkg == nil || len(pkg.Items) == 0 {
@@ -34,6 +38,28 @@ func RegisterBitmapFonts(pkg *assets.Package) {
 	}
 }
 
+// UnregisterBitmapFonts removes the bitmap fonts registered for the given package.
+// Aliases that were re-registered by another package are left untouched.
+func UnregisterBitmapFonts(pkg *assets.Package) {
+	if pkg == nil || len(pkg.Items) == 0 {
+		return
+	}
+	for _, item := range pkg.Items {
+		if item == nil || item.Type != assets.PackageItemTypeFont {
+			continue
+		}
+		for _, alias := range fontAliases(pkg, item) {
+			key := normalizeFontKey(alias)
+			if key == "" {
+				continue
+			}
+			if font, ok := bitmapFonts.Load(key); ok && font.(*assets.BitmapFont).Item == item {
+				bitmapFonts.Delete(key)
+			}
+		}
+	}
+}
+
 func fontAliases(pkg *assets.Package, item *assets.PackageItem) []string {
 	if pkg == nil || item == nil {
 		return nil
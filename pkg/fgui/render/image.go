@@ -30,6 +30,17 @@ var (
 
 const debugLargeDimensionLimit = 8192.0
 
+// densityGeo 在 geo 之前插入 1/density 缩放，使高清（@2x/@3x）纹理按逻辑尺寸绘制
+func densityGeo(geo ebiten.GeoM, density float64) ebiten.GeoM {
+	if density == 1 || density <= 0 {
+		return geo
+	}
+	pre := ebiten.GeoM{}
+	pre.Scale(1/density, 1/density)
+	pre.Concat(geo)
+	return pre
+}
+
 func drawNineSlice(target *ebiten.Image, baseGeo ebiten.GeoM, img *ebiten.Image, slice nineSlice, dstW, dstH float64, alpha float64, tint *color.NRGBA, scaleByTile bool, tileGrid int, sprite *laya.Sprite, debugLabel string) {
 	if target == nil || img == nil {
 		return
@@ -1167,15 +1167,17 @@ func drawBitmapRun(dst *ebiten.Image, run *renderedTextRun, startX float64, line
 					// bg.texture = Laya.Texture.create(mainTexture,
 					//     bx + mainSprite.rect.x, by + mainSprite.rect.y, bg.width, bg.height);
 					// bx, by 是相对于 font sprite rect 的坐标，需要加上 rect 偏移
-					x0 := int(glyph.AtlasX) + glyph.SpriteRectX
-					y0 := int(glyph.AtlasY) + glyph.SpriteRectY
-					x1 := x0 + int(glyph.Width)
-					y1 := y0 + int(glyph.Height)
+					// 高清 atlas 的像素坐标是逻辑坐标的 density 倍
+					density := atlas.AtlasDensity(glyph.Item)
+					x0 := int((float64(glyph.AtlasX) + float64(glyph.SpriteRectX)) * density)
+					y0 := int((float64(glyph.AtlasY) + float64(glyph.SpriteRectY)) * density)
+					x1 := x0 + int(glyph.Width*density)
+					y1 := y0 + int(glyph.Height*density)
 
 					// 边界检查
 					if x0 >= 0 && y0 >= 0 && x1 <= bounds.Dx() && y1 <= bounds.Dy() {
 						subImg := atlasImage.SubImage(image.Rect(x0, y0, x1, y1)).(*ebiten.Image)
-						opts := &ebiten.DrawImageOptions{GeoM: local}
+						opts := &ebiten.DrawImageOptions{GeoM: densityGeo(local, density)}
 						// 应用文本颜色
 						opts.ColorScale.ScaleWithColor(run.color)
 						dst.DrawImage(subImg, opts)
@@ -56,16 +56,18 @@ func (r *TextureRenderer) Render(
 	tint := parseColor(cmd.Color)
 
 	// 计算源尺寸和目标尺寸
+	// 高清纹理的像素尺寸是逻辑尺寸的 density 倍
+	density := r.atlas.SpriteDensity(item)
 	bounds := img.Bounds()
 	srcW := float64(bounds.Dx())
 	srcH := float64(bounds.Dy())
 	dstW := cmd.Dest.W
 	dstH := cmd.Dest.H
 	if dstW <= 0 {
-		dstW = srcW
+		dstW = srcW / density
 	}
 	if dstH <= 0 {
-		dstH = srcH
+		dstH = srcH / density
 	}
 
 	// 获取 sprite offset（图像裁剪后的偏移）
@@ -78,9 +80,9 @@ func (r *TextureRenderer) Render(
 	// 根据模式选择渲染方法
 	switch cmd.Mode {
 	case laya.TextureModeScale9:
-		return r.renderScale9(target, img, item, geo, dstW, dstH, srcW, srcH, alpha, tint, cmd, sprite, spriteOffsetX, spriteOffsetY)
+		return r.renderScale9(target, img, item, geo, dstW, dstH, srcW, srcH, density, alpha, tint, cmd, sprite, spriteOffsetX, spriteOffsetY)
 	case laya.TextureModeTile:
-		return r.renderTiled(target, img, geo, dstW, dstH, bounds, alpha, tint, cmd, sprite, spriteOffsetX, spriteOffsetY)
+		return r.renderTiled(target, img, geo, dstW, dstH, bounds, density, alpha, tint, cmd, sprite, spriteOffsetX, spriteOffsetY)
 	default: // TextureModeSimple
 		return r.renderSimple(target, img, geo, dstW, dstH, srcW, srcH, alpha, tint, sprite, cmd, spriteOffsetX, spriteOffsetY)
 	}
@@ -175,6 +177,7 @@ func (r *TextureRenderer) renderScale9(
 	item *assets.PackageItem,
 	parentGeo ebiten.GeoM,
 	dstW, dstH, srcW, srcH float64,
+	density float64,
 	alpha float64,
 	tint *color.NRGBA,
 	cmd *laya.TextureCommand,
@@ -195,11 +198,12 @@ func (r *TextureRenderer) renderScale9(
 		return r.renderSimple(target, img, parentGeo, dstW, dstH, srcW, srcH, alpha, tint, sprite, cmd, spriteOffsetX, spriteOffsetY)
 	}
 
-	// 计算九宫格切片
-	left := clampFloat(grid.X, 0, srcW)
-	top := clampFloat(grid.Y, 0, srcH)
-	right := clampFloat(srcW-grid.X-grid.W, 0, srcW)
-	bottom := clampFloat(srcH-grid.Y-grid.H, 0, srcH)
+	// 计算九宫格切片（九宫格是逻辑坐标，高清纹理需按 density 换算到像素坐标）
+	gx, gy, gw, gh := grid.X*density, grid.Y*density, grid.W*density, grid.H*density
+	left := clampFloat(gx, 0, srcW)
+	top := clampFloat(gy, 0, srcH)
+	right := clampFloat(srcW-gx-gw, 0, srcW)
+	bottom := clampFloat(srcH-gy-gh, 0, srcH)
 
 	slice := nineSlice{
 		left:   int(left),
@@ -243,11 +247,13 @@ func (r *TextureRenderer) renderScale9(
 	// 4. 应用父变换
 	localGeo.Concat(parentGeo)
 
-	drawNineSlice(target, localGeo, img, slice, dstW, dstH, alpha, tint,
+	// 高清纹理在像素空间切片，再整体缩回逻辑尺寸
+	localGeo = densityGeo(localGeo, density)
+	drawNineSlice(target, localGeo, img, slice, dstW*density, dstH*density, alpha, tint,
 		cmd.ScaleByTile, cmd.TileGridIndice, sprite, debugLabel)
 
 	if debugNineSliceOverlayEnabled {
-		drawNineSliceOverlay(target, localGeo, slice, dstW, dstH)
+		drawNineSliceOverlay(target, localGeo, slice, dstW*density, dstH*density)
 	}
 
 	return nil
@@ -260,6 +266,7 @@ func (r *TextureRenderer) renderTiled(
 	parentGeo ebiten.GeoM,
 	dstW, dstH float64,
 	bounds image.Rectangle,
+	density float64,
 	alpha float64,
 	tint *color.NRGBA,
 	cmd *laya.TextureCommand,
@@ -293,11 +300,12 @@ func (r *TextureRenderer) renderTiled(
 
 	// 3. 应用父变换
 	localGeo.Concat(parentGeo)
+	localGeo = densityGeo(localGeo, density)
 
-	// 平铺渲染
+	// 平铺渲染（高清纹理按像素尺寸平铺后整体缩回逻辑尺寸）
 	tileImagePatchWithFlip(target, localGeo, flipGeo, img,
 		0, 0, float64(bounds.Dx()), float64(bounds.Dy()),
-		0, 0, dstW, dstH,
+		0, 0, dstW*density, dstH*density,
 		alpha, tint, sprite, debugLabel)
 
 	return nil
@@ -0,0 +1,604 @@
+package spec
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/builder"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// packageSeq 为每次编译生成不同的包 ID，避免覆盖注册表中之前编译的包
+var packageSeq atomic.Uint64
+
+// Build 编译 root 并用 factory 构建根组件。每次调用都会编译并注册一个新的包，包由 factory 的
+// PackageManager 按根组件跟踪（factory 没有时自动创建）：根组件释放时注销该包，未释放就丢弃的
+// 根组件被垃圾回收后由 PackageManager.Collect 注销。需要多次创建同一界面时先调用 Compile，
+// 再用 Factory.BuildComponent 构建返回的条目，不再需要时调用 Factory.UnregisterPackage。
+// Image、Button 等通过 URL 引用的资源所在的包需要已通过同一个 factory 加载
+func Build(ctx context.Context, factory *builder.Factory, root *Component) (*core.GComponent, error) {
+	if factory == nil {
+		return nil, fmt.Errorf("spec: factory is nil")
+	}
+	pkg, item, err := Compile(root)
+	if err != nil {
+		return nil, err
+	}
+	packages := factory.PackageManager()
+	if packages == nil {
+		packages = builder.NewPackageManager(factory, nil)
+	}
+	if err := packages.AddTransientPackage(ctx, pkg); err != nil {
+		factory.UnregisterPackage(pkg)
+		return nil, err
+	}
+	comp, err := factory.BuildComponent(ctx, pkg, item)
+	if err != nil {
+		packages.Collect()
+		return nil, err
+	}
+	comp.OnDispose(func() { packages.ReleaseComponent(comp) })
+	return comp, nil
+}
+
+// Compile 把 root 及其引用的所有组件规格编译为内存中的包，返回包和 root 对应的条目。
+// 包的 ID 和名称自动生成（8 个字符，以便组成 ui:// 地址），包只依赖 URL 引用的已加载包
+func Compile(root *Component) (*assets.Package, *assets.PackageItem, error) {
+	if root == nil {
+		return nil, nil, fmt.Errorf("spec: root component is nil")
+	}
+	c := &compiler{
+		pkgID:    packageID(packageSeq.Add(1)),
+		ids:      make(map[*Component]string),
+		visiting: make(map[*Component]bool),
+		names:    make(map[string]bool),
+	}
+	rootID, err := c.component(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg, err := assets.CompileComponents(c.pkgID, c.pkgID, c.sources)
+	if err != nil {
+		return nil, nil, fmt.Errorf("spec: %w", err)
+	}
+	for _, dep := range c.deps {
+		pkg.Dependencies = append(pkg.Dependencies, assets.Dependency{ID: dep.ID, Name: dep.Name})
+	}
+	return pkg, pkg.ItemByID(rootID), nil
+}
+
+// packageID 返回第 seq 次编译的包 ID
+func packageID(seq uint64) string {
+	id := strconv.FormatUint(seq, 36)
+	return "s" + strings.Repeat("0", max(0, 7-len(id))) + id
+}
+
+// compiler 保存一次编译的状态，每个组件规格编译为一个 ComponentSource
+type compiler struct {
+	pkgID    string
+	ids      map[*Component]string
+	visiting map[*Component]bool
+	names    map[string]bool
+	sources  []assets.ComponentSource
+	deps     []*assets.Package
+}
+
+// component 编译组件规格（只编译一次）并返回条目 ID
+func (c *compiler) component(comp *Component) (string, error) {
+	if id, ok := c.ids[comp]; ok {
+		return id, nil
+	}
+	if c.visiting[comp] {
+		return "", fmt.Errorf("spec: component %s contains itself", comp.Name)
+	}
+	name := c.resourceName(comp)
+	c.visiting[comp] = true
+	defer delete(c.visiting, comp)
+
+	l := &lowering{c: c, comp: comp, path: name, byName: make(map[string]string), ctrls: make(map[string]*Controller)}
+	root, err := l.lower()
+	if err != nil {
+		return "", err
+	}
+	data, err := xml.Marshal(root)
+	if err != nil {
+		return "", fmt.Errorf("spec: component %s: %w", name, err)
+	}
+	// 子组件先于引用它的组件加入，根组件在最后
+	id := strconv.Itoa(len(c.sources))
+	c.ids[comp] = id
+	c.sources = append(c.sources, assets.ComponentSource{ID: id, Name: name, Exported: true, XML: data})
+	return id, nil
+}
+
+// resourceName 返回组件的资源名称，同名组件加上序号以便按名称查找
+func (c *compiler) resourceName(comp *Component) string {
+	base := comp.Name
+	if base == "" {
+		base = "Component"
+	}
+	name := base
+	for i := 2; c.names[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	c.names[name] = true
+	return name
+}
+
+// resolve 按 URL 查找已加载包中的资源并记录依赖，want 不为 0 时检查资源类型
+func (c *compiler) resolve(url string, want assets.PackageItemType) (*assets.PackageItem, error) {
+	if url == "" {
+		return nil, fmt.Errorf("missing resource url")
+	}
+	item := assets.GetItemByURL(url)
+	if item == nil || item.Owner == nil {
+		return nil, fmt.Errorf("resource %s not found in loaded packages", url)
+	}
+	if want != 0 && item.Type != want {
+		return nil, fmt.Errorf("resource %s is not a %s", url, itemTypeName(want))
+	}
+	for _, dep := range c.deps {
+		if dep == item.Owner {
+			return item, nil
+		}
+	}
+	c.deps = append(c.deps, item.Owner)
+	return item, nil
+}
+
+func itemTypeName(t assets.PackageItemType) string {
+	switch t {
+	case assets.PackageItemTypeImage:
+		return "image"
+	case assets.PackageItemTypeComponent:
+		return "component"
+	}
+	return "resource"
+}
+
+// lowering 把一个组件规格转换为编辑器组件 XML
+type lowering struct {
+	c    *compiler
+	comp *Component
+	path string
+	// child 是正在转换的子对象名称，用于错误信息
+	child  string
+	byName map[string]string
+	ctrls  map[string]*Controller
+}
+
+func (l *lowering) errorf(format string, args ...any) error {
+	where := l.path
+	if l.child != "" {
+		where += "/" + l.child
+	}
+	return fmt.Errorf("spec: %s: %s", where, fmt.Sprintf(format, args...))
+}
+
+func (l *lowering) lower() (*node, error) {
+	comp := l.comp
+	root := newNode("component")
+	root.set("size", pair(comp.Width, comp.Height))
+	root.set("overflow", string(comp.Overflow))
+	if comp.Overflow == OverflowScroll {
+		root.set("scroll", string(comp.Scroll))
+	}
+	root.set("customData", comp.Data)
+	if comp.Extension != nil {
+		ext := comp.Extension.node()
+		root.set("extention", ext.name)
+		root.add(ext)
+	}
+
+	for i := range comp.Controllers {
+		ctrl := &comp.Controllers[i]
+		if ctrl.Name == "" || l.ctrls[ctrl.Name] != nil {
+			return nil, l.errorf("controller %d has an empty or duplicate name %q", i, ctrl.Name)
+		}
+		l.ctrls[ctrl.Name] = ctrl
+		n := root.add(newNode("controller"))
+		n.set("name", ctrl.Name)
+		pages := make([]string, 0, len(ctrl.Pages)*2)
+		for id, page := range ctrl.Pages {
+			pages = append(pages, strconv.Itoa(id), page)
+		}
+		n.set("pages", strings.Join(pages, ","))
+		if ctrl.HomePage > 0 {
+			n.set("homePageType", "specific")
+			n.set("homePage", itoa(ctrl.HomePage))
+		}
+	}
+
+	// 先分配全部子对象的 ID，关联可以引用后面的子对象
+	ids := make([]string, len(comp.Children))
+	for i, child := range comp.Children {
+		if child == nil {
+			return nil, l.errorf("child %d is nil", i)
+		}
+		ids[i] = "n" + strconv.Itoa(i)
+		name := child.object().Name
+		if name == "" {
+			name = ids[i]
+		}
+		if _, ok := l.byName[name]; !ok {
+			l.byName[name] = ids[i]
+		}
+	}
+	list := root.add(newNode("displayList"))
+	for i, child := range comp.Children {
+		obj := child.object()
+		l.child = obj.Name
+		if l.child == "" {
+			l.child = ids[i]
+		}
+		n := newNode("")
+		n.set("id", ids[i])
+		n.set("name", l.child)
+		if err := child.lower(l, n); err != nil {
+			return nil, err
+		}
+		if err := l.object(n, obj); err != nil {
+			return nil, err
+		}
+		list.add(n)
+	}
+	l.child = ""
+
+	for _, trans := range comp.Transitions {
+		if err := l.transition(root.add(newNode("transition")), &trans); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// object 写入子对象的基础属性、齿轮和关联
+func (l *lowering) object(n *node, o *Object) error {
+	n.set("xy", pair(o.X, o.Y))
+	n.set("size", pair(o.Width, o.Height))
+	if o.PivotX != 0 || o.PivotY != 0 || o.PivotAsAnchor {
+		n.set("pivot", joinFloats(o.PivotX, o.PivotY))
+		n.setBool("anchor", o.PivotAsAnchor)
+	}
+	if o.ScaleX != 0 || o.ScaleY != 0 {
+		n.set("scale", joinFloats(orOne(o.ScaleX), orOne(o.ScaleY)))
+	}
+	n.setFloat("rotation", o.Rotation)
+	if o.Alpha != 0 && o.Alpha != 1 {
+		n.setFloat("alpha", o.Alpha)
+	}
+	if o.Hidden {
+		n.set("visible", "false")
+	}
+	if o.Untouchable {
+		n.set("touchable", "false")
+	}
+	n.setBool("grayed", o.Grayed)
+	n.set("tooltips", o.Tooltips)
+	n.set("customData", o.Data)
+
+	for _, gear := range o.Gears {
+		if err := l.gear(n, &gear); err != nil {
+			return err
+		}
+	}
+	for _, rel := range o.Relations {
+		r := n.add(newNode("relation"))
+		if rel.Target != "" {
+			id, ok := l.byName[rel.Target]
+			if !ok {
+				return l.errorf("relation target %q not found", rel.Target)
+			}
+			r.set("target", id)
+		}
+		r.set("sidePair", rel.Sides)
+	}
+	return nil
+}
+
+func (l *lowering) gear(n *node, gear *Gear) error {
+	if gear.Type < 0 || int(gear.Type) >= len(gearTags) {
+		return l.errorf("unknown gear type %d", gear.Type)
+	}
+	ctrl := l.ctrls[gear.Controller]
+	if ctrl == nil {
+		return l.errorf("gear controller %q not found", gear.Controller)
+	}
+	g := n.add(newNode(gearTags[gear.Type]))
+	g.set("controller", ctrl.Name)
+	var pages, values []string
+	if gear.Type == GearDisplay || gear.Type == GearDisplay2 {
+		for _, name := range gear.Pages {
+			id, err := l.page(ctrl.Name, name)
+			if err != nil {
+				return err
+			}
+			pages = append(pages, id)
+		}
+	} else {
+		for page := range gear.Values {
+			if _, err := l.page(ctrl.Name, page); err != nil {
+				return err
+			}
+		}
+		// 按控制器的页面顺序写出，结果与 map 的遍历顺序无关
+		for id, page := range ctrl.Pages {
+			if value, ok := gear.Values[page]; ok {
+				pages = append(pages, strconv.Itoa(id))
+				values = append(values, value)
+			}
+		}
+		g.set("values", strings.Join(values, "|"))
+		g.set("default", gear.Default)
+	}
+	g.set("pages", strings.Join(pages, ","))
+	if gear.Tween {
+		g.setBool("tween", true)
+		g.set("ease", gear.Ease)
+		g.setFloat("duration", gear.Duration)
+		g.setFloat("delay", gear.Delay)
+	}
+	return nil
+}
+
+// page 返回所在组件控制器中名为 page 的页面 ID
+func (l *lowering) page(ctrlName, page string) (string, error) {
+	ctrl := l.ctrls[ctrlName]
+	if ctrl == nil {
+		return "", l.errorf("controller %q not found", ctrlName)
+	}
+	if id := pageIndex(ctrl.Pages, page); id >= 0 {
+		return strconv.Itoa(id), nil
+	}
+	return "", l.errorf("controller %q has no page %q", ctrlName, page)
+}
+
+func (l *lowering) transition(n *node, trans *Transition) error {
+	n.set("name", trans.Name)
+	n.setBool("autoPlay", trans.AutoPlay)
+	if trans.AutoPlayRepeat != 0 {
+		n.set("autoPlayRepeat", itoa(trans.AutoPlayRepeat))
+	}
+	n.setFloat("autoPlayDelay", trans.AutoPlayDelay)
+	for _, item := range trans.Items {
+		in := n.add(newNode("item"))
+		in.set("time", itoa(item.Frame))
+		in.set("type", string(item.Type))
+		if item.Target != "" {
+			id, ok := l.byName[item.Target]
+			if !ok {
+				return l.errorf("transition %q target %q not found", trans.Name, item.Target)
+			}
+			in.set("target", id)
+		}
+		in.set("label", item.Label)
+		if !item.Tween {
+			in.set("value", item.Value)
+			continue
+		}
+		in.setBool("tween", true)
+		in.set("duration", itoa(item.Duration))
+		in.set("ease", item.Ease)
+		if item.Repeat != 0 {
+			in.set("repeat", itoa(item.Repeat))
+		}
+		in.setBool("yoyo", item.Yoyo)
+		in.set("startValue", item.Start)
+		in.set("endValue", item.End)
+	}
+	return nil
+}
+
+// setSource 写入引用资源的 src 和 pkg 属性
+func (l *lowering) setSource(n *node, item *assets.PackageItem) {
+	n.set("src", item.ID)
+	if item.Owner.ID != l.c.pkgID {
+		n.set("pkg", item.Owner.ID)
+	}
+}
+
+// itemURL 返回列表项资源的 ui:// 地址，两者都为空时返回空字符串
+func (l *lowering) itemURL(comp *Component, url string) (string, error) {
+	if comp != nil {
+		id, err := l.c.component(comp)
+		if err != nil {
+			return "", err
+		}
+		return "ui://" + l.c.pkgID + id, nil
+	}
+	if url == "" {
+		return "", nil
+	}
+	if _, err := l.c.resolve(url, assets.PackageItemTypeComponent); err != nil {
+		return "", l.errorf("%v", err)
+	}
+	return url, nil
+}
+
+// instance 写入组件实例，want 不为 0 时检查组件的扩展类型并返回实例的扩展属性节点（<Button> 等）
+func (l *lowering) instance(n *node, comp *Component, url string, pages map[string]string, want assets.ObjectType) (*node, error) {
+	n.name = "component"
+	var typ assets.ObjectType = assets.ObjectTypeComponent
+	var ctrls []assets.ControllerData
+	switch {
+	case comp != nil:
+		id, err := l.c.component(comp)
+		if err != nil {
+			return nil, err
+		}
+		n.set("src", id)
+		if comp.Extension != nil {
+			typ = comp.Extension.objectType()
+		}
+		for _, ctrl := range comp.Controllers {
+			data := assets.ControllerData{Name: ctrl.Name, PageNames: ctrl.Pages}
+			for id := range ctrl.Pages {
+				data.PageIDs = append(data.PageIDs, strconv.Itoa(id))
+			}
+			ctrls = append(ctrls, data)
+		}
+	case url != "":
+		item, err := l.c.resolve(url, assets.PackageItemTypeComponent)
+		if err != nil {
+			return nil, l.errorf("%v", err)
+		}
+		l.setSource(n, item)
+		typ = item.ObjectType
+		if item.Component != nil {
+			ctrls = item.Component.Controllers
+		}
+	default:
+		return nil, l.errorf("missing component or url")
+	}
+	if want != 0 && typ != want {
+		return nil, l.errorf("component is not a %s", extensionTag(want))
+	}
+
+	var pairs []string
+	for _, name := range sortedKeys(pages) {
+		id, ok := instancePage(ctrls, name, pages[name])
+		if !ok {
+			return nil, l.errorf("component has no controller page %s/%s", name, pages[name])
+		}
+		pairs = append(pairs, name, id)
+	}
+	n.set("controller", strings.Join(pairs, ","))
+	if want == 0 {
+		return nil, nil
+	}
+	return n.add(newNode(extensionTag(want))), nil
+}
+
+func instancePage(ctrls []assets.ControllerData, ctrl, page string) (string, bool) {
+	for _, data := range ctrls {
+		if data.Name != ctrl {
+			continue
+		}
+		if i := pageIndex(data.PageNames, page); i >= 0 && i < len(data.PageIDs) {
+			return data.PageIDs[i], true
+		}
+		for _, id := range data.PageIDs {
+			if id == page {
+				return id, true
+			}
+		}
+	}
+	return "", false
+}
+
+// pageIndex 按名称查找页面，没有同名页面时把 page 当作页面序号
+func pageIndex(names []string, page string) int {
+	for i, name := range names {
+		if name == page {
+			return i
+		}
+	}
+	if i, err := strconv.Atoi(page); err == nil && i >= 0 && i < len(names) {
+		return i
+	}
+	return -1
+}
+
+func extensionTag(t assets.ObjectType) string {
+	switch t {
+	case assets.ObjectTypeButton:
+		return "Button"
+	case assets.ObjectTypeLabel:
+		return "Label"
+	case assets.ObjectTypeProgressBar:
+		return "ProgressBar"
+	case assets.ObjectTypeSlider:
+		return "Slider"
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// node 是生成的编辑器 XML 节点，空值属性不写出，编译时使用编辑器的默认值
+type node struct {
+	name     string
+	attrs    []xml.Attr
+	children []*node
+}
+
+func newNode(name string) *node {
+	return &node{name: name}
+}
+
+func (n *node) set(name, value string) {
+	if value != "" {
+		n.attrs = append(n.attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+}
+
+func (n *node) setBool(name string, value bool) {
+	if value {
+		n.set(name, "true")
+	}
+}
+
+func (n *node) setFloat(name string, value float32) {
+	if value != 0 {
+		n.set(name, joinFloats(value))
+	}
+}
+
+func (n *node) add(child *node) *node {
+	n.children = append(n.children, child)
+	return child
+}
+
+// MarshalXML 实现 xml.Marshaler
+func (n *node) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{Name: xml.Name{Local: n.name}, Attr: n.attrs}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, child := range n.children {
+		if err := e.Encode(child); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func itoa(v int) string {
+	return strconv.Itoa(v)
+}
+
+// pair 返回 "a,b"，两者都为 0 时返回空字符串
+func pair(a, b int) string {
+	if a == 0 && b == 0 {
+		return ""
+	}
+	return itoa(a) + "," + itoa(b)
+}
+
+func joinFloats(values ...float32) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+	return strings.Join(parts, ",")
+}
+
+func orOne(v float32) float32 {
+	if v == 0 {
+		return 1
+	}
+	return v
+}
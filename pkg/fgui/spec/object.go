@@ -0,0 +1,462 @@
+package spec
+
+import "github.com/chslink/fairygui/pkg/fgui/assets"
+
+// Object 是所有子对象共有的属性，与编辑器属性面板的基础属性一致
+type Object struct {
+	// Name 是子对象名称，为空时与编辑器一样使用 "n<序号>"
+	Name string
+	X, Y int
+	// Width、Height 都为 0 时使用资源或内容的默认尺寸
+	Width, Height  int
+	PivotX, PivotY float32
+	PivotAsAnchor  bool
+	// ScaleX、ScaleY 为 0 时视为 1
+	ScaleX, ScaleY float32
+	Rotation       float32
+	// Alpha 为 0 时视为 1，需要初始不可见时使用 Hidden
+	Alpha       float32
+	Hidden      bool
+	Grayed      bool
+	Untouchable bool
+	Tooltips    string
+	// Data 是子对象的自定义数据（编辑器中的 customData）
+	Data      string
+	Gears     []Gear
+	Relations []Relation
+}
+
+func (o *Object) object() *Object { return o }
+
+// GearType 是齿轮类型，顺序与编辑器和 TypeScript 版本一致
+type GearType int
+
+const (
+	GearDisplay GearType = iota
+	GearXY
+	GearSize
+	GearLook
+	GearColor
+	GearAnimation
+	GearText
+	GearIcon
+	GearDisplay2
+	GearFontSize
+)
+
+var gearTags = [...]string{"gearDisplay", "gearXY", "gearSize", "gearLook", "gearColor", "gearAni", "gearText", "gearIcon", "gearDisplay2", "gearFontSize"}
+
+// Gear 把子对象的属性绑定到所在组件的控制器页面
+type Gear struct {
+	Type       GearType
+	Controller string
+	// Pages 是 GearDisplay、GearDisplay2 中显示对象的页面名称
+	Pages []string
+	// Values 是其它齿轮在各页面（按名称）的取值，格式与编辑器相同：GearXY 为 "x,y"，
+	// GearSize 为 "w,h[,scaleX,scaleY]"，GearLook 为 "alpha,rotation,grayed,touchable"，
+	// GearColor 为 "#rrggbb[,#描边色]"，GearText、GearIcon 为文本和地址，GearFontSize 为字号
+	Values map[string]string
+	// Default 是 Values 中没有列出的页面的取值，为空时这些页面保持构建时的属性
+	Default string
+	// Tween 为 true 时切换页面以缓动过渡，Duration 为 0 时使用默认的 0.3 秒
+	Tween    bool
+	Ease     string
+	Duration float32
+	Delay    float32
+}
+
+// Relation 描述子对象与同一组件中另一个子对象或父组件的关联
+type Relation struct {
+	// Target 是关联目标子对象的名称，为空时关联到父组件
+	Target string
+	// Sides 是编辑器格式的关联边，例如 "left-left,top-top"、"width-width%"（% 表示按比例）
+	Sides string
+}
+
+// Child 是组件的子对象：*Graph、*Image、*Text、*RichText、*InputText、*Loader、*List、
+// *Nested、*Button、*Label、*ProgressBar、*Slider
+type Child interface {
+	object() *Object
+	lower(l *lowering, n *node) error
+}
+
+// GraphType 是图形的形状
+type GraphType string
+
+const (
+	GraphEmpty          GraphType = "empty"
+	GraphRect           GraphType = "rect"
+	GraphEllipse        GraphType = "eclipse"
+	GraphPolygon        GraphType = "polygon"
+	GraphRegularPolygon GraphType = "regular_polygon"
+)
+
+// Graph 描述图形（GGraph），颜色为 "#rrggbb" 或 "#aarrggbb"
+type Graph struct {
+	Object
+	// Type 为空时是不绘制内容的空图形，常用作占位和点击区域
+	Type      GraphType
+	LineSize  int
+	LineColor string
+	FillColor string
+	// Corner 是矩形的圆角半径
+	Corner float32
+	// Points 是多边形顶点坐标 x0,y0,x1,y1...
+	Points []float32
+	// Sides 是正多边形的边数
+	Sides int
+}
+
+func (g *Graph) lower(l *lowering, n *node) error {
+	n.name = "graph"
+	if g.Type == "" || g.Type == GraphEmpty {
+		return nil
+	}
+	n.set("type", string(g.Type))
+	n.set("lineSize", itoa(g.LineSize))
+	n.set("lineColor", g.LineColor)
+	n.set("fillColor", g.FillColor)
+	n.setFloat("corner", g.Corner)
+	n.set("points", joinFloats(g.Points...))
+	if g.Sides > 0 {
+		n.set("sides", itoa(g.Sides))
+	}
+	return nil
+}
+
+// Image 描述图片（GImage），URL 引用已加载包中的图片资源
+type Image struct {
+	Object
+	URL string
+	// Color 是叠加颜色，"#rrggbb"
+	Color string
+	// Flip 是翻转方式："hz"、"vt" 或 "both"
+	Flip string
+}
+
+func (i *Image) lower(l *lowering, n *node) error {
+	n.name = "image"
+	item, err := l.c.resolve(i.URL, assets.PackageItemTypeImage)
+	if err != nil {
+		return err
+	}
+	l.setSource(n, item)
+	n.set("color", i.Color)
+	n.set("flip", i.Flip)
+	return nil
+}
+
+// Text 描述文本（GTextField）
+type Text struct {
+	Object
+	Text string
+	// Font 是字体名称或位图字体的 ui:// 地址
+	Font     string
+	FontSize int
+	// Color 是文字颜色，"#rrggbb"，默认为黑色
+	Color string
+	// Align、VAlign 是对齐方式："left"、"center"、"right" 和 "top"、"middle"、"bottom"
+	Align  string
+	VAlign string
+	// AutoSize 是自动尺寸："none"、"both"（默认）、"height"、"shrink"、"ellipsis"
+	AutoSize    string
+	Bold        bool
+	Italic      bool
+	Underline   bool
+	SingleLine  bool
+	UBB         bool
+	StrokeColor string
+	StrokeSize  float32
+}
+
+func (t *Text) lower(l *lowering, n *node) error {
+	n.name = "text"
+	n.set("text", t.Text)
+	n.set("font", t.Font)
+	if t.FontSize > 0 {
+		n.set("fontSize", itoa(t.FontSize))
+	}
+	n.set("color", t.Color)
+	n.set("align", t.Align)
+	n.set("vAlign", t.VAlign)
+	n.set("autoSize", t.AutoSize)
+	n.setBool("bold", t.Bold)
+	n.setBool("italic", t.Italic)
+	n.setBool("underline", t.Underline)
+	n.setBool("singleLine", t.SingleLine)
+	n.setBool("ubb", t.UBB)
+	if t.StrokeColor != "" {
+		n.set("strokeColor", t.StrokeColor)
+		n.setFloat("strokeSize", t.StrokeSize)
+	}
+	return nil
+}
+
+// RichText 描述富文本（GRichTextField），Text 为 HTML 或 UBB 格式
+type RichText struct {
+	Text
+}
+
+func (t *RichText) lower(l *lowering, n *node) error {
+	if err := t.Text.lower(l, n); err != nil {
+		return err
+	}
+	n.name = "richtext"
+	return nil
+}
+
+// InputText 描述输入框（GTextInput）
+type InputText struct {
+	Text
+	Prompt    string
+	Restrict  string
+	MaxLength int
+	Password  bool
+}
+
+func (t *InputText) lower(l *lowering, n *node) error {
+	if err := t.Text.lower(l, n); err != nil {
+		return err
+	}
+	n.setBool("input", true)
+	n.set("prompt", t.Prompt)
+	n.set("restrict", t.Restrict)
+	if t.MaxLength > 0 {
+		n.set("maxLength", itoa(t.MaxLength))
+	}
+	n.setBool("password", t.Password)
+	return nil
+}
+
+// Loader 描述装载器（GLoader），URL 可以是 ui:// 地址或外部资源地址
+type Loader struct {
+	Object
+	URL    string
+	Align  string
+	VAlign string
+	// Fill 是填充方式："scale"、"scaleMatchHeight"、"scaleMatchWidth"、"scaleFree"、"scaleNoBorder"
+	Fill     string
+	AutoSize bool
+	Color    string
+}
+
+func (ld *Loader) lower(l *lowering, n *node) error {
+	n.name = "loader"
+	n.set("url", ld.URL)
+	n.set("align", ld.Align)
+	n.set("vAlign", ld.VAlign)
+	n.set("fill", ld.Fill)
+	n.setBool("autoSize", ld.AutoSize)
+	n.set("color", ld.Color)
+	return nil
+}
+
+// List 描述列表（GList）。列表项使用 Component 规格或 URL 引用的组件，未指定时使用 DefaultItem
+type List struct {
+	Object
+	// Layout 是布局方式："column"（默认）、"row"、"flow_hz"、"flow_vt"、"pagination"
+	Layout string
+	// SelectionMode 是选择模式："single"（默认）、"multiple"、"multipleSingleClick"、"none"
+	SelectionMode string
+	Align         string
+	VAlign        string
+	LineGap       int
+	ColGap        int
+	LineItemCount int
+	Overflow      Overflow
+	Scroll        ScrollType
+	// DefaultItem、DefaultItemURL 是默认的列表项资源
+	DefaultItem    *Component
+	DefaultItemURL string
+	Items          []ListItem
+	// SelectionController 是与选中项同步的控制器名称
+	SelectionController string
+}
+
+// ListItem 是列表在构建时创建的一项
+type ListItem struct {
+	// Component、URL 覆盖列表的默认项资源
+	Component *Component
+	URL       string
+	Name      string
+	Title     string
+	Icon      string
+}
+
+func (li *List) lower(l *lowering, n *node) error {
+	n.name = "list"
+	n.set("layout", li.Layout)
+	n.set("selectionMode", li.SelectionMode)
+	n.set("align", li.Align)
+	n.set("vAlign", li.VAlign)
+	if li.LineGap != 0 {
+		n.set("lineGap", itoa(li.LineGap))
+	}
+	if li.ColGap != 0 {
+		n.set("colGap", itoa(li.ColGap))
+	}
+	if li.LineItemCount > 0 {
+		n.set("lineItemCount", itoa(li.LineItemCount))
+	}
+	n.set("overflow", string(li.Overflow))
+	n.set("scroll", string(li.Scroll))
+	if li.SelectionController != "" {
+		if l.ctrls[li.SelectionController] == nil {
+			return l.errorf("controller %q not found", li.SelectionController)
+		}
+		n.set("selectionController", li.SelectionController)
+	}
+	def, err := l.itemURL(li.DefaultItem, li.DefaultItemURL)
+	if err != nil {
+		return err
+	}
+	n.set("defaultItem", def)
+	for _, item := range li.Items {
+		url, err := l.itemURL(item.Component, item.URL)
+		if err != nil {
+			return err
+		}
+		if url == "" && def == "" {
+			return l.errorf("list item %q has no resource and the list has no default item", item.Name)
+		}
+		in := n.add(newNode("item"))
+		in.set("url", url)
+		in.set("name", item.Name)
+		in.set("title", item.Title)
+		in.set("icon", item.Icon)
+	}
+	return nil
+}
+
+// Nested 描述普通组件的实例，组件由 Component 规格或 URL 指定
+type Nested struct {
+	Object
+	Component *Component
+	URL       string
+	// Pages 设置实例中控制器的初始页面：控制器名称 -> 页面名称。
+	// 没有同名页面时按页面序号（Component 规格）或页面 ID（URL 引用的组件）查找
+	Pages map[string]string
+}
+
+func (c *Nested) lower(l *lowering, n *node) error {
+	_, err := l.instance(n, c.Component, c.URL, c.Pages, 0)
+	return err
+}
+
+// Button 描述按钮实例（GButton），引用的组件必须带有 ButtonExtension 或是发布的按钮组件
+type Button struct {
+	Object
+	Component     *Component
+	URL           string
+	Pages         map[string]string
+	Title         string
+	SelectedTitle string
+	Icon          string
+	SelectedIcon  string
+	TitleColor    string
+	TitleFontSize int
+	Selected      bool
+	// Controller、Page 把按钮关联到所在组件的控制器：点击时切换到 Page，页面为 Page 时按钮为选中状态
+	Controller string
+	Page       string
+}
+
+func (b *Button) lower(l *lowering, n *node) error {
+	ext, err := l.instance(n, b.Component, b.URL, b.Pages, assets.ObjectTypeButton)
+	if err != nil {
+		return err
+	}
+	ext.set("title", b.Title)
+	ext.set("selectedTitle", b.SelectedTitle)
+	ext.set("icon", b.Icon)
+	ext.set("selectedIcon", b.SelectedIcon)
+	ext.set("titleColor", b.TitleColor)
+	if b.TitleFontSize > 0 {
+		ext.set("titleFontSize", itoa(b.TitleFontSize))
+	}
+	ext.setBool("checked", b.Selected)
+	if b.Controller != "" {
+		page, err := l.page(b.Controller, b.Page)
+		if err != nil {
+			return err
+		}
+		ext.set("controller", b.Controller)
+		ext.set("page", page)
+	}
+	return nil
+}
+
+// Label 描述标签实例（GLabel），引用的组件必须带有 LabelExtension 或是发布的标签组件
+type Label struct {
+	Object
+	Component     *Component
+	URL           string
+	Pages         map[string]string
+	Title         string
+	Icon          string
+	TitleColor    string
+	TitleFontSize int
+}
+
+func (lb *Label) lower(l *lowering, n *node) error {
+	ext, err := l.instance(n, lb.Component, lb.URL, lb.Pages, assets.ObjectTypeLabel)
+	if err != nil {
+		return err
+	}
+	ext.set("title", lb.Title)
+	ext.set("icon", lb.Icon)
+	ext.set("titleColor", lb.TitleColor)
+	if lb.TitleFontSize > 0 {
+		ext.set("titleFontSize", itoa(lb.TitleFontSize))
+	}
+	return nil
+}
+
+// ProgressBar 描述进度条实例（GProgressBar），Max 为 0 时视为 100
+type ProgressBar struct {
+	Object
+	Component *Component
+	URL       string
+	Pages     map[string]string
+	Value     int
+	Min       int
+	Max       int
+}
+
+func (p *ProgressBar) lower(l *lowering, n *node) error {
+	ext, err := l.instance(n, p.Component, p.URL, p.Pages, assets.ObjectTypeProgressBar)
+	if err != nil {
+		return err
+	}
+	setRange(ext, p.Value, p.Min, p.Max)
+	return nil
+}
+
+// Slider 描述滑动条实例（GSlider），Max 为 0 时视为 100
+type Slider struct {
+	Object
+	Component *Component
+	URL       string
+	Pages     map[string]string
+	Value     int
+	Min       int
+	Max       int
+}
+
+func (s *Slider) lower(l *lowering, n *node) error {
+	ext, err := l.instance(n, s.Component, s.URL, s.Pages, assets.ObjectTypeSlider)
+	if err != nil {
+		return err
+	}
+	setRange(ext, s.Value, s.Min, s.Max)
+	return nil
+}
+
+func setRange(n *node, value, min, max int) {
+	if max == 0 {
+		max = 100
+	}
+	n.set("value", itoa(value))
+	n.set("min", itoa(min))
+	n.set("max", itoa(max))
+}
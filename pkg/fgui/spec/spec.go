@@ -0,0 +1,194 @@
+// Package spec 以声明式的 Go 结构描述组件：嵌套的子对象、带页面的控制器、按页面设置的齿轮、
+// 按子对象名称指定的关联和动效，无需编辑器或 .fui 文件即可构建界面，便于工具和测试使用。
+//
+// 规格先转换为编辑器格式的组件 XML，再由 assets.CompileComponents 编译为内存中的包，
+// 因此 builder.Factory 构建出的 core/widgets 对象与从编辑器工程或发布包构建的完全一致。
+// 颜色、齿轮和动效的取值、关联的边等字符串属性使用与编辑器 XML 相同的格式。
+//
+// 用法：
+//
+//	root := &spec.Component{
+//		Name: "Dialog", Width: 400, Height: 300,
+//		Controllers: []spec.Controller{{Name: "state", Pages: []string{"normal", "busy"}}},
+//		Children: []spec.Child{
+//			&spec.Graph{Object: spec.Object{Name: "bg", Width: 400, Height: 300,
+//				Relations: []spec.Relation{{Sides: "width-width,height-height"}}},
+//				Type: spec.GraphRect, FillColor: "#333333"},
+//			&spec.Text{Object: spec.Object{Name: "title", X: 20, Y: 20}, Text: "Hello", FontSize: 24},
+//		},
+//	}
+//	comp, err := spec.Build(ctx, factory, root)
+package spec
+
+import "github.com/chslink/fairygui/pkg/fgui/assets"
+
+// Overflow 是组件或列表内容超出边界时的处理方式
+type Overflow string
+
+const (
+	OverflowVisible Overflow = "visible"
+	OverflowHidden  Overflow = "hidden"
+	OverflowScroll  Overflow = "scroll"
+)
+
+// ScrollType 是 OverflowScroll 时的滚动方向，默认为垂直
+type ScrollType string
+
+const (
+	ScrollHorizontal ScrollType = "horizontal"
+	ScrollVertical   ScrollType = "vertical"
+	ScrollBoth       ScrollType = "both"
+)
+
+// Component 描述一个组件资源。同一个 *Component 可以被多个子对象、列表项引用，编译为同一个资源
+type Component struct {
+	// Name 是资源名称，为空时使用 "Component<序号>"
+	Name          string
+	Width, Height int
+	Overflow      Overflow
+	Scroll        ScrollType
+	// Extension 使组件成为按钮、标签、进度条或滑动条，为 nil 时是普通组件
+	Extension   Extension
+	Controllers []Controller
+	Children    []Child
+	Transitions []Transition
+	// Data 是组件的自定义数据（编辑器中的 customData）
+	Data string
+}
+
+// Controller 描述一个控制器。页面 ID 按顺序生成，齿轮、按钮等通过页面名称引用页面，
+// 没有同名页面时按页面序号引用
+type Controller struct {
+	Name  string
+	Pages []string
+	// HomePage 是初始选中的页面序号
+	HomePage int
+}
+
+// Extension 是组件的扩展类型：*ButtonExtension、*LabelExtension、*ProgressBarExtension、*SliderExtension
+type Extension interface {
+	objectType() assets.ObjectType
+	node() *node
+}
+
+// ButtonMode 是按钮模式
+type ButtonMode string
+
+const (
+	ButtonCommon ButtonMode = "Common"
+	ButtonCheck  ButtonMode = "Check"
+	ButtonRadio  ButtonMode = "Radio"
+)
+
+// ButtonExtension 使组件成为按钮。按钮的状态由名为 "button" 的控制器表示
+// （页面 up、down、over、selectedOver），标题和图标显示在名为 title、icon 的子对象中
+type ButtonExtension struct {
+	Mode ButtonMode
+	// DownEffect 是按下效果："dark" 或 "scale"
+	DownEffect      string
+	DownEffectValue float32
+}
+
+func (e *ButtonExtension) objectType() assets.ObjectType { return assets.ObjectTypeButton }
+
+func (e *ButtonExtension) node() *node {
+	n := newNode("Button")
+	n.set("mode", string(e.Mode))
+	n.set("downEffect", e.DownEffect)
+	n.setFloat("downEffectValue", e.DownEffectValue)
+	return n
+}
+
+// LabelExtension 使组件成为标签，标题和图标显示在名为 title、icon 的子对象中
+type LabelExtension struct{}
+
+func (e *LabelExtension) objectType() assets.ObjectType { return assets.ObjectTypeLabel }
+
+func (e *LabelExtension) node() *node { return newNode("Label") }
+
+// ProgressBarExtension 使组件成为进度条，进度显示在名为 bar、bar_v 的子对象中
+type ProgressBarExtension struct {
+	// TitleType 是标题格式："percent"、"valueAndmax"、"value" 或 "max"
+	TitleType string
+	Reverse   bool
+}
+
+func (e *ProgressBarExtension) objectType() assets.ObjectType { return assets.ObjectTypeProgressBar }
+
+func (e *ProgressBarExtension) node() *node {
+	n := newNode("ProgressBar")
+	n.set("titleType", e.TitleType)
+	n.setBool("reverse", e.Reverse)
+	return n
+}
+
+// SliderExtension 使组件成为滑动条，滑块为名为 grip 的子对象
+type SliderExtension struct {
+	TitleType    string
+	Reverse      bool
+	WholeNumbers bool
+}
+
+func (e *SliderExtension) objectType() assets.ObjectType { return assets.ObjectTypeSlider }
+
+func (e *SliderExtension) node() *node {
+	n := newNode("Slider")
+	n.set("titleType", e.TitleType)
+	n.setBool("reverse", e.Reverse)
+	n.setBool("wholeNumbers", e.WholeNumbers)
+	return n
+}
+
+// TransitionType 是动效帧的类型
+type TransitionType string
+
+const (
+	TransitionXY          TransitionType = "XY"
+	TransitionSize        TransitionType = "Size"
+	TransitionScale       TransitionType = "Scale"
+	TransitionPivot       TransitionType = "Pivot"
+	TransitionAlpha       TransitionType = "Alpha"
+	TransitionRotation    TransitionType = "Rotation"
+	TransitionColor       TransitionType = "Color"
+	TransitionAnimation   TransitionType = "Animation"
+	TransitionVisible     TransitionType = "Visible"
+	TransitionSound       TransitionType = "Sound"
+	TransitionNested      TransitionType = "Transition"
+	TransitionShake       TransitionType = "Shake"
+	TransitionColorFilter TransitionType = "ColorFilter"
+	TransitionSkew        TransitionType = "Skew"
+	TransitionText        TransitionType = "Text"
+	TransitionIcon        TransitionType = "Icon"
+)
+
+// Transition 描述组件的一个动效
+type Transition struct {
+	Name     string
+	AutoPlay bool
+	// AutoPlayRepeat 是自动播放的次数，0 视为 1，-1 表示无限循环
+	AutoPlayRepeat int
+	AutoPlayDelay  float32
+	Items          []TransitionItem
+}
+
+// TransitionItem 是动效中的一帧。取值格式与编辑器相同：XY、Size、Pivot、Skew 为 "x,y"（"-" 表示该分量不变），
+// Scale 为 "sx,sy"，Alpha、Rotation 为数值，Color 为 "#rrggbb"，Visible 为 "true"/"false"，
+// Text、Icon 为文本和地址
+type TransitionItem struct {
+	// Frame 是开始时间，单位为帧（每秒 24 帧），与编辑器时间轴一致
+	Frame int
+	Type  TransitionType
+	// Target 是目标子对象的名称，为空时作用于组件自身
+	Target string
+	Label  string
+	// Tween 为 true 时在 Duration 帧内从 Start 缓动到 End，否则在 Frame 时设置为 Value
+	Tween    bool
+	Duration int
+	// Ease 是缓动函数名称，例如 "Quad.Out"（默认）、"Linear"
+	Ease   string
+	Repeat int
+	Yoyo   bool
+	Value  string
+	Start  string
+	End    string
+}
@@ -0,0 +1,263 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/builder"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/widgets"
+)
+
+// buttonSpec 是一个最简单的按钮：背景图形在按下时变色，标题居中
+func buttonSpec() *Component {
+	return &Component{
+		Name: "Button", Width: 100, Height: 30,
+		Extension:   &ButtonExtension{Mode: ButtonCheck},
+		Controllers: []Controller{{Name: "button", Pages: []string{"up", "down", "over", "selectedOver"}}},
+		Children: []Child{
+			&Graph{
+				Object: Object{Name: "bg", Width: 100, Height: 30,
+					Relations: []Relation{{Sides: "width-width,height-height"}},
+					Gears: []Gear{{Type: GearColor, Controller: "button",
+						Values: map[string]string{"down": "#ff0000", "selectedOver": "#ff0000"}, Default: "#ffffff"}},
+				},
+				Type: GraphRect, FillColor: "#ffffff",
+			},
+			&Text{Object: Object{Name: "title", Width: 100, Height: 30}, AutoSize: "none", Align: "center", VAlign: "middle"},
+		},
+	}
+}
+
+func dialogSpec() *Component {
+	button := buttonSpec()
+	return &Component{
+		Name: "Dialog", Width: 400, Height: 300,
+		Controllers: []Controller{{Name: "state", Pages: []string{"normal", "busy"}}},
+		Children: []Child{
+			&Graph{Object: Object{Name: "bg", Width: 400, Height: 300,
+				Relations: []Relation{{Sides: "width-width,height-height"}}}, Type: GraphRect, FillColor: "#333333"},
+			&Text{Object: Object{Name: "title", X: 20, Y: 20,
+				Gears: []Gear{{Type: GearText, Controller: "state", Values: map[string]string{"busy": "Working"}}}},
+				Text: "Hello", FontSize: 24},
+			&Button{Object: Object{Name: "ok", X: 280, Y: 250,
+				Relations: []Relation{{Target: "bg", Sides: "right-right,bottom-bottom"}},
+				Gears:     []Gear{{Type: GearDisplay, Controller: "state", Pages: []string{"normal"}}}},
+				Component: button, Title: "OK", Controller: "state", Page: "busy"},
+			&List{Object: Object{Name: "list", X: 20, Y: 60, Width: 200, Height: 150}, DefaultItem: button,
+				Items: []ListItem{{Title: "a"}, {Title: "b"}, {Title: "c"}}},
+		},
+		Transitions: []Transition{{Name: "pop", Items: []TransitionItem{
+			{Type: TransitionScale, Target: "ok", Tween: true, Duration: 6, Start: "0,0", End: "1,1"},
+			{Frame: 6, Type: TransitionAlpha, Value: "0.5"},
+		}}},
+	}
+}
+
+func TestBuildSpec(t *testing.T) {
+	factory := builder.NewFactory(nil, nil)
+	comp, err := Build(context.Background(), factory, dialogSpec())
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if comp.Width() != 400 || comp.Height() != 300 {
+		t.Fatalf("unexpected size %vx%v", comp.Width(), comp.Height())
+	}
+	graph, _ := comp.GetChild("bg").Data().(*widgets.GGraph)
+	title, _ := comp.GetChild("title").Data().(*widgets.GTextField)
+	ok, _ := comp.GetChild("ok").Data().(*widgets.GButton)
+	list, _ := comp.GetChild("list").Data().(*widgets.GList)
+	if graph == nil || title == nil || ok == nil || list == nil {
+		t.Fatalf("unexpected children:\n%s", describe(comp))
+	}
+	if title.Text() != "Hello" || title.FontSize() != 24 || ok.Title() != "OK" || list.NumItems() != 3 {
+		t.Fatalf("unexpected properties: title %q/%d, button %q, %d list items", title.Text(), title.FontSize(), ok.Title(), list.NumItems())
+	}
+	if ok.Mode() != widgets.ButtonModeCheck || comp.Transition("pop") == nil {
+		t.Fatalf("expected the button template and the transition to be built")
+	}
+
+	// 齿轮和按钮都按页面名称关联到控制器
+	state := comp.GetController("state")
+	if ok.RelatedController() != state {
+		t.Fatalf("expected the button to be related to the state controller")
+	}
+	state.SetSelectedPageName("busy")
+	if title.Text() != "Working" || comp.GetChild("ok").Visible() {
+		t.Fatalf("expected gears to apply the busy page: title %q, ok visible %v", title.Text(), comp.GetChild("ok").Visible())
+	}
+	state.SetSelectedPageName("normal")
+	if title.Text() != "Hello" || !comp.GetChild("ok").Visible() {
+		t.Fatalf("expected gears to restore the normal page")
+	}
+
+	// 关联按子对象名称指定
+	comp.SetSize(500, 400)
+	if graph.Width() != 500 || graph.Height() != 400 {
+		t.Fatalf("expected bg to follow the component size, got %vx%v", graph.Width(), graph.Height())
+	}
+	if x, y := comp.GetChild("ok").X(), comp.GetChild("ok").Y(); x != 380 || y != 350 {
+		t.Fatalf("expected ok to stay at the bottom-right of bg, got %v,%v", x, y)
+	}
+}
+
+// Build 注册的包在根组件释放时注销
+func TestBuildUnregistersPackageOnDispose(t *testing.T) {
+	factory := builder.NewFactory(nil, nil)
+	comp, err := Build(context.Background(), factory, dialogSpec())
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	id := packageID(packageSeq.Load())
+	if assets.GetPackageByID(id) == nil {
+		t.Fatalf("expected package %s to be registered while the component is alive", id)
+	}
+	comp.Dispose()
+	if assets.GetPackageByID(id) != nil {
+		t.Fatalf("expected package %s to be unregistered after dispose", id)
+	}
+}
+
+// 未释放就丢弃的根组件被垃圾回收后，PackageManager.Collect 注销 Build 注册的包
+func TestBuildReleasesPackageOfCollectedComponent(t *testing.T) {
+	factory := builder.NewFactory(nil, nil)
+	if _, err := Build(context.Background(), factory, dialogSpec()); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	id := packageID(packageSeq.Load())
+	packages := factory.PackageManager()
+	if packages == nil || packages.LiveComponents(id) != 1 {
+		t.Fatalf("expected the built component to be tracked by the factory's package manager")
+	}
+	for i := 0; i < 10 && assets.GetPackageByID(id) != nil; i++ {
+		runtime.GC()
+		packages.Collect()
+	}
+	if assets.GetPackageByID(id) != nil {
+		t.Fatalf("expected package %s to be unregistered after its component was collected", id)
+	}
+}
+
+// Compile 的结果可以用 Factory.BuildComponent 多次构建，引用的组件在包中只有一份
+func TestCompileSharesComponents(t *testing.T) {
+	pkg, item, err := Compile(dialogSpec())
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if len(pkg.ID) != 8 || item.Name != "Dialog" || len(pkg.Items) != 2 {
+		t.Fatalf("unexpected package %s with %d items", pkg.ID, len(pkg.Items))
+	}
+	button := pkg.ItemByName("Button")
+	if button == nil || button.ObjectType != assets.ObjectTypeButton {
+		t.Fatalf("expected the button template to be compiled as a button")
+	}
+	factory := builder.NewFactory(nil, nil)
+	first, err := factory.BuildComponent(context.Background(), pkg, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	second, err := factory.BuildComponent(context.Background(), pkg, item)
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	if describe(first) != describe(second) {
+		t.Fatalf("expected identical builds")
+	}
+}
+
+// 引用已加载包中的组件时，构建结果与直接构建该组件相同
+func TestNestedURLMatchesFactoryBuild(t *testing.T) {
+	loader := assets.NewFileLoader(filepath.Join("..", "..", "..", "demo", "assets"))
+	factory := builder.NewFactoryWithLoader(nil, loader)
+	pkg, err := builder.NewPackageManager(factory, loader).AddPackage(context.Background(), "Bag")
+	if err != nil {
+		t.Skipf("demo assets unavailable: %v", err)
+	}
+	want, err := factory.BuildComponent(context.Background(), pkg, pkg.ItemByName("BagWin"))
+	if err != nil {
+		t.Fatalf("BuildComponent: %v", err)
+	}
+	var image *assets.PackageItem
+	for _, item := range pkg.Items {
+		if item.Type == assets.PackageItemTypeImage {
+			image = item
+			break
+		}
+	}
+
+	comp, err := Build(context.Background(), factory, &Component{
+		Name: "Host", Width: 800, Height: 600,
+		Children: []Child{
+			&Nested{Object: Object{Name: "win"}, URL: "ui://Bag/BagWin", Pages: map[string]string{"page": "1"}},
+			&Image{Object: Object{Name: "icon"}, URL: "ui://" + pkg.ID + image.ID},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	win := core.ComponentFrom(comp.GetChild("win"))
+	if win == nil {
+		t.Fatalf("expected a nested component")
+	}
+	// BagWin 的页面没有名称，按页面 ID 引用
+	if got := win.GetController("page").SelectedPageID(); got != "1" {
+		t.Fatalf("expected the instance page to be applied, got %q", got)
+	}
+	win.GetController("page").SetSelectedIndex(want.GetController("page").SelectedIndex())
+	want.SetName("win")
+	if got, want := describe(win), describe(want); got != want {
+		t.Fatalf("nested build differs:\n%s\nwant:\n%s", got, want)
+	}
+	img, _ := comp.GetChild("icon").Data().(*widgets.GImage)
+	if img == nil || comp.GetChild("icon").Width() != float64(image.Width) {
+		t.Fatalf("expected the image to be resolved from the Bag package")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	self := &Component{Name: "Loop"}
+	self.Children = []Child{&Nested{Component: self}}
+	for _, tc := range []struct {
+		root *Component
+		want string
+	}{
+		{&Component{Name: "A", Children: []Child{&Text{Object: Object{Relations: []Relation{{Target: "x"}}}}}},
+			`spec: A/n0: relation target "x" not found`},
+		{&Component{Name: "A", Controllers: []Controller{{Name: "c1", Pages: []string{"p"}}},
+			Children: []Child{&Text{Object: Object{Name: "t", Gears: []Gear{{Type: GearDisplay, Controller: "c1", Pages: []string{"q"}}}}}}},
+			`spec: A/t: controller "c1" has no page "q"`},
+		{&Component{Name: "A", Children: []Child{&Button{Component: &Component{Name: "B"}}}},
+			`spec: A/n0: component is not a Button`},
+		{&Component{Name: "A", Children: []Child{&Image{URL: "ui://NoSuchPackage/img"}}},
+			`resource ui://NoSuchPackage/img not found`},
+		{self, "spec: component Loop contains itself"},
+		{&Component{Name: "A", Transitions: []Transition{{Name: "t0", Items: []TransitionItem{{Target: "x"}}}}},
+			`spec: A: transition "t0" target "x" not found`},
+	} {
+		_, _, err := Compile(tc.root)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Compile(%s) error = %v, want %q", tc.root.Name, err, tc.want)
+		}
+	}
+}
+
+func describe(comp *core.GComponent) string {
+	var sb strings.Builder
+	var walk func(obj *core.GObject, depth int)
+	walk = func(obj *core.GObject, depth int) {
+		fmt.Fprintf(&sb, "%s%s %T %.0f,%.0f %.0fx%.0f %v\n", strings.Repeat("  ", depth), obj.Name(), obj.Data(),
+			obj.X(), obj.Y(), obj.Width(), obj.Height(), obj.Visible())
+		if c := core.ComponentFrom(obj); c != nil {
+			for _, child := range c.Children() {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(comp.GObject, 0)
+	return sb.String()
+}
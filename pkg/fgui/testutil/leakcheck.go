@@ -0,0 +1,54 @@
+// Package testutil 提供测试 FairyGUI 对象生命周期的辅助函数。
+package testutil
+
+import (
+	"testing"
+
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/tween"
+)
+
+// LeakCounts 是全局注册表中与对象生命周期相关的计数
+type LeakCounts struct {
+	// Tweens 是活动中的补间数量
+	Tweens int
+	// Tickers 是通过 core.RegisterTicker 注册的回调数量
+	Tickers int
+	// StageListeners 是注册在舞台根节点上的监听器数量，GRoot 未绑定舞台时为 0
+	StageListeners int
+	// ControllerListeners 是被监视的控制器上的选择监听器总数
+	ControllerListeners int
+}
+
+// CountLeaks 返回当前计数；ctrls 是需要额外监视的控制器，通常是被释放对象之外的控制器
+func CountLeaks(ctrls ...*core.Controller) LeakCounts {
+	counts := LeakCounts{
+		Tweens:  tween.ActiveCount(),
+		Tickers: core.TickerCount(),
+	}
+	if stage := core.Root().Stage(); stage != nil {
+		counts.StageListeners = stage.Root().ListenerCount()
+	}
+	for _, ctrl := range ctrls {
+		counts.ControllerListeners += ctrl.ListenerCount()
+	}
+	return counts
+}
+
+// LeakCheck 记录当前计数作为基线，返回的函数断言计数已回到基线。用法：
+//
+//	check := testutil.LeakCheck(t, parentCtrl)
+//	obj := build()
+//	... // 播放动效、拖动等
+//	obj.Dispose()
+//	check()
+func LeakCheck(t testing.TB, ctrls ...*core.Controller) func() {
+	t.Helper()
+	baseline := CountLeaks(ctrls...)
+	return func() {
+		t.Helper()
+		if got := CountLeaks(ctrls...); got != baseline {
+			t.Errorf("leak check: counts %+v, want baseline %+v", got, baseline)
+		}
+	}
+}
@@ -926,6 +926,19 @@ func GetTween(target any, prop ...string) *GTweener {
 	return globalManager.get(target, propKey)
 }
 
+// ActiveCount 返回尚未结束的补间数量，主要用于检查补间泄漏。
+func ActiveCount() int {
+	globalManager.mu.Lock()
+	defer globalManager.mu.Unlock()
+	count := 0
+	for _, tw := range globalManager.tweeners {
+		if tw != nil && !tw.killed {
+			count++
+		}
+	}
+	return count
+}
+
 func (m *manager) add(t *GTweener) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
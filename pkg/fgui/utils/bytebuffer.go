@@ -40,6 +40,11 @@ func (b *ByteBuffer) Len() int {
 	return len(b.data)
 }
 
+// Bytes returns the whole underlying data regardless of the read cursor.
+func (b *ByteBuffer) Bytes() []byte {
+	return b.data
+}
+
 // Pos returns the current read cursor.
 func (b *ByteBuffer) Pos() int {
 	return b.pos
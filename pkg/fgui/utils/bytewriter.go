@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// StringIndex assigns shared string table indices for ByteWriter.WriteS.
+// Equal strings share one entry; the resulting Table is what ByteBuffer.StringTable expects.
+type StringIndex struct {
+	Table []string
+	index map[string]uint16
+}
+
+// NewStringIndex creates an index pre-filled with table (the first occurrence of a
+// duplicated string wins), so existing indices stay valid.
+func NewStringIndex(table []string) *StringIndex {
+	s := &StringIndex{Table: append([]string(nil), table...), index: make(map[string]uint16, len(table))}
+	for i, v := range s.Table {
+		if _, ok := s.index[v]; !ok {
+			s.index[v] = uint16(i)
+		}
+	}
+	return s
+}
+
+// Index returns the table index of value, appending it when missing.
+func (s *StringIndex) Index(value string) uint16 {
+	if s.index == nil {
+		s.index = make(map[string]uint16)
+	}
+	if idx, ok := s.index[value]; ok {
+		return idx
+	}
+	idx := uint16(len(s.Table))
+	s.Table = append(s.Table, value)
+	s.index[value] = idx
+	return idx
+}
+
+// ByteWriter is the write side of ByteBuffer: big-endian values, shared-string
+// references and the block index tables read by ByteBuffer.Seek.
+type ByteWriter struct {
+	data []byte
+	// Strings receives the strings written by WriteS.
+	Strings *StringIndex
+}
+
+// NewByteWriter creates a writer whose WriteS calls use strings; nil allocates a new index.
+func NewByteWriter(strings *StringIndex) *ByteWriter {
+	if strings == nil {
+		strings = &StringIndex{}
+	}
+	return &ByteWriter{Strings: strings}
+}
+
+// Bytes returns the written data.
+func (w *ByteWriter) Bytes() []byte {
+	return w.data
+}
+
+// Len returns the number of bytes written.
+func (w *ByteWriter) Len() int {
+	return len(w.data)
+}
+
+// Buffer wraps the written data in a ByteBuffer sharing the writer's string table.
+func (w *ByteWriter) Buffer(version int) *ByteBuffer {
+	buf := NewByteBuffer(w.data)
+	buf.StringTable = w.Strings.Table
+	buf.Version = version
+	return buf
+}
+
+// WriteBool writes 1 for true and 0 for false.
+func (w *ByteWriter) WriteBool(v bool) {
+	if v {
+		w.data = append(w.data, 1)
+	} else {
+		w.data = append(w.data, 0)
+	}
+}
+
+// WriteUint8 writes an unsigned byte.
+func (w *ByteWriter) WriteUint8(v uint8) {
+	w.data = append(w.data, v)
+}
+
+// WriteInt8 writes a signed byte (the counterpart of ByteBuffer.ReadByte).
+func (w *ByteWriter) WriteInt8(v int8) {
+	w.data = append(w.data, uint8(v))
+}
+
+// WriteUint16 writes a big-endian uint16.
+func (w *ByteWriter) WriteUint16(v uint16) {
+	w.data = binary.BigEndian.AppendUint16(w.data, v)
+}
+
+// WriteInt16 writes a big-endian int16.
+func (w *ByteWriter) WriteInt16(v int16) {
+	w.WriteUint16(uint16(v))
+}
+
+// WriteUint32 writes a big-endian uint32.
+func (w *ByteWriter) WriteUint32(v uint32) {
+	w.data = binary.BigEndian.AppendUint32(w.data, v)
+}
+
+// WriteInt32 writes a big-endian int32.
+func (w *ByteWriter) WriteInt32(v int32) {
+	w.WriteUint32(uint32(v))
+}
+
+// WriteFloat32 writes a big-endian float32.
+func (w *ByteWriter) WriteFloat32(v float32) {
+	w.WriteUint32(math.Float32bits(v))
+}
+
+// WriteS writes a string table reference; the empty string is written as null,
+// matching the output of the FairyGUI editor.
+func (w *ByteWriter) WriteS(v string) {
+	if v == "" {
+		w.WriteUint16(indexNull)
+		return
+	}
+	w.WriteUint16(w.Strings.Index(v))
+}
+
+// WriteSPtr writes a string table reference distinguishing null (nil) from "".
+func (w *ByteWriter) WriteSPtr(v *string) {
+	switch {
+	case v == nil:
+		w.WriteUint16(indexNull)
+	case *v == "":
+		w.WriteUint16(indexEmpty)
+	default:
+		w.WriteUint16(w.Strings.Index(*v))
+	}
+}
+
+// WriteColor writes an 0xAARRGGBB colour as the R, G, B, A bytes read by ReadColor.
+func (w *ByteWriter) WriteColor(argb uint32) {
+	w.data = append(w.data, uint8(argb>>16), uint8(argb>>8), uint8(argb), uint8(argb>>24))
+}
+
+// WriteUTFString writes a uint16 length-prefixed UTF-8 string.
+func (w *ByteWriter) WriteUTFString(v string) {
+	w.WriteUint16(uint16(len(v)))
+	w.data = append(w.data, v...)
+}
+
+// WriteBytes appends raw bytes.
+func (w *ByteWriter) WriteBytes(p []byte) {
+	w.data = append(w.data, p...)
+}
+
+// MarkLength16 reserves an int16 length prefix; FillLength16 patches it with the
+// number of bytes written after the prefix.
+func (w *ByteWriter) MarkLength16() int {
+	pos := len(w.data)
+	w.WriteUint16(0)
+	return pos
+}
+
+// FillLength16 completes a prefix reserved by MarkLength16.
+func (w *ByteWriter) FillLength16(mark int) {
+	binary.BigEndian.PutUint16(w.data[mark:], uint16(len(w.data)-mark-2))
+}
+
+// MarkLength32 reserves an int32 length prefix, see FillLength32.
+func (w *ByteWriter) MarkLength32() int {
+	pos := len(w.data)
+	w.WriteUint32(0)
+	return pos
+}
+
+// FillLength32 completes a prefix reserved by MarkLength32.
+func (w *ByteWriter) FillLength32(mark int) {
+	binary.BigEndian.PutUint32(w.data[mark:], uint32(len(w.data)-mark-4))
+}
+
+// Block is an index table written by BeginBlock. Sections that are never
+// started keep a zero offset and ByteBuffer.Seek reports them as absent.
+type Block struct {
+	w     *ByteWriter
+	start int
+	short bool
+}
+
+// BeginBlock writes an index table for segCount sections at the current position.
+// short selects uint16 offsets (used for child objects) over uint32 offsets.
+func (w *ByteWriter) BeginBlock(segCount int, short bool) *Block {
+	b := &Block{w: w, start: len(w.data), short: short}
+	w.WriteUint8(uint8(segCount))
+	w.WriteBool(short)
+	for i := 0; i < segCount; i++ {
+		if short {
+			w.WriteUint16(0)
+		} else {
+			w.WriteUint32(0)
+		}
+	}
+	return b
+}
+
+// Start returns the position of the index table, the beginPos passed to ByteBuffer.Seek.
+func (b *Block) Start() int {
+	return b.start
+}
+
+// Section marks the current write position as the start of section index.
+func (b *Block) Section(index int) {
+	offset := len(b.w.data) - b.start
+	if b.short {
+		binary.BigEndian.PutUint16(b.w.data[b.start+2+index*2:], uint16(offset))
+	} else {
+		binary.BigEndian.PutUint32(b.w.data[b.start+2+index*4:], uint32(offset))
+	}
+}
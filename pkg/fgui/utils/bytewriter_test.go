@@ -0,0 +1,75 @@
+package utils
+
+import "testing"
+
+func TestByteWriterRoundTrip(t *testing.T) {
+	w := NewByteWriter(nil)
+	block := w.BeginBlock(3, false)
+	block.Section(0)
+	w.WriteBool(true)
+	w.WriteInt8(-3)
+	w.WriteInt16(-1234)
+	w.WriteInt32(-123456)
+	w.WriteFloat32(1.5)
+	w.WriteColor(0x80112233)
+	w.WriteUTFString("hello")
+	block.Section(2)
+	w.WriteS("a")
+	w.WriteS("")
+	empty := ""
+	w.WriteSPtr(&empty)
+	w.WriteSPtr(nil)
+	w.WriteS("a")
+	mark := w.MarkLength16()
+	w.WriteBytes([]byte{1, 2, 3})
+	w.FillLength16(mark)
+
+	buf := w.Buffer(7)
+	if !buf.Seek(block.Start(), 0) {
+		t.Fatalf("expected section 0")
+	}
+	if !buf.ReadBool() || buf.ReadByte() != -3 || buf.ReadInt16() != -1234 || buf.ReadInt32() != -123456 || buf.ReadFloat32() != 1.5 {
+		t.Fatalf("unexpected scalar values")
+	}
+	if c := buf.ReadColor(true); c != 0x80112233 {
+		t.Fatalf("expected color 0x80112233, got %#x", c)
+	}
+	if s := buf.ReadUTFString(); s != "hello" {
+		t.Fatalf("expected hello, got %q", s)
+	}
+	if buf.Seek(block.Start(), 1) {
+		t.Fatalf("section 1 was never started and should be absent")
+	}
+	if !buf.Seek(block.Start(), 2) {
+		t.Fatalf("expected section 2")
+	}
+	if s := buf.ReadS(); s == nil || *s != "a" {
+		t.Fatalf("expected a, got %v", s)
+	}
+	if s := buf.ReadS(); s != nil {
+		t.Fatalf("expected null for empty WriteS, got %q", *s)
+	}
+	if s := buf.ReadS(); s == nil || *s != "" {
+		t.Fatalf("expected empty string, got %v", s)
+	}
+	if s := buf.ReadS(); s != nil {
+		t.Fatalf("expected null, got %q", *s)
+	}
+	_ = buf.ReadS()
+	if n := buf.ReadInt16(); n != 3 {
+		t.Fatalf("expected length prefix 3, got %d", n)
+	}
+	if len(w.Strings.Table) != 1 {
+		t.Fatalf("expected shared string entry, got %v", w.Strings.Table)
+	}
+}
+
+func TestStringIndexKeepsExistingIndices(t *testing.T) {
+	idx := NewStringIndex([]string{"x", "y", "x"})
+	if idx.Index("y") != 1 || idx.Index("x") != 0 {
+		t.Fatalf("existing strings should keep their indices")
+	}
+	if idx.Index("z") != 3 || len(idx.Table) != 4 {
+		t.Fatalf("new strings should be appended, got %v", idx.Table)
+	}
+}
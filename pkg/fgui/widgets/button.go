@@ -73,6 +73,15 @@ func (b *GButton) ComponentRoot() *core.GComponent {
 	return b.GComponent
 }
 
+// HandleDispose 移除注册在关联控制器上的监听器，由 GObject.Dispose 调用。
+func (b *GButton) HandleDispose() {
+	if b.relatedController != nil && b.controllerListenerID != 0 {
+		b.relatedController.RemoveSelectionListener(b.controllerListenerID)
+	}
+	b.relatedController = nil
+	b.controllerListenerID = 0
+}
+
 // NewButton creates a button widget.
 func NewButton() *GButton {
 	btn := &GButton{
@@ -66,6 +66,16 @@ func (c *GComboBox) ComponentRoot() *core.GComponent {
 	return c.GComponent
 }
 
+// HandleDispose 释放下拉框组件。下拉框只在弹出时才加入显示列表，不随子对象一起释放。
+// 对应 TypeScript 版本 GComboBox.dispose()
+func (c *GComboBox) HandleDispose() {
+	if c.dropdown != nil {
+		c.dropdown.Dispose()
+		c.dropdown = nil
+	}
+	c.list = nil
+}
+
 // SetFactoryInternal 设置内部factory（仅供builder使用）
 // 采用public方法避免反射，符合Go最佳实践
 func (c *GComboBox) SetFactoryInternal(factory interface {
@@ -651,12 +661,6 @@ func (c *GComboBox) ConstructExtension(buf *utils.ByteBuffer) error {
 						if listObj := dropdownComp.ChildByName("list"); listObj != nil {
 							if list, ok := listObj.Data().(*GList); ok {
 								c.SetList(list)
-
-								// TypeScript版本第308行：给list添加点击事件监听
-								// 监听list的StateChanged事件（当选择改变时触发）
-								list.GComponent.GObject.On(laya.EventStateChanged, func(evt *laya.Event) {
-									c.onListItemClick(evt)
-								})
 							}
 						}
 
@@ -673,12 +677,7 @@ func (c *GComboBox) ConstructExtension(buf *utils.ByteBuffer) error {
 							dropdownComp.GObject.RemoveRelation(c.list.GComponent.GObject, core.RelationTypeWidth)
 						}
 
-						// TypeScript版本第316行：监听dropdown的UNDISPLAY事件
-						if disp := dropdownComp.DisplayObject(); disp != nil {
-							disp.Dispatcher().On(laya.EventUndisplay, func(evt *laya.Event) {
-								c.onPopupWinClosed(evt)
-							})
-						}
+						c.bindDropdownEvents()
 					}
 				}
 			} else {
@@ -714,9 +713,23 @@ func (c *GComboBox) bindEvents() {
 		obj.On(laya.EventMouseDown, func(evt *laya.Event) {
 			c.onMouseDown(evt)
 		})
+		obj.On(laya.EventKeyDown, c.onKeyDown)
 	})
 }
 
+// bindDropdownEvents 监听下拉列表的条目点击，以及下拉框的关闭和按键
+func (c *GComboBox) bindDropdownEvents() {
+	// TypeScript版本第308行：给list添加CLICK_ITEM监听，键盘 Enter/Space 激活条目时同样派发
+	if c.list != nil {
+		c.list.GComponent.GObject.On(laya.EventClickItem, c.onListItemClick)
+	}
+	// TypeScript版本第316行：监听dropdown的UNDISPLAY事件
+	if disp := c.dropdown.DisplayObject(); disp != nil {
+		disp.Dispatcher().On(laya.EventUndisplay, c.onPopupWinClosed)
+		disp.Dispatcher().On(laya.EventKeyDown, c.onDropdownKeyDown)
+	}
+}
+
 func (c *GComboBox) onRollOver(evt *laya.Event) {
 	c.over = true
 	if c.down || (c.dropdown != nil && c.dropdown.Parent() != nil) {
@@ -739,6 +752,7 @@ func (c *GComboBox) onMouseDown(evt *laya.Event) {
 	}
 
 	c.down = true
+	core.Root().SetFocus(c.GComponent.GObject)
 
 	// TypeScript版本第452行：调用GRoot.checkPopups关闭其他popup
 	if root := core.Root(); root != nil && c.GComponent != nil && c.GComponent.GObject != nil {
@@ -766,6 +780,10 @@ func (c *GComboBox) setState(state string) {
 // onPopupWinClosed 处理dropdown关闭事件
 // 对应TypeScript版本第411-416行
 func (c *GComboBox) onPopupWinClosed(evt *laya.Event) {
+	// 键盘焦点在下拉列表上时交还给组合框
+	if root := core.Root(); c.list != nil && root.Focus() == c.list.GComponent.GObject {
+		root.SetFocus(c.GComponent.GObject)
+	}
 	if c.over {
 		c.setState(buttonStateOver)
 	} else {
@@ -773,7 +791,7 @@ func (c *GComboBox) onPopupWinClosed(evt *laya.Event) {
 	}
 }
 
-// onListItemClick 处理列表项点击事件（通过StateChanged事件）
+// onListItemClick 处理列表项点击事件（CLICK_ITEM）
 // 对应TypeScript版本第418-429行的CLICK_ITEM处理逻辑
 func (c *GComboBox) onListItemClick(evt *laya.Event) {
 	if c == nil || c.list == nil {
@@ -893,5 +911,38 @@ func (c *GComboBox) showDropdown() {
 	core.Root().TogglePopup(c.dropdown.GObject, c.GComponent.GObject, core.PopupDirection(c.popupDirection))
 	if c.dropdown.Parent() != nil {
 		c.setState(buttonStateDown)
+		// 下拉列表获得键盘焦点，方向键在列表中移动选择
+		core.Root().SetFocus(c.list.GComponent.GObject)
+	}
+}
+
+// onKeyDown 组合框持有焦点时，方向键上下、Enter 或 Space 打开下拉框并选中当前项
+func (c *GComboBox) onKeyDown(evt *laya.Event) {
+	ke, ok := evt.Data.(laya.KeyboardEvent)
+	if !ok || core.Root().Focus() != c.GComponent.GObject {
+		return
+	}
+	switch ke.Code {
+	case laya.KeyCodeDown, laya.KeyCodeUp, laya.KeyCodeEnter, laya.KeyCodeSpace:
+	default:
+		return
+	}
+	evt.StopPropagation()
+	if c.dropdown == nil || c.dropdown.Parent() != nil {
+		return
+	}
+	c.showDropdown()
+	if c.list != nil && c.dropdown.Parent() != nil && c.selectedIndex >= 0 && c.selectedIndex < c.list.keyItemCount() {
+		c.list.selectByKey(c.selectedIndex, false)
+	}
+}
+
+// onDropdownKeyDown 处理下拉列表没有处理的按键：Escape 关闭下拉框，不改变选择
+func (c *GComboBox) onDropdownKeyDown(evt *laya.Event) {
+	ke, ok := evt.Data.(laya.KeyboardEvent)
+	if !ok || ke.Code != laya.KeyCodeEscape || c.dropdown == nil || c.dropdown.Parent() == nil {
+		return
 	}
+	evt.StopPropagation()
+	core.Root().HidePopup(c.dropdown.GObject)
 }
@@ -0,0 +1,90 @@
+package widgets
+
+import (
+	"testing"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+	"github.com/chslink/fairygui/pkg/fgui/testutil"
+)
+
+func TestDisposeReleasesWidgetRegistrations(t *testing.T) {
+	prev := core.Root().Stage()
+	core.Root().AttachStage(laya.NewStage(400, 300))
+	defer core.Root().AttachStage(prev)
+
+	host := core.NewGComponent()
+	ctrl := core.NewController("c1")
+	ctrl.SetPages([]string{"0", "1"}, []string{"a", "b"})
+	host.AddController(ctrl)
+	check := testutil.LeakCheck(t, ctrl)
+
+	panel := core.NewGComponent()
+	host.AddChild(panel.GObject)
+
+	button := NewButton()
+	button.SetRelatedController(ctrl)
+	panel.AddChild(button.GObject)
+
+	// 与构建器一致，NewList、NewLoader 创建的对象由调用方设置 Data
+	list := NewList()
+	list.SetData(list)
+	list.SetSelectionController(ctrl)
+	panel.AddChild(list.GObject)
+
+	clip := NewMovieClip()
+	clip.SetPackageItem(&assets.PackageItem{Interval: 20, Frames: []*assets.MovieClipFrame{{}, {}}})
+	panel.AddChild(clip.GObject)
+
+	slider := NewSlider()
+	slider.registerStageDrag()
+	panel.AddChild(slider.GObject)
+
+	loader := NewLoader()
+	loader.SetData(loader)
+	content := core.NewGComponent()
+	loader.SetComponent(content)
+	panel.AddChild(loader.GObject)
+
+	combo := NewComboBox()
+	dropdown := core.NewGComponent()
+	combo.SetDropdownComponent(dropdown)
+	panel.AddChild(combo.GObject)
+
+	if counts := testutil.CountLeaks(ctrl); counts.Tickers == 0 || counts.StageListeners == 0 || counts.ControllerListeners == 0 {
+		t.Fatalf("expected the widgets to register tickers and listeners, got %+v", counts)
+	}
+
+	panel.Dispose()
+	check()
+	for name, obj := range map[string]*core.GObject{
+		"button": button.GObject, "list": list.GObject, "clip": clip.GObject,
+		"loader content": content.GObject, "dropdown": dropdown.GObject,
+	} {
+		if !obj.IsDisposed() {
+			t.Errorf("expected %s to be disposed", name)
+		}
+	}
+	// 释放后重新播放不会再注册 ticker
+	clip.SetPlaying(false)
+	clip.SetPlaying(true)
+	check()
+}
+
+func TestObjectPoolSkipsDisposedObjects(t *testing.T) {
+	pool := NewGObjectPool()
+	live, dead := core.NewGObject(), core.NewGObject()
+	live.SetName("item")
+	dead.SetName("item")
+	pool.ReturnObject(dead)
+	pool.ReturnObject(live)
+	dead.Dispose()
+	if got := pool.GetObject("item"); got != live {
+		t.Fatalf("expected the live object, got %v", got)
+	}
+	pool.ReturnObject(dead)
+	if pool.Count() != 0 {
+		t.Fatalf("expected disposed objects not to be pooled, count=%d", pool.Count())
+	}
+}
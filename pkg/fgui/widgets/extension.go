@@ -0,0 +1,151 @@
+package widgets
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/chslink/fairygui/pkg/fgui/assets"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// ExtensionFunc 创建自定义组件实例。返回值必须是内置控件（*GButton、*GLabel、*core.GComponent 等），
+// 或内嵌了内置控件指针的结构体指针，例如：
+//
+//	type ItemCell struct {
+//		*widgets.GButton
+//		count int
+//	}
+//
+//	widgets.SetExtension("ui://Bag/ItemCell", func() any {
+//		return &ItemCell{GButton: widgets.NewButton()}
+//	})
+//
+// 构建器照常配置内嵌的内置控件，自定义实例可通过 ExtensionOf 或 GObject.Extension 取回。
+// 自定义类型实现 ExtensionConstructor 或 AfterAdder 时，构建器调用自定义实现而不是内置控件的实现，
+// 需要保留内置行为时应在其中调用内嵌控件的同名方法（相当于 TypeScript 中的 super 调用）。
+type ExtensionFunc func() any
+
+var extensionRegistry = struct {
+	sync.RWMutex
+	byURL map[string]ExtensionFunc
+}{byURL: make(map[string]ExtensionFunc)}
+
+// SetExtension 将资源 URL 映射到自定义组件的构造函数。
+// url 可以是 "ui://包ID资源ID"、"ui://包名/资源名" 或 "包名/资源名"；包名不区分大小写。
+// 注册在创建对象时才解析，因此可以在加载包之前调用。fn 为 nil 时等同于 RemoveExtension。
+// 对应 TypeScript 版本的 UIObjectFactory.setExtension / setPackageItemExtension
+func SetExtension(url string, fn ExtensionFunc) error {
+	key, err := extensionKey(url)
+	if err != nil {
+		return err
+	}
+	extensionRegistry.Lock()
+	defer extensionRegistry.Unlock()
+	if fn == nil {
+		delete(extensionRegistry.byURL, key)
+	} else {
+		extensionRegistry.byURL[key] = fn
+	}
+	return nil
+}
+
+// RemoveExtension 移除 url 对应的自定义组件注册
+func RemoveExtension(url string) {
+	if key, err := extensionKey(url); err == nil {
+		extensionRegistry.Lock()
+		delete(extensionRegistry.byURL, key)
+		extensionRegistry.Unlock()
+	}
+}
+
+// ExtensionFor 返回资源注册的构造函数。依次查找资源自身及其分支主干资源的 ID 和名称 URL。
+// 对应 TypeScript 版本的 UIObjectFactory.resolvePackageItemExtension
+func ExtensionFor(item *assets.PackageItem) ExtensionFunc {
+	if item == nil || item.Owner == nil {
+		return nil
+	}
+	extensionRegistry.RLock()
+	defer extensionRegistry.RUnlock()
+	if len(extensionRegistry.byURL) == 0 {
+		return nil
+	}
+	for _, it := range []*assets.PackageItem{item, item.BranchSource()} {
+		if it == nil || it.Owner == nil {
+			continue
+		}
+		if fn := extensionRegistry.byURL["ui://"+it.Owner.ID+it.ID]; fn != nil {
+			return fn
+		}
+		if fn := extensionRegistry.byURL["ui://"+strings.ToLower(it.Owner.Name)+"/"+it.Name]; fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// ExtensionOf 返回对象的自定义组件实例，对象不是由扩展创建时返回 nil
+func ExtensionOf(obj *core.GObject) any {
+	if obj == nil {
+		return nil
+	}
+	return obj.Extension()
+}
+
+// newExtensionWidget 调用构造函数并返回其内嵌的内置控件，自定义实例记录在控件的 GObject 上
+func newExtensionWidget(item *assets.PackageItem, fn ExtensionFunc) interface{} {
+	ext := fn()
+	var widget any
+	if holder, ok := ext.(interface{ Data() any }); ok {
+		// 内置控件的构造函数都会把 Data 设为控件自身
+		widget = holder.Data()
+	}
+	obj := widgetObject(widget)
+	if obj == nil {
+		log.Printf("[widgets] extension for %s returned %T, which does not embed a built-in widget", item.Name, ext)
+		return nil
+	}
+	obj.SetExtension(ext)
+	return widget
+}
+
+// widgetObject 返回内置控件的 GObject
+func widgetObject(widget any) *core.GObject {
+	switch w := widget.(type) {
+	case *core.GComponent:
+		return w.GObject
+	case core.ComponentAccessor:
+		if root := w.ComponentRoot(); root != nil {
+			return root.GObject
+		}
+	case *GImage:
+		return w.GObject
+	case *GMovieClip:
+		return w.GObject
+	case *GRichTextField:
+		return w.GObject()
+	case *GTextInput:
+		return w.GObject
+	case *GTextField:
+		return w.GObject
+	case *GLoader:
+		return w.GObject
+	case *GGroup:
+		return w.GObject
+	case *GGraph:
+		return w.GObject
+	}
+	return nil
+}
+
+func extensionKey(url string) (string, error) {
+	body := strings.TrimPrefix(strings.TrimSpace(url), "ui://")
+	if idx := strings.Index(body, "/"); idx > 0 && idx < len(body)-1 {
+		return "ui://" + strings.ToLower(body[:idx]) + "/" + body[idx+1:], nil
+	}
+	if len(body) > 8 && !strings.Contains(body, "/") {
+		return "ui://" + body, nil
+	}
+	return "", fmt.Errorf("widgets: invalid extension url %q", url)
+}
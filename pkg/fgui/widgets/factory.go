@@ -46,7 +46,21 @@ func CreateWidget(meta *assets.ComponentChild) interface{} {
 }
 
 // CreateWidgetFromPackage attempts to instantiate a widget based on the package item's object type.
+// Items registered through SetExtension are created by their extension constructor instead.
 func CreateWidgetFromPackage(item *assets.PackageItem) interface{} {
+	if item == nil {
+		return nil
+	}
+	if fn := ExtensionFor(item); fn != nil {
+		if widget := newExtensionWidget(item, fn); widget != nil {
+			return widget
+		}
+	}
+	return CreateBuiltinWidget(item)
+}
+
+// CreateBuiltinWidget instantiates the built-in widget for the package item's object type, ignoring extensions.
+func CreateBuiltinWidget(item *assets.PackageItem) interface{} {
 	if item == nil {
 		return nil
 	}
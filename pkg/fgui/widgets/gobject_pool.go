@@ -33,16 +33,11 @@ func NewGObjectPoolWithCreator(creator ObjectCreator) *GObjectPool {
 }
 
 // Clear 清空对象池并释放所有对象
-// 注意：与TypeScript版本不同，Go版本GObject没有Dispose方法，所以这里只从父对象移除
 func (p *GObjectPool) Clear() {
 	for _, arr := range p.pool {
 		for _, obj := range arr {
 			if obj != nil {
-				// 从父对象移除
-				if parent := obj.Parent(); parent != nil {
-					parent.RemoveChild(obj)
-				}
-				// 注意：理想情况下应该调用Dispose方法，但当前GObject没有实现
+				obj.Dispose()
 			}
 		}
 	}
@@ -65,12 +60,16 @@ func (p *GObjectPool) GetObject(url string) *core.GObject {
 	}
 
 	arr := p.pool[url]
-	if len(arr) > 0 {
+	for len(arr) > 0 {
 		p.count--
 		// 从数组头部取出对象（与TypeScript的shift()行为一致）
 		obj := arr[0]
-		p.pool[url] = arr[1:]
-		return obj
+		arr = arr[1:]
+		p.pool[url] = arr
+		// 池中的对象可能已在外部被释放，跳过
+		if !obj.IsDisposed() {
+			return obj
+		}
 	}
 
 	// 池中没有可用对象，需要创建新对象
@@ -88,7 +87,7 @@ func (p *GObjectPool) GetObject(url string) *core.GObject {
 // 注意：TypeScript版本使用obj.resourceURL，但Go版本的GObject没有这个属性
 // 这里使用obj.Name()作为临时替代，实际应该在GObject中添加ResourceURL属性
 func (p *GObjectPool) ReturnObject(obj *core.GObject) {
-	if obj == nil {
+	if obj == nil || obj.IsDisposed() {
 		return
 	}
 
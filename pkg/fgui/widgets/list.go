@@ -108,6 +108,10 @@ type GList struct {
 	batchAdding bool
 	// 首次布局标志
 	boundsInitialized bool
+
+	// 键盘导航：Shift 扩展选择时的起点，以及派生控件（GTree）优先处理按键的钩子
+	keyAnchor int
+	keyHook   func(ke laya.KeyboardEvent) bool
 }
 
 // ComponentRoot exposes the embedded component for helpers.
@@ -118,6 +122,22 @@ func (l *GList) ComponentRoot() *core.GComponent {
 	return l.GComponent
 }
 
+// HandleDispose 移除选择控制器上的监听器，列表项作为子对象随组件一起释放。
+// 对象池由所有列表共享，池中的对象不属于本列表，因此不在这里清空。
+// 对应 TypeScript 版本 GList.dispose()
+func (l *GList) HandleDispose() {
+	if l.selectionCtrl != nil && l.ctrlListenerID != 0 {
+		l.selectionCtrl.RemoveSelectionListener(l.ctrlListenerID)
+	}
+	l.selectionCtrl = nil
+	l.ctrlListener = nil
+	l.ctrlListenerID = 0
+	l.itemHandlers = make(map[*core.GObject]laya.Listener)
+	l.virtualItems = nil
+	l.itemRenderer = nil
+	l.itemProvider = nil
+}
+
 // ListSelectionMode mirrors FairyGUI's list selection options.
 type ListSelectionMode int
 
@@ -187,6 +207,7 @@ func NewList() *GList {
 		GComponent:    core.NewGComponent(),
 		selected:      -1,
 		lastSelected:  -1,
+		keyAnchor:     -1,
 		selectionMode: ListSelectionModeSingle,
 		layout:        ListLayoutTypeSingleColumn,
 		align:         LoaderAlignLeft,
@@ -204,6 +225,7 @@ func NewList() *GList {
 	}
 	// 参考 TypeScript 原版：GList.ts 构造函数中设置 opaque=true
 	list.GComponent.SetOpaque(true)
+	list.bindKeyboard()
 	return list
 }
 
@@ -408,6 +430,8 @@ func (l *GList) attachItemClick(obj *core.GObject) {
 		index := l.indexOf(obj)
 		if index >= 0 {
 			l.handleItemClick(index)
+			// 对应 TypeScript 版本 GList.__clickItem 中派发的 Events.CLICK_ITEM
+			l.GComponent.GObject.Emit(laya.EventClickItem, obj)
 		}
 	}
 	l.itemHandlers[obj] = handler
@@ -566,14 +590,14 @@ func (l *GList) AddSelection(index int, scrollItToView bool) {
 		l.SetSelectedIndex(index)
 		// 单选模式下，SetSelectedIndex 内部不会触发滚动，需要这里处理
 		if scrollItToView {
-			l.ScrollToView(index)
+			l.ScrollToView(index, false)
 		}
 		return
 	}
 
 	// 对应 TypeScript: if (scrollItToView) this.scrollToView(index)
 	if scrollItToView {
-		l.ScrollToView(index)
+		l.ScrollToView(index, false)
 	}
 
 	if l.selectedSet == nil {
@@ -587,10 +611,11 @@ func (l *GList) AddSelection(index int, scrollItToView bool) {
 	l.updateSelection(set, index, true)
 }
 
-// ScrollToView scrolls the list to make the specified item visible.
+// ScrollToView scrolls the list to make the specified item visible, animating
+// the scroll when ani is true.
 // 对应 TypeScript 版本的 scrollToView(index: number, ani?: boolean, setFirst?: boolean)
-// 当前简化版本不支持 ani 和 setFirst 参数
-func (l *GList) ScrollToView(index int) {
+// 当前简化版本不支持 setFirst 参数
+func (l *GList) ScrollToView(index int, ani bool) {
 	if l == nil {
 		return
 	}
@@ -703,12 +728,12 @@ func (l *GList) ScrollToView(index int) {
 		pane := l.GComponent.ScrollPane()
 		if pane != nil {
 			log.Printf("📍 ScrollToView: scrolling to index=%d, rect=(%.0f,%.0f,%.0f,%.0f)", index, x, y, width, height)
-			pane.ScrollToRect(x, y, width, height, false)
+			pane.ScrollToRect(x, y, width, height, ani)
 		}
 	} else {
 		// 非虚拟列表：使用现有的 scrollItemToView 方法
 		// 对应 TypeScript GList.ts:901-906
-		l.scrollItemToView(index)
+		l.scrollItemToView(index, ani)
 	}
 }
 
@@ -742,7 +767,7 @@ func (l *GList) handleItemClick(index int) {
 		return
 	}
 	if l.scrollToView {
-		l.scrollItemToView(index)
+		l.scrollItemToView(index, true)
 	}
 	switch l.selectionMode {
 	case ListSelectionModeNone:
@@ -784,7 +809,7 @@ func (l *GList) clearSelection(notify bool) {
 	l.updateSelection(nil, -1, notify)
 }
 
-func (l *GList) scrollItemToView(index int) {
+func (l *GList) scrollItemToView(index int, ani bool) {
 	if l == nil || index < 0 || index >= len(l.items) {
 		return
 	}
@@ -812,7 +837,7 @@ func (l *GList) scrollItemToView(index int) {
 	if height <= 0 {
 		height = l.GComponent.Height()
 	}
-	pane.ScrollToRect(item.X(), item.Y(), width, height, true)
+	pane.ScrollToRect(item.X(), item.Y(), width, height, ani)
 }
 
 func (l *GList) copySelectionSet() map[int]struct{} {
@@ -974,6 +999,20 @@ func (l *GList) Layout() ListLayoutType {
 	return l.layout
 }
 
+// SetLayout 设置列表布局类型并重新排列列表项。
+// 对应 TypeScript 版本 GList.layout
+func (l *GList) SetLayout(value ListLayoutType) {
+	if l == nil {
+		return
+	}
+	value = clampListLayout(value)
+	if l.layout == value {
+		return
+	}
+	l.layout = value
+	l.setBoundsChangedFlag()
+}
+
 // Align 返回水平对齐方式。
 func (l *GList) Align() LoaderAlign {
 	if l == nil {
@@ -982,6 +1021,16 @@ func (l *GList) Align() LoaderAlign {
 	return l.align
 }
 
+// SetAlign 设置内容窄于视口时的水平对齐方式。
+// 对应 TypeScript 版本 GList.align
+func (l *GList) SetAlign(value LoaderAlign) {
+	if l == nil || l.align == value {
+		return
+	}
+	l.align = value
+	l.setBoundsChangedFlag()
+}
+
 // VerticalAlign 返回垂直对齐方式。
 func (l *GList) VerticalAlign() LoaderAlign {
 	if l == nil {
@@ -990,31 +1039,180 @@ func (l *GList) VerticalAlign() LoaderAlign {
 	return l.verticalAlign
 }
 
+// SetVerticalAlign 设置内容矮于视口时的垂直对齐方式。
+// 对应 TypeScript 版本 GList.verticalAlign
+func (l *GList) SetVerticalAlign(value LoaderAlign) {
+	if l == nil || l.verticalAlign == value {
+		return
+	}
+	l.verticalAlign = value
+	l.setBoundsChangedFlag()
+}
+
 // LineGap 返回行间距。
 func (l *GList) LineGap() int {
 	return l.lineGap
 }
 
+// SetLineGap 设置行间距。对应 TypeScript 版本 GList.lineGap
+func (l *GList) SetLineGap(value int) {
+	if l == nil || l.lineGap == value {
+		return
+	}
+	l.lineGap = value
+	l.setBoundsChangedFlag()
+}
+
 // ColumnGap 返回列间距。
 func (l *GList) ColumnGap() int {
 	return l.columnGap
 }
 
+// SetColumnGap 设置列间距。对应 TypeScript 版本 GList.columnGap
+func (l *GList) SetColumnGap(value int) {
+	if l == nil || l.columnGap == value {
+		return
+	}
+	l.columnGap = value
+	l.setBoundsChangedFlag()
+}
+
 // LineCount 返回行数限制。
 func (l *GList) LineCount() int {
 	return l.lineCount
 }
 
+// SetLineCount 设置每列的行数（FlowVertical 与 Pagination 布局），0 表示按视口高度自动换列。
+// 对应 TypeScript 版本 GList.lineCount
+func (l *GList) SetLineCount(value int) {
+	if l == nil || l.lineCount == value {
+		return
+	}
+	l.lineCount = value
+	if l.layout == ListLayoutTypeFlowVertical || l.layout == ListLayoutTypePagination {
+		l.setBoundsChangedFlag()
+	}
+}
+
 // ColumnCount 返回列数限制。
 func (l *GList) ColumnCount() int {
 	return l.columnCount
 }
 
+// SetColumnCount 设置每行的列数（FlowHorizontal 与 Pagination 布局），0 表示按视口宽度自动换行。
+// 对应 TypeScript 版本 GList.columnCount
+func (l *GList) SetColumnCount(value int) {
+	if l == nil || l.columnCount == value {
+		return
+	}
+	l.columnCount = value
+	if l.layout == ListLayoutTypeFlowHorizontal || l.layout == ListLayoutTypePagination {
+		l.setBoundsChangedFlag()
+	}
+}
+
 // AutoResizeItem 表示是否自动调整子项尺寸。
 func (l *GList) AutoResizeItem() bool {
 	return l.autoResizeItem
 }
 
+// SetAutoResizeItem 设置单列、单行布局下是否把列表项拉伸到视口的宽度或高度。
+// 对应 TypeScript 版本 GList.autoResizeItem
+func (l *GList) SetAutoResizeItem(value bool) {
+	if l == nil || l.autoResizeItem == value {
+		return
+	}
+	l.autoResizeItem = value
+	l.setBoundsChangedFlag()
+}
+
+// setBoundsChangedFlag 在布局参数改变后重新排列列表项：虚拟列表重新计算每行项目数并刷新，
+// 普通列表重新计算子项位置。对应 TypeScript 版本 GList 各属性 setter 中的 setBoundsChangedFlag()
+func (l *GList) setBoundsChangedFlag() {
+	if l.virtual {
+		l.SetVirtualListChangedFlag(true)
+		return
+	}
+	l.updateBounds()
+}
+
+// ResizeToFit 调整列表的视口尺寸，使其正好显示前 itemCount 项；单列和水平流动布局调整高度，
+// 其他布局调整宽度。itemCount 超过项目数时按项目数计算，尺寸不小于 minSize。
+// 对应 TypeScript 版本 GList.resizeToFit()
+func (l *GList) ResizeToFit(itemCount int, minSize float64) {
+	if l == nil || l.GComponent == nil {
+		return
+	}
+	vertical := l.layout == ListLayoutTypeSingleColumn || l.layout == ListLayoutTypeFlowHorizontal
+	setView := func(size float64) {
+		if vertical {
+			l.setViewHeight(size)
+		} else {
+			l.setViewWidth(size)
+		}
+	}
+	if itemCount > l.NumItems() {
+		itemCount = l.NumItems()
+	}
+
+	if l.virtual {
+		l.CheckVirtualList()
+		if l.curLineItemCount <= 0 {
+			l.calculateLineItemCount()
+		}
+		lineCount := int(math.Ceil(float64(itemCount) / float64(l.curLineItemCount)))
+		gaps := float64(max(0, lineCount-1))
+		if vertical {
+			setView(float64(lineCount)*l.itemSize.Y + gaps*float64(l.lineGap))
+		} else {
+			setView(float64(lineCount)*l.itemSize.X + gaps*float64(l.columnGap))
+		}
+		return
+	}
+
+	l.updateBounds()
+	i := itemCount - 1
+	for ; i >= 0; i-- {
+		if obj := l.items[i]; obj != nil && (!l.foldInvisible || obj.Visible()) {
+			break
+		}
+	}
+	if i < 0 {
+		setView(minSize)
+		return
+	}
+	obj := l.items[i]
+	size := obj.X() + obj.Width()
+	if vertical {
+		size = obj.Y() + obj.Height()
+	}
+	setView(math.Max(size, minSize))
+}
+
+// setViewWidth 调整列表宽度，使视口宽度为 value，对应 TypeScript 版本 GComponent.viewWidth 的 setter
+func (l *GList) setViewWidth(value float64) {
+	margin := l.GComponent.Margin()
+	value += float64(margin.Left + margin.Right)
+	if pane := l.GComponent.ScrollPane(); pane != nil && !pane.Floating() {
+		if bar := pane.VtScrollBar(); bar != nil {
+			value += bar.Width()
+		}
+	}
+	l.SetSize(value, l.GComponent.Height())
+}
+
+// setViewHeight 调整列表高度，使视口高度为 value，对应 TypeScript 版本 GComponent.viewHeight 的 setter
+func (l *GList) setViewHeight(value float64) {
+	margin := l.GComponent.Margin()
+	value += float64(margin.Top + margin.Bottom)
+	if pane := l.GComponent.ScrollPane(); pane != nil && !pane.Floating() {
+		if bar := pane.HzScrollBar(); bar != nil {
+			value += bar.Height()
+		}
+	}
+	l.SetSize(l.GComponent.Width(), value)
+}
+
 // ChildrenRenderOrder 返回子对象渲染顺序。
 func (l *GList) ChildrenRenderOrder() ListChildrenRenderOrder {
 	return l.childrenOrder
@@ -2046,6 +2244,7 @@ func (l *GList) updateBounds() {
 		cw = math.Ceil(maxWidth)
 	}
 
+	l.handleAlign(cw, ch)
 	l.setBounds(0, 0, cw, ch)
 }
 
@@ -0,0 +1,342 @@
+package widgets
+
+import (
+	"github.com/chslink/fairygui/internal/compat/laya"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// ListArrowKey 表示列表方向键导航的方向，取值与 TypeScript 版本 GList.handleArrowKey 的 dir 参数一致。
+type ListArrowKey int
+
+const (
+	ListArrowKeyUp    ListArrowKey = 1
+	ListArrowKeyRight ListArrowKey = 3
+	ListArrowKeyDown  ListArrowKey = 5
+	ListArrowKeyLeft  ListArrowKey = 7
+)
+
+// bindKeyboard 注册键盘导航的监听：按下列表时获得焦点，持有焦点时处理按键。
+func (l *GList) bindKeyboard() {
+	obj := l.GComponent.GObject
+	obj.On(laya.EventMouseDown, l.onKeyboardMouseDown)
+	obj.On(laya.EventKeyDown, l.onKeyDown)
+}
+
+// onKeyboardMouseDown 让按下的列表获得键盘焦点；焦点已在列表内的对象（如条目中的输入框）上时保持不变
+func (l *GList) onKeyboardMouseDown(*laya.Event) {
+	if l.selectionMode == ListSelectionModeNone || l.hasFocusWithin() {
+		return
+	}
+	if input := FocusedInput(); input != nil {
+		input.LoseFocus()
+	}
+	core.Root().SetFocus(l.GComponent.GObject)
+}
+
+func (l *GList) hasFocusWithin() bool {
+	stage := core.Root().Stage()
+	if stage == nil {
+		return false
+	}
+	display := l.GComponent.GObject.DisplayObject()
+	for sprite := stage.Focus(); sprite != nil; sprite = sprite.Parent() {
+		if sprite == display {
+			return true
+		}
+	}
+	return false
+}
+
+// onKeyDown 处理冒泡到列表的按键；只有列表自身持有焦点时才导航，条目内输入框的按键不受影响
+func (l *GList) onKeyDown(evt *laya.Event) {
+	ke, ok := evt.Data.(laya.KeyboardEvent)
+	if !ok || core.Root().Focus() != l.GComponent.GObject {
+		return
+	}
+	if (l.keyHook != nil && l.keyHook(ke)) || l.HandleKey(ke) {
+		evt.StopPropagation()
+	}
+}
+
+// HandleKey 处理一次按键：方向键按布局移动选择，Home/End 选择首尾条目，PageUp/PageDown
+// 按视口中可见的行数翻页，Enter/Space 激活选中条目（派发 laya.EventClickItem）。
+// 多选模式下按住 Shift 把选择扩展到新条目；没有选中条目时方向键和翻页键选中第一个条目。
+// 列表持有键盘焦点时自动调用，手柄等输入可以映射为键码后直接调用。返回按键是否被处理。
+func (l *GList) HandleKey(ke laya.KeyboardEvent) bool {
+	if l == nil || l.selectionMode == ListSelectionModeNone {
+		return false
+	}
+	if l.virtual {
+		l.CheckVirtualList()
+	}
+	count := l.keyItemCount()
+	if count == 0 {
+		return false
+	}
+	current := l.keyIndex()
+	target := -1
+	switch ke.Code {
+	case laya.KeyCodeUp, laya.KeyCodeDown, laya.KeyCodeLeft, laya.KeyCodeRight:
+		target = 0
+		if current >= 0 {
+			target = l.arrowTarget(current, keyArrow(ke.Code))
+		}
+	case laya.KeyCodePageUp, laya.KeyCodePageDown:
+		target = 0
+		if current >= 0 {
+			target = l.pageTarget(current, ke.Code == laya.KeyCodePageDown)
+		}
+	case laya.KeyCodeHome:
+		target = 0
+	case laya.KeyCodeEnd:
+		target = count - 1
+	case laya.KeyCodeEnter, laya.KeyCodeSpace:
+		if current < 0 {
+			return false
+		}
+		if obj := l.itemObjectAt(current); obj != nil {
+			l.GComponent.GObject.Emit(laya.EventClickItem, obj)
+		}
+		return true
+	default:
+		return false
+	}
+	if target >= 0 {
+		l.selectByKey(target, ke.Modifiers.Shift)
+	}
+	return true
+}
+
+// HandleArrowKey 按方向移动选择并把新条目滚动到可见区域，返回移动后的选中索引；
+// 没有选中条目时不移动并返回 -1。
+// 对应 TypeScript 版本 GList.handleArrowKey
+func (l *GList) HandleArrowKey(dir ListArrowKey) int {
+	if l == nil {
+		return -1
+	}
+	if l.virtual {
+		l.CheckVirtualList()
+	}
+	index := l.keyIndex()
+	if index < 0 {
+		return -1
+	}
+	if target := l.arrowTarget(index, dir); target >= 0 {
+		l.selectByKey(target, false)
+	}
+	return l.keyIndex()
+}
+
+func keyArrow(code laya.KeyCode) ListArrowKey {
+	switch code {
+	case laya.KeyCodeUp:
+		return ListArrowKeyUp
+	case laya.KeyCodeRight:
+		return ListArrowKeyRight
+	case laya.KeyCodeDown:
+		return ListArrowKeyDown
+	default:
+		return ListArrowKeyLeft
+	}
+}
+
+// keyItemCount 返回可导航的条目数：虚拟列表为数据项数
+func (l *GList) keyItemCount() int {
+	if l.virtual {
+		return l.numItems
+	}
+	return len(l.items)
+}
+
+// keyIndex 返回键盘导航的当前条目，即选择的主条目；没有选择时返回 -1
+func (l *GList) keyIndex() int {
+	if l.selected >= 0 && l.selected < l.keyItemCount() {
+		return l.selected
+	}
+	return -1
+}
+
+// arrowTarget 返回从 index 按方向移动一格后的条目，不能移动时返回 -1。
+// 沿条目排列方向移动一个条目（可以换行），垂直于排列方向时移动到相邻行（列）中同一位置的条目。
+func (l *GList) arrowTarget(index int, dir ListArrowKey) int {
+	vertical := dir == ListArrowKeyUp || dir == ListArrowKeyDown
+	forward := dir == ListArrowKeyRight || dir == ListArrowKeyDown
+	crossLine := false
+	switch l.layout {
+	case ListLayoutTypeSingleColumn:
+		if !vertical {
+			return -1
+		}
+	case ListLayoutTypeSingleRow:
+		if vertical {
+			return -1
+		}
+	case ListLayoutTypeFlowHorizontal, ListLayoutTypePagination:
+		crossLine = vertical
+	case ListLayoutTypeFlowVertical:
+		crossLine = !vertical
+	}
+	if crossLine {
+		return l.lineTarget(index, forward)
+	}
+	if forward {
+		return l.wrapKeyIndex(index + 1)
+	}
+	return l.wrapKeyIndex(index - 1)
+}
+
+// wrapKeyIndex 检查导航目标是否有效；循环列表越界时绕回另一端
+func (l *GList) wrapKeyIndex(index int) int {
+	count := l.keyItemCount()
+	if index >= 0 && index < count {
+		return index
+	}
+	if l.virtual && l.loop && count > 0 {
+		return (index%count + count) % count
+	}
+	return -1
+}
+
+// lineTarget 返回相邻行（FlowVertical 为相邻列）中与 index 位置相同的条目；
+// 相邻行较短时返回该行的最后一个条目。
+func (l *GList) lineTarget(index int, forward bool) int {
+	count := l.keyItemCount()
+	if l.virtual {
+		// 虚拟列表每行条目数固定，分页布局中相邻页的同一列也相差一行
+		n := max(l.curLineItemCount, 1)
+		if !forward {
+			return l.wrapKeyIndex(index - n)
+		}
+		if index+n >= count && !l.loop && index/n < (count-1)/n {
+			return count - 1
+		}
+		return l.wrapKeyIndex(index + n)
+	}
+
+	// 非虚拟列表的条目尺寸可能不同，按条目坐标划分行，对应 TypeScript 版本比较 x/y 的做法
+	pos := func(i int) float64 {
+		if l.layout == ListLayoutTypeFlowVertical {
+			return l.items[i].X()
+		}
+		return l.items[i].Y()
+	}
+	start := index
+	for start > 0 && pos(start-1) == pos(index) {
+		start--
+	}
+	col := index - start
+	if forward {
+		next := index + 1
+		for next < count && pos(next) == pos(index) {
+			next++
+		}
+		if next >= count {
+			return -1
+		}
+		end := next
+		for end < count && pos(end) == pos(next) {
+			end++
+		}
+		return min(next+col, end-1)
+	}
+	if start == 0 {
+		return -1
+	}
+	prevStart := start - 1
+	for prevStart > 0 && pos(prevStart-1) == pos(start-1) {
+		prevStart--
+	}
+	return min(prevStart+col, start-1)
+}
+
+// pageTarget 返回沿滚动方向移动一屏可见行数后的条目
+func (l *GList) pageTarget(index int, forward bool) int {
+	horizontal := l.layout == ListLayoutTypeSingleRow || l.layout == ListLayoutTypeFlowVertical
+	var dir ListArrowKey
+	switch {
+	case horizontal && forward:
+		dir = ListArrowKeyRight
+	case horizontal:
+		dir = ListArrowKeyLeft
+	case forward:
+		dir = ListArrowKeyDown
+	default:
+		dir = ListArrowKeyUp
+	}
+	target := index
+	for i := l.linesInView(index, horizontal); i > 0; i-- {
+		next := l.arrowTarget(target, dir)
+		if next < 0 {
+			break
+		}
+		target = next
+	}
+	return target
+}
+
+// linesInView 返回视口中能完整显示的行（列）数，至少为 1
+func (l *GList) linesInView(index int, horizontal bool) int {
+	pane := l.GComponent.ScrollPane()
+	var view, size, gap float64
+	if horizontal {
+		view, size, gap = l.Width(), l.itemSize.X, float64(l.columnGap)
+		if pane != nil {
+			view = pane.ViewWidth()
+		}
+		if !l.virtual {
+			size = l.items[index].Width()
+		}
+	} else {
+		view, size, gap = l.Height(), l.itemSize.Y, float64(l.lineGap)
+		if pane != nil {
+			view = pane.ViewHeight()
+		}
+		if !l.virtual {
+			size = l.items[index].Height()
+		}
+	}
+	if size+gap <= 0 {
+		return 1
+	}
+	return max(int((view+gap)/(size+gap)), 1)
+}
+
+// selectByKey 选中键盘导航到的条目并滚动到可见区域。多选模式下 extend 为 true 时
+// 选中从起点（上一次不带 Shift 导航到的条目）到该条目的整段。
+func (l *GList) selectByKey(index int, extend bool) {
+	set := map[int]struct{}{index: {}}
+	multiple := l.selectionMode == ListSelectionModeMultiple || l.selectionMode == ListSelectionModeMultipleSingleClick
+	if extend && multiple {
+		if l.keyAnchor < 0 || !l.IsSelected(l.keyAnchor) {
+			l.keyAnchor = l.keyIndex()
+		}
+		if l.keyAnchor >= 0 {
+			for i := min(l.keyAnchor, index); i <= max(l.keyAnchor, index); i++ {
+				set[i] = struct{}{}
+			}
+		}
+	} else {
+		l.keyAnchor = index
+	}
+	l.updateSelection(set, index, true)
+	l.ScrollToView(index, false)
+}
+
+// itemObjectAt 返回条目对应的显示对象；虚拟列表中未渲染的条目返回 nil
+func (l *GList) itemObjectAt(index int) *core.GObject {
+	if !l.virtual {
+		if index >= 0 && index < len(l.items) {
+			return l.items[index]
+		}
+		return nil
+	}
+	if l.numItems <= 0 {
+		return nil
+	}
+	for i, ii := range l.virtualItems {
+		if ii != nil && ii.obj != nil && i%l.numItems == index {
+			return ii.obj
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package widgets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+	"github.com/chslink/fairygui/internal/compat/laya/testutil"
+	"github.com/chslink/fairygui/pkg/fgui/core"
+)
+
+// attachKeyboardStage 把根对象挂到新的舞台上，测试结束时恢复原来的舞台
+func attachKeyboardStage(t *testing.T) *testutil.StageEnv {
+	env := testutil.NewStageEnv(t, 400, 300)
+	prev := core.Root().Stage()
+	core.Root().AttachStage(env.Stage)
+	t.Cleanup(func() { core.Root().AttachStage(prev) })
+	return env
+}
+
+func pressKey(env *testutil.StageEnv, code laya.KeyCode, shift bool) {
+	mods := laya.KeyModifiers{Shift: shift}
+	env.AdvanceInput(16*time.Millisecond, laya.InputState{Keys: []laya.KeyboardEvent{
+		{Code: code, Down: true, Modifiers: mods},
+		{Code: code, Modifiers: mods},
+	}})
+}
+
+func TestListArrowKeysFollowLayout(t *testing.T) {
+	list := NewList()
+	list.SetSize(130, 200)
+	list.SetLayout(ListLayoutTypeFlowHorizontal)
+	for i := 0; i < 7; i++ {
+		list.AddItem(newSizedItem(40, 30))
+	}
+
+	if got := list.HandleArrowKey(ListArrowKeyDown); got != -1 {
+		t.Fatalf("expected no movement without a selection, got %d", got)
+	}
+	// 每行 3 个条目：[0 1 2] [3 4 5] [6]
+	list.SetSelectedIndex(1)
+	steps := []struct {
+		dir  ListArrowKey
+		want int
+	}{
+		{ListArrowKeyDown, 4},
+		{ListArrowKeyDown, 6}, // 下一行较短时停在行尾
+		{ListArrowKeyDown, 6},
+		{ListArrowKeyUp, 3},
+		{ListArrowKeyRight, 4},
+		{ListArrowKeyUp, 1},
+		{ListArrowKeyLeft, 0},
+		{ListArrowKeyLeft, 0},
+	}
+	for i, step := range steps {
+		if got := list.HandleArrowKey(step.dir); got != step.want {
+			t.Fatalf("step %d: expected index %d, got %d", i, step.want, got)
+		}
+	}
+
+	list.SetLayout(ListLayoutTypeSingleColumn)
+	list.SetSelectedIndex(2)
+	if got := list.HandleArrowKey(ListArrowKeyRight); got != 2 {
+		t.Fatalf("expected left/right to be ignored in a single column, got %d", got)
+	}
+	if got := list.HandleArrowKey(ListArrowKeyDown); got != 3 {
+		t.Fatalf("expected down to move to the next item, got %d", got)
+	}
+}
+
+func TestListKeyboardNavigationWithFocus(t *testing.T) {
+	env := attachKeyboardStage(t)
+	list := NewList()
+	list.SetSize(100, 100)
+	list.GComponent.EnsureScrollPane(core.ScrollTypeVertical)
+	list.SetSelectionMode(ListSelectionModeMultiple)
+	for i := 0; i < 10; i++ {
+		list.AddItem(newSizedItem(100, 30))
+	}
+	core.Root().AddChild(list.GComponent.GObject)
+	defer list.Dispose()
+	var clicked *core.GObject
+	list.GComponent.GObject.On(laya.EventClickItem, func(evt *laya.Event) {
+		clicked = evt.Data.(*core.GObject)
+	})
+
+	pressKey(env, laya.KeyCodeDown, false)
+	if len(list.SelectedIndices()) != 0 {
+		t.Fatalf("expected keys to be ignored before the list has focus")
+	}
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 50, Y: 95, Primary: true})
+	env.Advance(16*time.Millisecond, laya.MouseState{X: 50, Y: 95})
+	if core.Root().Focus() != list.GComponent.GObject || list.SelectedIndex() != 3 {
+		t.Fatalf("expected clicking to focus the list and select item 3, got %d", list.SelectedIndex())
+	}
+
+	pressKey(env, laya.KeyCodeDown, false)
+	pressKey(env, laya.KeyCodeDown, true)
+	pressKey(env, laya.KeyCodeDown, true)
+	if got := list.SelectedIndices(); len(got) != 3 || got[0] != 4 || got[2] != 6 || list.SelectedIndex() != 6 {
+		t.Fatalf("expected shift to extend the selection to 4..6, got %v", got)
+	}
+	if pane := list.GComponent.ScrollPane(); pane.PosY() != 110 {
+		t.Fatalf("expected item 6 to be scrolled into view, got %v", pane.PosY())
+	}
+	pressKey(env, laya.KeyCodeUp, true)
+	if got := list.SelectedIndices(); len(got) != 2 || got[1] != 5 {
+		t.Fatalf("expected shift+up to shrink the selection, got %v", got)
+	}
+
+	pressKey(env, laya.KeyCodeHome, false)
+	if got := list.SelectedIndices(); len(got) != 1 || got[0] != 0 {
+		t.Fatalf("expected home to select only the first item, got %v", got)
+	}
+	// 视口显示 3 行
+	pressKey(env, laya.KeyCodePageDown, false)
+	if list.SelectedIndex() != 3 {
+		t.Fatalf("expected page down to move by the visible lines, got %d", list.SelectedIndex())
+	}
+	pressKey(env, laya.KeyCodeEnd, false)
+	pressKey(env, laya.KeyCodePageUp, false)
+	if list.SelectedIndex() != 6 {
+		t.Fatalf("expected page up from the last item to select 6, got %d", list.SelectedIndex())
+	}
+	pressKey(env, laya.KeyCodeEnter, false)
+	if clicked != list.Items()[6] {
+		t.Fatalf("expected enter to activate the selected item")
+	}
+}
+
+func TestVirtualLoopListWrapsArrowKeys(t *testing.T) {
+	list := NewList()
+	list.SetSize(50, 90)
+	list.GComponent.EnsureScrollPane(core.ScrollTypeVertical)
+	list.SetDefaultItem("ui://test/item")
+	list.SetObjectCreator(sizedItemCreator{})
+	list.SetVirtualItemSize(&laya.Point{X: 50, Y: 30})
+	list.SetVirtual(true)
+	list.SetNumItems(5)
+
+	list.HandleKey(laya.KeyboardEvent{Code: laya.KeyCodeDown})
+	if list.keyIndex() != 0 {
+		t.Fatalf("expected the first key to select the first item, got %d", list.keyIndex())
+	}
+	if got := list.HandleArrowKey(ListArrowKeyUp); got != 0 {
+		t.Fatalf("expected a non-looping list to stop at the first item, got %d", got)
+	}
+	list.HandleKey(laya.KeyboardEvent{Code: laya.KeyCodeEnd})
+	if list.keyIndex() != 4 {
+		t.Fatalf("expected end to select the last data item, got %d", list.keyIndex())
+	}
+
+	list.SetLoop(true)
+	list.SetNumItems(5)
+	list.HandleKey(laya.KeyboardEvent{Code: laya.KeyCodeHome})
+	if got := list.HandleArrowKey(ListArrowKeyUp); got != 4 {
+		t.Fatalf("expected a looping list to wrap to the last item, got %d", got)
+	}
+	if got := list.HandleArrowKey(ListArrowKeyDown); got != 0 {
+		t.Fatalf("expected a looping list to wrap to the first item, got %d", got)
+	}
+}
+
+func TestComboBoxDropdownKeyboard(t *testing.T) {
+	env := attachKeyboardStage(t)
+	combo := NewComboBox()
+	combo.SetSize(100, 20)
+	list := NewList()
+	list.SetSize(100, 90)
+	list.SetDefaultItem("ui://test/item")
+	list.SetObjectCreator(sizedItemCreator{})
+	dropdown := core.NewGComponent()
+	dropdown.AddChild(list.GComponent.GObject)
+	combo.SetDropdownComponent(dropdown)
+	combo.SetList(list)
+	combo.bindDropdownEvents()
+	combo.bindEvents()
+	combo.SetItems([]string{"a", "b", "c"}, []string{"va", "vb", "vc"}, nil)
+	combo.SetSelectedIndex(1)
+	core.Root().AddChild(combo.GComponent.GObject)
+	defer combo.Dispose()
+	core.Root().SetFocus(combo.GComponent.GObject)
+
+	pressKey(env, laya.KeyCodeDown, false)
+	if dropdown.Parent() == nil || core.Root().Focus() != list.GComponent.GObject || list.SelectedIndex() != 1 {
+		t.Fatalf("expected the dropdown to open on the current item with keyboard focus")
+	}
+	pressKey(env, laya.KeyCodeDown, false)
+	if combo.SelectedIndex() != 1 || dropdown.Parent() == nil {
+		t.Fatalf("expected moving in the dropdown not to commit the selection")
+	}
+	pressKey(env, laya.KeyCodeEnter, false)
+	if combo.SelectedIndex() != 2 || combo.Value() != "vc" || dropdown.Parent() != nil {
+		t.Fatalf("expected enter to commit item 2 and close, got %d", combo.SelectedIndex())
+	}
+	if core.Root().Focus() != combo.GComponent.GObject {
+		t.Fatalf("expected focus to return to the combo box")
+	}
+
+	pressKey(env, laya.KeyCodeSpace, false)
+	pressKey(env, laya.KeyCodeUp, false)
+	pressKey(env, laya.KeyCodeEscape, false)
+	if combo.SelectedIndex() != 2 || dropdown.Parent() != nil || core.Root().Focus() != combo.GComponent.GObject {
+		t.Fatalf("expected escape to close the dropdown without changing the selection")
+	}
+}
@@ -322,3 +322,123 @@ func TestListSetVirtualPreservesEventListeners(t *testing.T) {
 		t.Fatalf("expected selected item to be itemB after disabling virtualization")
 	}
 }
+
+func newSizedItem(width, height float64) *core.GObject {
+	obj := core.NewGObject()
+	obj.SetSize(width, height)
+	return obj
+}
+
+func TestListLayoutSetters(t *testing.T) {
+	list := NewList()
+	list.SetSize(200, 200)
+	for i := 0; i < 5; i++ {
+		list.AddItem(newSizedItem(40, 30))
+	}
+	items := list.Items()
+	if items[2].Y() != 60 {
+		t.Fatalf("expected a single column by default, got y %v", items[2].Y())
+	}
+
+	list.SetLineGap(10)
+	if items[2].Y() != 80 {
+		t.Fatalf("expected the line gap to re-layout the items, got y %v", items[2].Y())
+	}
+	list.SetLayout(ListLayoutTypeFlowHorizontal)
+	list.SetColumnGap(5)
+	list.SetColumnCount(2)
+	if items[1].X() != 45 || items[2].X() != 0 || items[2].Y() != 40 || items[4].Y() != 80 {
+		t.Fatalf("expected two columns, got item1 %v item2 %v,%v", items[1].X(), items[2].X(), items[2].Y())
+	}
+	list.SetLayout(ListLayoutTypeSingleRow)
+	if items[4].X() != 180 || items[4].Y() != 80 {
+		t.Fatalf("expected a single row keeping item y, got %v,%v", items[4].X(), items[4].Y())
+	}
+	list.SetAutoResizeItem(true)
+	if items[0].Height() != 200 {
+		t.Fatalf("expected auto resize to stretch items to the view height, got %v", items[0].Height())
+	}
+}
+
+func TestListAlignOffset(t *testing.T) {
+	list := NewList()
+	list.SetSize(200, 100)
+	list.AddItem(newSizedItem(60, 30))
+	list.AddItem(newSizedItem(60, 30))
+
+	list.SetAlign(LoaderAlignCenter)
+	list.SetVerticalAlign(LoaderAlignBottom)
+	if offset := list.AlignOffset(); offset.X != 70 || offset.Y != 40 {
+		t.Fatalf("expected the content to be aligned center/bottom, got %+v", offset)
+	}
+	if pos := list.GComponent.Container().Position(); list.Items()[1].Y() != 30 || pos.X != 70 || pos.Y != 40 {
+		t.Fatalf("expected the container, not the items, to be offset, got %+v", pos)
+	}
+	list.AddItem(newSizedItem(60, 50))
+	if offset := list.AlignOffset(); offset.X != 70 || offset.Y != 0 {
+		t.Fatalf("expected no vertical offset once the content fills the view, got %+v", offset)
+	}
+}
+
+func TestListResizeToFit(t *testing.T) {
+	list := NewList()
+	list.SetSize(100, 300)
+	list.SetLineGap(2)
+	for i := 0; i < 10; i++ {
+		list.AddItem(newSizedItem(100, 20))
+	}
+
+	list.ResizeToFit(3, 0)
+	if list.Height() != 64 {
+		t.Fatalf("expected the list to fit three items, got %v", list.Height())
+	}
+	list.ResizeToFit(3, 100)
+	if list.Height() != 100 {
+		t.Fatalf("expected the min size to apply, got %v", list.Height())
+	}
+	list.ResizeToFit(100, 0)
+	if list.Height() != 218 {
+		t.Fatalf("expected the count to be limited to the items, got %v", list.Height())
+	}
+	list.Items()[9].SetVisible(false)
+	list.foldInvisible = true
+	list.ResizeToFit(10, 0)
+	if list.Height() != 196 {
+		t.Fatalf("expected folded items to be skipped, got %v", list.Height())
+	}
+
+	list.SetLayout(ListLayoutTypeSingleRow)
+	list.ResizeToFit(2, 0)
+	if list.Width() != 200 {
+		t.Fatalf("expected a single row to fit its width, got %v", list.Width())
+	}
+}
+
+type sizedItemCreator struct{}
+
+func (sizedItemCreator) CreateObject(string) *core.GObject { return newSizedItem(50, 30) }
+
+func TestVirtualListLayoutSettersAndResizeToFit(t *testing.T) {
+	list := NewList()
+	list.SetSize(200, 300)
+	list.GComponent.EnsureScrollPane(core.ScrollTypeVertical)
+	list.SetDefaultItem("ui://test/item")
+	list.SetObjectCreator(sizedItemCreator{})
+	list.SetVirtual(true)
+	list.SetVirtualItemSize(&laya.Point{X: 50, Y: 30})
+	list.SetNumItems(20)
+
+	list.SetLayout(ListLayoutTypeFlowHorizontal)
+	list.SetColumnCount(3)
+	if list.curLineItemCount != 3 {
+		t.Fatalf("expected three items per line, got %d", list.curLineItemCount)
+	}
+	list.SetLineGap(4)
+	if size := list.ScrollPane().ContentSize(); size.Y != 7*30+6*4 {
+		t.Fatalf("expected the content height to follow the line gap, got %v", size.Y)
+	}
+	list.ResizeToFit(7, 0)
+	if list.Height() != 3*30+2*4 {
+		t.Fatalf("expected the view to fit three lines, got %v", list.Height())
+	}
+}
@@ -309,12 +309,9 @@ func (l *GList) calculateContentSize() (float64, float64) {
 	return contentWidth, contentHeight
 }
 
-// handleAlign 处理对齐
+// handleAlign 内容小于视口时按对齐方式设置组件的对齐偏移，普通列表与虚拟列表共用。
+// 对应 TypeScript 版本 GList.handleAlign()
 func (l *GList) handleAlign(contentWidth, contentHeight float64) {
-	if !l.virtual {
-		return
-	}
-
 	var newOffsetX, newOffsetY float64
 
 	// 垂直对齐
@@ -339,11 +336,7 @@ func (l *GList) handleAlign(contentWidth, contentHeight float64) {
 		}
 	}
 
-	// 应用偏移
-	if newOffsetX != 0 || newOffsetY != 0 {
-		// 注意：laya.Sprite 没有 SetX/SetY 方法，需要使用 SetMatrix 或其他方式
-		// 这里暂时跳过偏移设置
-	}
+	l.GComponent.SetAlignOffset(newOffsetX, newOffsetY)
 }
 
 // handleScroll 处理滚动 - 对应 TypeScript 版本的 handleScroll
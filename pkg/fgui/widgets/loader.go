@@ -2,6 +2,7 @@ package widgets
 
 import (
 	"math"
+	"strings"
 
 	"github.com/chslink/fairygui/internal/compat/laya"
 	"github.com/chslink/fairygui/pkg/fgui/assets"
@@ -150,6 +151,19 @@ func (l *GLoader) SetComponent(comp *core.GComponent) {
 	l.updateLayout()
 }
 
+// HandleDispose 释放装载的组件和内部 MovieClip，它们直接挂在装载器的显示对象上，不是子对象。
+// 对应 TypeScript 版本 GLoader.dispose()
+func (l *GLoader) HandleDispose() {
+	if l.movieClip != nil {
+		l.movieClip.Dispose()
+		l.movieClip = nil
+	}
+	if l.component != nil {
+		l.component.Dispose()
+		l.component = nil
+	}
+}
+
 // Component returns the component rendered by this loader, if any.
 func (l *GLoader) Component() *core.GComponent {
 	return l.component
@@ -175,6 +189,58 @@ func (l *GLoader) URL() string {
 	return l.url
 }
 
+// Reload 重新解析并加载当前内容，用于分支（assets.SetBranch）切换后刷新
+// 由构建器直接设置 PackageItem 的加载器会按主干资源重新查找分支变体
+func (l *GLoader) Reload() {
+	if l == nil {
+		return
+	}
+	if strings.HasPrefix(l.url, "ui://") {
+		l.loadContent()
+		l.updateLayout()
+		return
+	}
+	item := l.packageItem.BranchSource()
+	if item == nil || item.Owner == nil {
+		return
+	}
+	if item.Branch() == l.packageItem {
+		return
+	}
+	url := l.url
+	l.clearContent()
+	l.loadFromPackage("ui://" + item.Owner.ID + item.ID)
+	l.url = url
+	if l.packageItem != nil {
+		l.SetScale9Grid(l.packageItem.Scale9Grid)
+		l.SetScaleByTile(l.packageItem.ScaleByTile)
+		l.SetTileGridIndice(l.packageItem.TileGridIndice)
+	}
+	l.updateLayout()
+}
+
+// ReloadLoaders 递归刷新 root 下所有 GLoader 的内容，返回刷新的加载器数量
+// 通常在 assets.SetBranch 之后调用
+func ReloadLoaders(root *core.GComponent) int {
+	if root == nil {
+		return 0
+	}
+	count := 0
+	for _, child := range root.Children() {
+		if child == nil {
+			continue
+		}
+		if loader, ok := child.Data().(*GLoader); ok && loader != nil {
+			loader.Reload()
+			count++
+			count += ReloadLoaders(loader.Component())
+			continue
+		}
+		count += ReloadLoaders(core.ComponentFrom(child))
+	}
+	return count
+}
+
 // SetPlaying toggles playback for content with frames.
 func (l *GLoader) SetPlaying(playing bool) {
 	l.playing = playing
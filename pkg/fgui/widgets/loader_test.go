@@ -2,6 +2,8 @@ package widgets
 
 import (
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/chslink/fairygui/pkg/fgui/assets"
@@ -143,3 +145,60 @@ func TestLoaderPlaybackAndColor(t *testing.T) {
 		t.Fatalf("unexpected fill amount %v", loader.FillAmount())
 	}
 }
+
+func TestReloadLoadersFollowsBranch(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "demo", "assets", "Basics.fui"))
+	if err != nil {
+		t.Skipf("demo assets unavailable: %v", err)
+	}
+	pkg, err := assets.ParsePackage(data, "Basics")
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	trunk, variant := pkg.ItemByID("rpmb1"), pkg.ItemByID("a7vt7m")
+	if trunk == nil || variant == nil {
+		t.Skip("Basics package missing the test images")
+	}
+	// 演示包没有分支，这里给图片添加 en 分支的变体
+	pkg.Branches = []string{"en"}
+	trunk.Branches = []string{variant.ID}
+	assets.RegisterPackage(pkg)
+	t.Cleanup(func() {
+		assets.SetBranch("")
+		assets.UnregisterPackage(pkg)
+	})
+
+	url := "ui://" + pkg.ID + trunk.ID
+	byURL := NewLoader()
+	byURL.SetData(byURL)
+	byURL.SetURL(url)
+	// 构建器直接设置资源的加载器
+	direct := NewLoader()
+	direct.SetData(direct)
+	direct.SetPackageItem(trunk)
+	nested := core.NewGComponent()
+	nested.AddChild(direct.GObject)
+	root := core.NewGComponent()
+	root.AddChild(byURL.GObject)
+	root.AddChild(nested.GObject)
+	if byURL.PackageItem() != trunk || direct.PackageItem() != trunk {
+		t.Fatalf("expected both loaders to show the trunk image")
+	}
+
+	assets.SetBranch("en")
+	if n := ReloadLoaders(root); n != 2 {
+		t.Fatalf("expected 2 loaders to be reloaded, got %d", n)
+	}
+	if byURL.PackageItem() != variant || direct.PackageItem() != variant {
+		t.Fatalf("expected both loaders to show the en variant, got %v and %v", byURL.PackageItem().ID, direct.PackageItem().ID)
+	}
+	if byURL.URL() != url {
+		t.Fatalf("expected the loader url to be kept, got %s", byURL.URL())
+	}
+
+	assets.SetBranch("")
+	byURL.Reload()
+	if byURL.PackageItem() != trunk {
+		t.Fatalf("expected the trunk image after switching back, got %v", byURL.PackageItem().ID)
+	}
+}
@@ -343,7 +343,7 @@ func (m *GMovieClip) frameCount() int {
 }
 
 func (m *GMovieClip) ensureTicker() {
-	if m == nil {
+	if m == nil || m.IsDisposed() {
 		return
 	}
 	if !m.playing || m.frameCount() == 0 {
@@ -360,6 +360,11 @@ func (m *GMovieClip) ensureTicker() {
 	})
 }
 
+// HandleDispose 取消 ticker 注册，由 GObject.Dispose 调用；释放后不再重新注册。
+func (m *GMovieClip) HandleDispose() {
+	m.stopTicker()
+}
+
 func (m *GMovieClip) stopTicker() {
 	if m == nil || m.tickerCancel == nil {
 		return
@@ -40,6 +40,11 @@ func (b *GScrollBar) ComponentRoot() *core.GComponent {
 	return b.GComponent
 }
 
+// HandleDispose 移除拖动时注册在舞台上的监听器，由 GObject.Dispose 调用。
+func (b *GScrollBar) HandleDispose() {
+	b.unregisterStageDrag()
+}
+
 // NewScrollBar 创建滚动条。
 func NewScrollBar() *GScrollBar {
 	comp := core.NewGComponent()
@@ -311,6 +316,8 @@ func (b *GScrollBar) onGripMouseDown(evt *laya.Event) {
 	// 与TypeScript版本一致：阻止事件冒泡
 	// TypeScript: evt.stopPropagation();
 	evt.StopPropagation()
+	// 拖动滑块期间外层 ScrollPane 不滚动
+	core.ClaimScrollGesture()
 
 	if b.grip == nil || b.target == nil {
 		return
@@ -52,6 +52,14 @@ func (s *GSlider) ComponentRoot() *core.GComponent {
 	return s.GComponent
 }
 
+// HandleDispose 移除拖动时注册在舞台上的监听器，由 GObject.Dispose 调用。
+func (s *GSlider) HandleDispose() {
+	if s.dragging {
+		s.dragging = false
+	}
+	s.unregisterStageDrag()
+}
+
 // NewSlider 构建默认 slider。
 func NewSlider() *GSlider {
 	comp := core.NewGComponent()
@@ -336,6 +344,8 @@ func (s *GSlider) applyTitle() {
 func (s *GSlider) onGripMouseDown(evt *laya.Event) {
 	// 与TypeScript版本一致：阻止事件冒泡
 	evt.StopPropagation()
+	// 拖动滑块期间外层 ScrollPane 不滚动
+	core.ClaimScrollGesture()
 
 	if s.gripObject == nil || s.GComponent == nil {
 		return
@@ -31,6 +31,7 @@ func NewTree() *GTree {
 	tree.rootNode.level = 0
 	tree.rootNode.expanded = true
 	tree.rootNode.setTree(tree)
+	list.keyHook = tree.handleKey
 	return tree
 }
 
@@ -542,6 +543,40 @@ func (t *GTree) handleCellClick(node *GTreeNode) {
 	}
 }
 
+// handleKey 处理树的左右方向键：左键折叠展开的文件夹或选中父节点，右键展开文件夹或选中第一个子节点。
+// 其他按键交给 GList 处理。
+func (t *GTree) handleKey(ke laya.KeyboardEvent) bool {
+	if ke.Code != laya.KeyCodeLeft && ke.Code != laya.KeyCodeRight {
+		return false
+	}
+	node := t.GetSelectedNode()
+	if node == nil {
+		return false
+	}
+	if ke.Code == laya.KeyCodeLeft {
+		if node.IsFolder() && node.Expanded() {
+			node.SetExpanded(false)
+		} else if node.parent != nil && node.parent != t.rootNode {
+			t.selectNodeByKey(node.parent)
+		}
+		return true
+	}
+	if node.IsFolder() {
+		if !node.Expanded() {
+			node.SetExpanded(true)
+		} else if len(node.children) > 0 {
+			t.selectNodeByKey(node.children[0])
+		}
+	}
+	return true
+}
+
+func (t *GTree) selectNodeByKey(node *GTreeNode) {
+	if index := t.indexOfNode(node); index >= 0 {
+		t.selectByKey(index, false)
+	}
+}
+
 func (t *GTree) getFolderEndIndex(startIndex, level int) int {
 	for i := startIndex + 1; i < len(t.items); i++ {
 		test := t.nodeFromIndex(i)
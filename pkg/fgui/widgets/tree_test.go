@@ -1,6 +1,10 @@
 package widgets
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/chslink/fairygui/internal/compat/laya"
+)
 
 func TestTreeBasicInsertionAndExpansion(t *testing.T) {
 	tree := NewTree()
@@ -61,3 +65,40 @@ func TestTreeSelection(t *testing.T) {
 		t.Fatalf("expected no selection, got %v", sel)
 	}
 }
+
+func TestTreeArrowKeysExpandAndCollapse(t *testing.T) {
+	tree := NewTree()
+	folder := NewTreeNode(true, "")
+	first := NewTreeNode(false, "")
+	second := NewTreeNode(false, "")
+	tail := NewTreeNode(false, "")
+	tree.RootNode().AddChild(folder)
+	tree.RootNode().AddChild(tail)
+	folder.AddChild(first)
+	folder.AddChild(second)
+	folder.SetExpanded(false)
+	tree.SelectNode(folder, false)
+
+	right := laya.KeyboardEvent{Code: laya.KeyCodeRight}
+	left := laya.KeyboardEvent{Code: laya.KeyCodeLeft}
+	if !tree.handleKey(right) || !folder.Expanded() || tree.GetSelectedNode() != folder {
+		t.Fatalf("expected right to expand the selected folder")
+	}
+	tree.handleKey(right)
+	if tree.GetSelectedNode() != first {
+		t.Fatalf("expected right on an expanded folder to select its first child")
+	}
+	tree.HandleKey(laya.KeyboardEvent{Code: laya.KeyCodeDown})
+	tree.handleKey(left)
+	if tree.GetSelectedNode() != folder {
+		t.Fatalf("expected left on a leaf to select its parent")
+	}
+	tree.handleKey(left)
+	if folder.Expanded() || tree.GetSelectedNode() != folder {
+		t.Fatalf("expected left to collapse the selected folder")
+	}
+	tree.HandleKey(laya.KeyboardEvent{Code: laya.KeyCodeDown})
+	if tree.GetSelectedNode() != tail {
+		t.Fatalf("expected down to skip the collapsed children")
+	}
+}